@@ -0,0 +1,230 @@
+package adapter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Hymn is the hymn on the catalogue
+type Hymn struct {
+	Number    int    `json:"number"`
+	Variant   string `json:"variant,omitempty"`
+	Title     string `json:"title"`
+	Lyric     string `json:"lyric"`
+	Music     string `json:"music"`
+	Verses    int    `json:"verses"`
+	Copyright string `json:"copyright,omitempty"`
+	// the number of the same hymn on the other hymnals (nr_number and be_number)
+	RefNR   int  `json:"nr,omitempty"`
+	RefBE   int  `json:"be,omitempty"`
+	ForKids bool `json:"forKids"`
+}
+
+// HymnDetail is the hymn with its variants and the footnotes
+type HymnDetail struct {
+	Hymn
+	// every variant of the same number, e.g. 3a and 3b. empty when the hymn has no variant
+	Variants       []string        `json:"variants"`
+	Footnotes      string          `json:"footnotes,omitempty"`
+	TitleFootnotes string          `json:"titleFootnotes,omitempty"`
+	VerseFootnotes []VerseFootnote `json:"verseFootnotes"`
+	// the same hymn on the other hymnals
+	References []HymnReference `json:"references,omitempty"`
+}
+
+type HymnReference struct {
+	Hymnal  string `json:"hymnal"`
+	Number  int    `json:"number"`
+	Variant string `json:"variant,omitempty"`
+	// empty when the hymn is not found on the hymnal
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+type VerseFootnote struct {
+	Verse    int    `json:"verse"`
+	Line     int    `json:"line"`
+	Marker   string `json:"marker"`
+	Footnote string `json:"footnote"`
+}
+
+func newHymn(data repository.HymnData, verses int) Hymn {
+	return Hymn{
+		Number:    data.Number,
+		Variant:   data.Variant.String,
+		Title:     data.Title,
+		Lyric:     data.Lyric,
+		Music:     data.Music,
+		Verses:    verses,
+		Copyright: data.Copyright.String,
+		RefNR:     int(data.RefNR.Int16),
+		RefBE:     int(data.RefBE.Int16),
+		ForKids:   data.IsForKids.Int16 > 0,
+	}
+}
+
+// CatalogueHTTP serves the list of the hymns and the detail of a hymn as the json
+type CatalogueHTTP struct {
+	Repo repository.Repository
+	// the references to the other hymnals are resolved when the hymnal is on the collections
+	Hymnal      string
+	Collections *repository.Collections
+}
+
+// NewCatalogue creates the handler of the list of the hymns
+func NewCatalogue(repo repository.Repository) *CatalogueHTTP {
+	return &CatalogueHTTP{
+		Repo: repo,
+	}
+}
+
+// NewCollectionCatalogue creates the handler of the hymnal, with the references to the other hymnals
+func NewCollectionCatalogue(collections *repository.Collections, hymnal *repository.Collection) *CatalogueHTTP {
+	return &CatalogueHTTP{
+		Repo:        hymnal.Repo,
+		Hymnal:      hymnal.Slug,
+		Collections: collections,
+	}
+}
+
+// ServeHTTP returns the list of the hymns, or the hymn when the number is on the path
+func (ch *CatalogueHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if raw := ps.ByName("number"); raw != "" {
+		ch.serveHymn(w, r, raw)
+		return
+	}
+
+	rows, err := ch.Repo.ListHymns(r.Context())
+	if err != nil {
+		log.Printf("[CatalogueHTTP] failed to list the hymns: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	result := make([]Hymn, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, newHymn(row.HymnData, row.TotalVerses))
+	}
+	writeJSON(w, result)
+}
+
+func (ch *CatalogueHTTP) serveHymn(w http.ResponseWriter, r *http.Request, raw string) {
+	num, variant, err := utils.ParseHymnWithVariant(raw)
+	if err != nil {
+		log.Printf("[CatalogueHTTP] invalid number: %v", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	variants, err := ch.Repo.GetHymnVariant(r.Context(), num)
+	if err != nil {
+		log.Printf("[CatalogueHTTP] failed to get the variants: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	// the same as the render, the number without the variant goes to the first variant
+	if variant == "" && len(variants) > 0 {
+		r.URL.Path += "a"
+		http.Redirect(w, r, r.URL.RequestURI(), http.StatusSeeOther)
+		return
+	}
+
+	var metadata *repository.HymnMetadata
+	if variant == "" {
+		metadata, err = ch.Repo.GetHymnMetaData(r.Context(), num)
+	} else {
+		metadata, err = ch.Repo.GetHymnMetaData(r.Context(), num, variant)
+	}
+	if errors.Is(err, repository.ErrHymnNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	} else if err != nil {
+		log.Printf("[CatalogueHTTP] failed to get the hymn: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	detail := newHymnDetail(metadata, variants)
+	if ch.Collections != nil {
+		references, err := ch.Collections.ResolveReferences(r.Context(), ch.Hymnal, metadata.HymnData)
+		if err != nil {
+			log.Printf("[CatalogueHTTP] failed to resolve the references: %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		for _, ref := range references {
+			detail.References = append(detail.References, HymnReference{
+				Hymnal:  ref.Hymnal,
+				Number:  ref.Number,
+				Variant: ref.Variant.String,
+				Title:   ref.Title,
+				URL:     fmt.Sprintf("/%s/hymns/%d%s", ref.Hymnal, ref.Number, ref.Variant.String),
+			})
+		}
+	}
+
+	writeJSON(w, detail)
+}
+
+func newHymnDetail(metadata *repository.HymnMetadata, variants []repository.HymnIndicator) HymnDetail {
+	result := HymnDetail{
+		Hymn:           newHymn(metadata.HymnData, metadata.TotalVerses()),
+		Variants:       []string{},
+		Footnotes:      metadata.Footnotes.String,
+		TitleFootnotes: metadata.TitleFootnotes.String,
+		VerseFootnotes: []VerseFootnote{},
+	}
+
+	for _, v := range variants {
+		result.Variants = append(result.Variants, strconv.Itoa(v.Number)+v.Variant.String)
+	}
+
+	for verse, lines := range metadata.VerseFootNotes {
+		for line, footnote := range lines {
+			result.VerseFootnotes = append(result.VerseFootnotes, VerseFootnote{
+				Verse:    verse,
+				Line:     line,
+				Marker:   footnote.FootnoteMarker.String,
+				Footnote: footnote.Footnote.String,
+			})
+		}
+	}
+	sort.Slice(result.VerseFootnotes, func(i, j int) bool {
+		if result.VerseFootnotes[i].Verse != result.VerseFootnotes[j].Verse {
+			return result.VerseFootnotes[i].Verse < result.VerseFootnotes[j].Verse
+		}
+		return result.VerseFootnotes[i].Line < result.VerseFootnotes[j].Line
+	})
+
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypes[FormatJSON])
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
@@ -0,0 +1,220 @@
+package adapter
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogueHTTP_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		path     string
+		number   string
+		initMock func(ctrl *gomock.Controller) *repository.MockRepository
+
+		wantStatus   int
+		wantBody     string
+		wantLocation string
+	}{
+		{
+			name: "list of the hymns",
+			path: "/kidung-jemaat/hymns",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().ListHymns(gomock.Any()).Return([]repository.HymnSummary{
+					{
+						HymnData: repository.HymnData{
+							HymnIndicator: repository.HymnIndicator{HymnID: 1, Number: 1},
+							Title:         "Haleluya, Pujilah",
+							Lyric:         "lyric",
+							Music:         "music",
+							RefNR:         sql.NullInt16{Int16: 5, Valid: true},
+							IsForKids:     sql.NullInt16{Int16: 1, Valid: true},
+						},
+						TotalVerses: 3,
+					},
+					{
+						HymnData: repository.HymnData{
+							HymnIndicator: repository.HymnIndicator{HymnID: 2, Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+							Title:         "Tiga",
+						},
+						TotalVerses: 1,
+					},
+				}, nil)
+				return repo
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `[{"number":1,"title":"Haleluya, Pujilah","lyric":"lyric","music":"music","verses":3,"nr":5,"forKids":true},` +
+				`{"number":3,"variant":"a","title":"Tiga","lyric":"","music":"","verses":1,"forKids":false}]`,
+		},
+		{
+			name: "failed to list the hymns",
+			path: "/kidung-jemaat/hymns",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().ListHymns(gomock.Any()).Return(nil, errors.New("database is locked"))
+				return repo
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "database is locked",
+		},
+		{
+			name:   "invalid number",
+			path:   "/kidung-jemaat/hymns/ab",
+			number: "ab",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				return repository.NewMockRepository(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name:   "the hymn",
+			path:   "/kidung-jemaat/hymns/1",
+			number: "1",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().GetHymnVariant(gomock.Any(), 1).Return([]repository.HymnIndicator{}, nil)
+				repo.EXPECT().GetHymnMetaData(gomock.Any(), 1).Return(&repository.HymnMetadata{
+					HymnData: repository.HymnData{
+						HymnIndicator: repository.HymnIndicator{HymnID: 1, Number: 1},
+						Title:         "Haleluya, Pujilah",
+						Footnotes:     sql.NullString{String: "the footnote", Valid: true},
+					},
+					Verse: map[int]repository.HymnVerse{2: {}, 3: {}},
+					VerseFootNotes: map[int]map[int]repository.VerseFootNotes{
+						3: {1: {FootnoteMarker: sql.NullString{String: "*", Valid: true}, Footnote: sql.NullString{String: "the third", Valid: true}}},
+						2: {4: {FootnoteMarker: sql.NullString{String: "a", Valid: true}, Footnote: sql.NullString{String: "the second", Valid: true}}},
+					},
+				}, nil)
+				return repo
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"number":1,"title":"Haleluya, Pujilah","lyric":"","music":"","verses":3,"forKids":false,` +
+				`"variants":[],"footnotes":"the footnote","verseFootnotes":[` +
+				`{"verse":2,"line":4,"marker":"a","footnote":"the second"},{"verse":3,"line":1,"marker":"*","footnote":"the third"}]}`,
+		},
+		{
+			name:   "the variant of the hymn",
+			path:   "/kidung-jemaat/hymns/3b",
+			number: "3b",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().GetHymnVariant(gomock.Any(), 3).Return([]repository.HymnIndicator{
+					{Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+					{Number: 3, Variant: sql.NullString{String: "b", Valid: true}},
+				}, nil)
+				repo.EXPECT().GetHymnMetaData(gomock.Any(), 3, "b").Return(&repository.HymnMetadata{
+					HymnData: repository.HymnData{
+						HymnIndicator: repository.HymnIndicator{HymnID: 4, Number: 3, Variant: sql.NullString{String: "b", Valid: true}},
+						Title:         "Tiga",
+					},
+				}, nil)
+				return repo
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"number":3,"variant":"b","title":"Tiga","lyric":"","music":"","verses":1,"forKids":false,` +
+				`"variants":["3a","3b"],"verseFootnotes":[]}`,
+		},
+		{
+			name:   "the hymn has variants",
+			path:   "/kidung-jemaat/hymns/3",
+			number: "3",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().GetHymnVariant(gomock.Any(), 3).Return([]repository.HymnIndicator{
+					{Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+				}, nil)
+				return repo
+			},
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/kidung-jemaat/hymns/3a",
+		},
+		{
+			name:   "hymn not found",
+			path:   "/kidung-jemaat/hymns/999",
+			number: "999",
+			initMock: func(ctrl *gomock.Controller) *repository.MockRepository {
+				repo := repository.NewMockRepository(ctrl)
+				repo.EXPECT().GetHymnVariant(gomock.Any(), 999).Return(nil, nil)
+				repo.EXPECT().GetHymnMetaData(gomock.Any(), 999).Return(nil, repository.ErrHymnNotFound)
+				return repo
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   repository.ErrHymnNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := NewCatalogue(tt.initMock(ctrl))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			ps := httprouter.Params{}
+			if tt.number != "" {
+				ps = append(ps, httprouter.Param{Key: "number", Value: tt.number})
+			}
+
+			ch.ServeHTTP(w, r, ps)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantLocation != "" {
+				assert.Equal(t, tt.wantLocation, w.Header().Get("Location"))
+				return
+			}
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestCatalogueHTTP_references(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	kj := repository.NewMockRepository(ctrl)
+	kj.EXPECT().GetHymnVariant(gomock.Any(), 5).Return([]repository.HymnIndicator{}, nil)
+	kj.EXPECT().GetHymnMetaData(gomock.Any(), 5).Return(&repository.HymnMetadata{
+		HymnData: repository.HymnData{
+			HymnIndicator: repository.HymnIndicator{HymnID: 5, Number: 5},
+			Title:         "Lima",
+			RefNR:         sql.NullInt16{Int16: 12, Valid: true},
+		},
+	}, nil)
+	nkb := repository.NewMockRepository(ctrl)
+	nkb.EXPECT().GetHymnMetaData(gomock.Any(), 12).Return(&repository.HymnMetadata{
+		HymnData: repository.HymnData{
+			HymnIndicator: repository.HymnIndicator{HymnID: 7, Number: 12, Variant: sql.NullString{String: "a", Valid: true}},
+			Title:         "Dua Belas",
+		},
+	}, nil)
+
+	collections, err := repository.NewCollections(
+		&repository.Collection{Slug: "kidung-jemaat", Repo: kj, References: map[string]string{repository.RefCodeNR: "nyanyikanlah-kidung-baru"}},
+		&repository.Collection{Slug: "nyanyikanlah-kidung-baru", Repo: nkb},
+	)
+	assert.NoError(t, err)
+	hymnal, _ := collections.Get("kidung-jemaat")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/kidung-jemaat/hymns/5", nil)
+	NewCollectionCatalogue(collections, hymnal).ServeHTTP(w, r, httprouter.Params{{Key: "number", Value: "5"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"number":5,"title":"Lima","lyric":"","music":"","verses":1,"nr":12,"forKids":false,`+
+		`"variants":[],"verseFootnotes":[],"references":[`+
+		`{"hymnal":"nyanyikanlah-kidung-baru","number":12,"variant":"a","title":"Dua Belas","url":"/nyanyikanlah-kidung-baru/hymns/12a"}]}`, w.Body.String())
+}
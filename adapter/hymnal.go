@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Hymnal is the configured hymnal
+type Hymnal struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// HymnalsHTTP serves the list of the hymnals
+type HymnalsHTTP struct {
+	collections *repository.Collections
+}
+
+func NewHymnals(collections *repository.Collections) *HymnalsHTTP {
+	return &HymnalsHTTP{
+		collections: collections,
+	}
+}
+
+func (hh *HymnalsHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	result := []Hymnal{}
+	for _, collection := range hh.collections.List() {
+		result = append(result, Hymnal{
+			Slug:  collection.Slug,
+			Title: collection.Title,
+			URL:   "/" + collection.Slug + "/hymns",
+		})
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,27 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHymnalsHTTP_ServeHTTP(t *testing.T) {
+	collections, err := repository.NewCollections(
+		&repository.Collection{Slug: "kidung-jemaat", Title: "Kidung Jemaat"},
+		&repository.Collection{Slug: "pelengkap-kidung-jemaat", Title: "Pelengkap Kidung Jemaat"},
+	)
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/hymnals", nil)
+	NewHymnals(collections).ServeHTTP(w, r, httprouter.Params{})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `[{"slug":"kidung-jemaat","title":"Kidung Jemaat","url":"/kidung-jemaat/hymns"},`+
+		`{"slug":"pelengkap-kidung-jemaat","title":"Pelengkap Kidung Jemaat","url":"/pelengkap-kidung-jemaat/hymns"}]`, w.Body.String())
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
 )
 
 type CanvasDelegator struct{}
@@ -18,17 +19,25 @@ func (rscd *CanvasDelegator) OnError(err error) canvas.DelegatorErrorFlowControl
 }
 
 type RenderString struct {
-	usecase usecase.Usecase
+	usecase  usecase.Usecase
+	fontPath string
 }
 
-func NewRenderString(u usecase.Usecase) *RenderString {
+// NewRenderString creates the string renderer, fontPath is the directory of the fonts used by the png and the embedded fonts
+func NewRenderString(u usecase.Usecase, fontPath string) *RenderString {
 
 	return &RenderString{
-		usecase: u,
+		usecase:  u,
+		fontPath: fontPath,
 	}
 }
 
 func (rs *RenderString) RenderHymn(ctx context.Context, buf *bytes.Buffer, number int, variant ...string) (string, error) {
+	// the embedded fonts are read from the font path of the renderer
+	if param, _ := params.GetParamFromContext(ctx); param.Render != nil && param.Render.EmbedFonts && param.Render.FontPath == "" {
+		param.Render.FontPath = rs.fontPath
+	}
+
 	canv := canvas.NewBufferedCanvas(buf, &CanvasDelegator{})
 	err := rs.usecase.RenderHymn(ctx, canv, number, variant...)
 	if err != nil {
@@ -36,3 +45,29 @@ func (rs *RenderString) RenderHymn(ctx context.Context, buf *bytes.Buffer, numbe
 	}
 	return buf.String(), nil
 }
+
+// RenderHymnPNG renders the hymn as the png, the png is written into the buffer
+func (rs *RenderString) RenderHymnPNG(ctx context.Context, buf *bytes.Buffer, option canvas.PNGOption, number int, variant ...string) ([]byte, error) {
+	canv := canvas.NewPNGCanvas(buf, &CanvasDelegator{}, rs.fontPath, option)
+	err := rs.usecase.RenderHymn(ctx, canv, number, variant...)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderHymnText renders the hymn as the plain text numbered notation, the text is written into the buffer
+func (rs *RenderString) RenderHymnText(ctx context.Context, buf *bytes.Buffer, number int, variant ...string) (string, error) {
+	param, _ := params.GetParamFromContext(ctx)
+	if param.Render == nil {
+		param.Render = &params.RenderParam{}
+	}
+	param.Render.Format = params.FormatText
+
+	canv := canvas.NewTextCanvas(buf, &CanvasDelegator{})
+	err := rs.usecase.RenderHymn(params.NewParamContext(ctx, param), canv, number, variant...)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -2,10 +2,13 @@ package adapter
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 
+	"github.com/jodi-ivan/numbered-notation-xml/internal/synth"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/transpose"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
@@ -13,13 +16,46 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
+const (
+	FormatSVG  = "svg"
+	FormatPDF  = "pdf"
+	FormatPNG  = "png"
+	FormatTXT  = "txt"
+	FormatMIDI = "midi"
+	FormatWAV  = "wav"
+	FormatJSON = "json"
+)
+
+var contentTypes = map[string]string{
+	FormatSVG:  "image/svg+xml",
+	FormatPDF:  "application/pdf",
+	FormatPNG:  "image/png",
+	FormatTXT:  "text/plain; charset=utf-8",
+	FormatMIDI: "audio/midi",
+	FormatWAV:  "audio/wav",
+	FormatJSON: "application/json",
+}
+
+// renderFormats are the formats written by their own renderer, the other formats are converted from the svg
+var renderFormats = map[string]params.Format{
+	FormatTXT:  params.FormatText,
+	FormatMIDI: params.FormatMIDI,
+	FormatWAV:  params.FormatWAV,
+	FormatJSON: params.FormatTiming,
+}
+
 type CanvasDelegatorHTTP struct {
-	w http.ResponseWriter
-	r *http.Request
+	w      http.ResponseWriter
+	r      *http.Request
+	format string
 }
 
 func (cdh *CanvasDelegatorHTTP) OnBeforeStartWrite() {
-	cdh.w.Header().Set("Content-Type", "image/svg+xml")
+	contentType, ok := contentTypes[cdh.format]
+	if !ok {
+		contentType = contentTypes[FormatSVG]
+	}
+	cdh.w.Header().Set("Content-Type", contentType)
 	cdh.w.WriteHeader(http.StatusOK)
 }
 
@@ -28,6 +64,12 @@ func (cdh *CanvasDelegatorHTTP) OnError(err error) canvas.DelegatorErrorFlowCont
 		// metadata is not found
 		return canvas.DelegatorErrorFlowControlIgnore
 
+	} else if errors.Is(err, transpose.ErrInvalidTranspose) {
+		// the target key is not available on the mode of the hymn
+		cdh.w.WriteHeader(http.StatusBadRequest)
+		cdh.w.Write([]byte(err.Error()))
+		return canvas.DelegatorErrorFlowControlStop
+
 	} else if errors.Is(err, repository.ErrHymnHasMoreThanOneVariant) {
 		// Perform the redirect
 		cdh.r.URL.Path += "a"
@@ -41,19 +83,38 @@ func (cdh *CanvasDelegatorHTTP) OnError(err error) canvas.DelegatorErrorFlowCont
 	return canvas.DelegatorErrorFlowControlStop
 }
 
-func New(u usecase.Usecase) *RenderHTTP {
+// New creates the render handler, fontPath is the directory of the fonts embedded on the pdf and the svg
+func New(u usecase.Usecase, fontPath string) *RenderHTTP {
+	return &RenderHTTP{
+		usecase:  u,
+		fontPath: fontPath,
+	}
+}
+
+// NewMIDI creates the handler of the playback of the hymn as the midi, the format is not taken from the request
+func NewMIDI(u usecase.Usecase) *RenderHTTP {
+	return &RenderHTTP{
+		usecase: u,
+		format:  FormatMIDI,
+	}
+}
+
+// NewTiming creates the handler of the time of every note on every verse as the json, the format is not taken from the request
+func NewTiming(u usecase.Usecase) *RenderHTTP {
 	return &RenderHTTP{
 		usecase: u,
+		format:  FormatJSON,
 	}
 }
 
 type RenderHTTP struct {
-	usecase usecase.Usecase
+	usecase  usecase.Usecase
+	fontPath string
+	// the fixed format of the handler, empty takes the format of the request
+	format string
 }
 
 func (rh *RenderHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	delegator := &CanvasDelegatorHTTP{w: w, r: r}
-	canv := canvas.NewBufferedCanvas(w, delegator)
 	raw := ps.ByName("number")
 
 	var variant []string
@@ -95,9 +156,94 @@ func (rh *RenderHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httpr
 		return
 	}
 
+	chordRaw := r.FormValue("numbered-chord")
+
+	numberedChord, err := strconv.ParseBool(chordRaw)
+	if chordRaw != "" && err != nil {
+		log.Printf("[ServeHTTP] invalid numbered chord: %v", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	embedRaw := r.FormValue("embed-fonts")
+
+	embedFonts, err := strconv.ParseBool(embedRaw)
+	if embedRaw != "" && err != nil {
+		log.Printf("[ServeHTTP] invalid embed fonts: %v", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	instrument := r.FormValue("voice")
+	if instrument != "" && !synth.IsVoice(synth.Voice(instrument)) {
+		log.Printf("[ServeHTTP] invalid voice: %s", instrument)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	transposeRaw := r.FormValue("transpose")
+	if transposeRaw != "" {
+		if _, err := transpose.ParseRequest(transposeRaw); err != nil {
+			log.Printf("[ServeHTTP] invalid transpose: %v", err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid URL"))
+			return
+		}
+	}
+
+	format := rh.format
+	if format == "" {
+		format = r.FormValue("format")
+	}
+	if format == "" {
+		format = FormatSVG
+	}
+
+	delegator := &CanvasDelegatorHTTP{w: w, r: r, format: format}
+
+	var canv canvas.Canvas
+	switch format {
+	case FormatSVG:
+		canv = canvas.NewBufferedCanvas(w, delegator)
+	case FormatPDF:
+		canv = canvas.NewPDFCanvas(w, delegator, rh.fontPath)
+	case FormatPNG:
+		option, err := parsePNGOption(r)
+		if err != nil {
+			log.Printf("[ServeHTTP] invalid png size: %v", err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid URL"))
+			return
+		}
+		canv = canvas.NewPNGCanvas(w, delegator, rh.fontPath, option)
+	case FormatTXT, FormatMIDI, FormatWAV, FormatJSON:
+		canv = canvas.NewTextCanvas(w, delegator)
+	default:
+		log.Printf("[ServeHTTP] invalid format: %s", format)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
 	prm := &params.Param{
 		Verse:           verseNo,
 		SingleVerseMode: focusMode,
+		NumberedChord:   numberedChord,
+		Transpose:       transposeRaw,
+	}
+	render := params.RenderParam{
+		EmbedFonts: embedFonts,
+		Format:     renderFormats[format],
+		Instrument: instrument,
+	}
+	if embedFonts {
+		render.FontPath = rh.fontPath
+	}
+	if render != (params.RenderParam{}) {
+		prm.Render = &render
 	}
 
 	err = rh.usecase.RenderHymn(params.NewParamContext(r.Context(), prm), canv, num, variant...)
@@ -106,3 +252,32 @@ func (rh *RenderHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httpr
 	}
 
 }
+
+// parsePNGOption parses the width in pixel or the dpi of the png, both are optional
+func parsePNGOption(r *http.Request) (canvas.PNGOption, error) {
+	result := canvas.PNGOption{}
+
+	if raw := r.FormValue("width"); raw != "" {
+		width, err := strconv.Atoi(raw)
+		if err != nil {
+			return result, err
+		}
+		if width <= 0 || width > canvas.PNG_MAX_WIDTH {
+			return result, fmt.Errorf("width %d is out of range", width)
+		}
+		result.Width = width
+	}
+
+	if raw := r.FormValue("dpi"); raw != "" {
+		dpi, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return result, err
+		}
+		if dpi <= 0 || dpi > canvas.PNG_MAX_DPI {
+			return result, fmt.Errorf("dpi %v is out of range", dpi)
+		}
+		result.DPI = dpi
+	}
+
+	return result, nil
+}
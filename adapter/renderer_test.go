@@ -1,15 +1,19 @@
 package adapter
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/transpose"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/webserver"
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
@@ -24,8 +28,9 @@ func TestRenderHTTP_ServeHTTP(t *testing.T) {
 		ps httprouter.Params
 	}
 	tests := []struct {
-		name string
-		args args
+		name   string
+		args   args
+		format string
 
 		initMock                   func(ctrl *gomock.Controller) *usecase.MockUsecase
 		initHTTPResponseWriterMock func(ctrl *gomock.Controller) *webserver.MockResponseWriter
@@ -57,6 +62,312 @@ func TestRenderHTTP_ServeHTTP(t *testing.T) {
 				return res
 			},
 		},
+		{
+			name: "invalid format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=jpg", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte("Invalid URL"))
+				return res
+			},
+		},
+		{
+			name: "pdf format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=pdf", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.AssignableToTypeOf(&canvas.PDFCanvas{}), int(1))
+				return res
+			},
+		},
+		{
+			name: "invalid embed fonts",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?embed-fonts=maybe", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte("Invalid URL"))
+				return res
+			},
+		},
+		{
+			name: "embed fonts",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?embed-fonts=true", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{EmbedFonts: true}, prm.Render)
+				})
+				return res
+			},
+		},
+		{
+			name: "txt format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=txt", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{Format: params.FormatText}, prm.Render)
+					assert.IsType(t, &canvas.TextCanvas{}, canv)
+				})
+				return res
+			},
+		},
+		{
+			name: "midi format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=midi", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{Format: params.FormatMIDI}, prm.Render)
+				})
+				return res
+			},
+		},
+		{
+			name: "midi handler, the format of the request is ignored",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1b",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/midi/1b?format=png", nil),
+			},
+			format: FormatMIDI,
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1), "b").Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{Format: params.FormatMIDI}, prm.Render)
+					assert.IsType(t, &canvas.TextCanvas{}, canv)
+				})
+				return res
+			},
+		},
+		{
+			name: "timing handler",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/timing/1", nil),
+			},
+			format: FormatJSON,
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{Format: params.FormatTiming}, prm.Render)
+					assert.IsType(t, &canvas.TextCanvas{}, canv)
+				})
+				return res
+			},
+		},
+		{
+			name: "invalid transpose",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?transpose=H", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte("Invalid URL"))
+				return res
+			},
+		},
+		{
+			name: "transpose",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?transpose=-2", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, "-2", prm.Transpose)
+					assert.Nil(t, prm.Render)
+				})
+				return res
+			},
+		},
+		{
+			name: "invalid voice",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=wav&voice=harp", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte("Invalid URL"))
+				return res
+			},
+		},
+		{
+			name: "wav format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=wav&voice=piano", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.Any(), int(1)).Do(func(ctx context.Context, canv canvas.Canvas, number int, variant ...string) {
+					prm, _ := params.GetParamFromContext(ctx)
+					assert.Equal(t, &params.RenderParam{Format: params.FormatWAV, Instrument: "piano"}, prm.Render)
+				})
+				return res
+			},
+		},
+		{
+			name: "png with invalid width",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=png&width=100000", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte("Invalid URL"))
+				return res
+			},
+		},
+		{
+			name: "png format",
+			args: args{
+				ps: httprouter.Params([]httprouter.Param{
+					httprouter.Param{
+						Key:   "number",
+						Value: "1",
+					},
+				}),
+				r: httptest.NewRequest(http.MethodGet, "/kidung-jemaat/render/1?format=png&width=1200", nil),
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				return res
+			},
+			initMock: func(ctrl *gomock.Controller) *usecase.MockUsecase {
+				res := usecase.NewMockUsecase(ctrl)
+				res.EXPECT().RenderHymn(gomock.Any(), gomock.AssignableToTypeOf(&canvas.PNGCanvas{}), int(1))
+				return res
+			},
+		},
 		{
 			name: "everything went fine",
 			args: args{
@@ -102,7 +413,7 @@ func TestRenderHTTP_ServeHTTP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rh := &RenderHTTP{}
+			rh := &RenderHTTP{format: tt.format}
 			if tt.initMock != nil {
 				rh.usecase = tt.initMock(ctrl)
 			}
@@ -120,10 +431,85 @@ func TestCanvasDelegatorHTTP_OnBeforeStartWrite(t *testing.T) {
 
 	tests := []struct {
 		name                       string
+		format                     string
+		want                       string
 		initHTTPResponseWriterMock func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header)
 	}{
 		{
 			name: "default",
+			want: "image/svg+xml",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "png",
+			format: FormatPNG,
+			want:   "image/png",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "txt",
+			format: FormatTXT,
+			want:   "text/plain; charset=utf-8",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "midi",
+			format: FormatMIDI,
+			want:   "audio/midi",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "wav",
+			format: FormatWAV,
+			want:   "audio/wav",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			want:   "application/json",
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusOK)
+				header := http.Header{}
+				res.EXPECT().Header().Return(header)
+				return res, header
+			},
+		},
+		{
+			name:   "pdf",
+			format: FormatPDF,
+			want:   "application/pdf",
 			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) (*webserver.MockResponseWriter, http.Header) {
 				res := webserver.NewMockResponseWriter(ctrl)
 				res.EXPECT().WriteHeader(http.StatusOK)
@@ -137,12 +523,13 @@ func TestCanvasDelegatorHTTP_OnBeforeStartWrite(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			w, h := tt.initHTTPResponseWriterMock(ctrl)
 			cdh := CanvasDelegatorHTTP{
-				w: w,
+				w:      w,
+				format: tt.format,
 			}
 			cdh.OnBeforeStartWrite()
 
 			if !assert.Equal(t, http.Header(map[string][]string{
-				"Content-Type": []string{"image/svg+xml"},
+				"Content-Type": []string{tt.want},
 			}), h) {
 				t.Fail()
 			}
@@ -176,6 +563,19 @@ func TestCanvasDelegatorHTTP_OnError(t *testing.T) {
 			},
 			want: canvas.DelegatorErrorFlowControlIgnore,
 		},
+		{
+			name: "invalid transpose",
+			args: args{
+				err: transpose.ErrInvalidTranspose,
+			},
+			initHTTPResponseWriterMock: func(ctrl *gomock.Controller) *webserver.MockResponseWriter {
+				res := webserver.NewMockResponseWriter(ctrl)
+				res.EXPECT().WriteHeader(http.StatusBadRequest)
+				res.EXPECT().Write([]byte(transpose.ErrInvalidTranspose.Error()))
+				return res
+			},
+			want: canvas.DelegatorErrorFlowControlStop,
+		},
 		{
 			name: "other error",
 			args: args{
@@ -221,7 +621,7 @@ func TestNew(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res := New(tt.args.u)
+			res := New(tt.args.u, "")
 
 			if !assert.NotNil(t, res) {
 				t.Fail()
@@ -0,0 +1,170 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/julienschmidt/httprouter"
+)
+
+// SearchHit is the hymn found by the search, the best match first
+type SearchHit struct {
+	Number    int    `json:"number"`
+	Variant   string `json:"variant,omitempty"`
+	Title     string `json:"title"`
+	FirstLine string `json:"firstLine"`
+	// the matched text, the matched words are wrapped with <mark>
+	Snippet string `json:"snippet"`
+}
+
+// MelodyHit is the hymn whose melody contains the query
+type MelodyHit struct {
+	Number  int    `json:"number"`
+	Variant string `json:"variant,omitempty"`
+	Title   string `json:"title"`
+	// the opening of the melody on the numbered notation
+	Incipit string `json:"incipit"`
+}
+
+type SearchHTTP struct {
+	search usecase.Search
+}
+
+// NewSearch creates the handler of the full text search of the hymns
+func NewSearch(s usecase.Search) *SearchHTTP {
+	return &SearchHTTP{
+		search: s,
+	}
+}
+
+func (sh *SearchHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	query := strings.TrimSpace(r.FormValue("q"))
+	if query == "" {
+		log.Printf("[SearchHTTP] empty query")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		log.Printf("[SearchHTTP] invalid limit: %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	rows, err := sh.search.Search(r.Context(), query, limit)
+	if errors.Is(err, repository.ErrInvalidSearchQuery) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	} else if err != nil {
+		log.Printf("[SearchHTTP] failed to search %q: %s", query, err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	result := make([]SearchHit, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, SearchHit{
+			Number:    row.Number,
+			Variant:   row.Variant.String,
+			Title:     row.Title,
+			FirstLine: row.FirstLine,
+			Snippet:   row.Snippet,
+		})
+	}
+	writeJSON(w, result)
+}
+
+// MelodySearchHTTP finds the hymns by the tune written on the numbered notation.
+// the query is read on the major scale, the tune on the minor key is written from la (6), e.g. `6 7 1 2 3`
+type MelodySearchHTTP struct {
+	search usecase.Search
+}
+
+// NewMelodySearch creates the handler of the search by the melody
+func NewMelodySearch(s usecase.Search) *MelodySearchHTTP {
+	return &MelodySearchHTTP{
+		search: s,
+	}
+}
+
+func (mh *MelodySearchHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	query := strings.TrimSpace(r.FormValue("q"))
+	if query == "" {
+		log.Printf("[MelodySearchHTTP] empty query")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	flags := map[string]bool{}
+	for _, name := range []string{"any-key", "any-rhythm"} {
+		raw := r.FormValue(name)
+		value, err := strconv.ParseBool(raw)
+		if raw != "" && err != nil {
+			log.Printf("[MelodySearchHTTP] invalid %s: %v", name, err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid URL"))
+			return
+		}
+		flags[name] = value
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		log.Printf("[MelodySearchHTTP] invalid limit: %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	rows, err := mh.search.SearchMelody(r.Context(), query, flags["any-key"], flags["any-rhythm"], limit)
+	if errors.Is(err, repository.ErrInvalidMelodyQuery) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	} else if err != nil {
+		log.Printf("[MelodySearchHTTP] failed to search %q: %s", query, err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	result := make([]MelodyHit, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, MelodyHit{
+			Number:  row.Number,
+			Variant: row.Variant.String,
+			Title:   row.Title,
+			Incipit: row.Incipit,
+		})
+	}
+	writeJSON(w, result)
+}
+
+// parseLimit returns the limit of the results, zero when it is not set
+func parseLimit(r *http.Request) (int, error) {
+	raw := r.FormValue("limit")
+	if raw == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 {
+		return 0, fmt.Errorf("limit %d is not positive", limit)
+	}
+	return limit, nil
+}
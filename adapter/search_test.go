@@ -0,0 +1,206 @@
+package adapter
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchHTTP_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		path     string
+		initMock func(ctrl *gomock.Controller) *usecase.MockSearch
+
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "found",
+			path: "/kidung-jemaat/search?q=kasih+tu&limit=5",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().Search(gomock.Any(), "kasih tu", 5).Return([]repository.SearchResult{
+					{
+						HymnIndicator: repository.HymnIndicator{HymnID: 2, Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+						Title:         "Tiga",
+						FirstLine:     "Kasih Tuhan",
+						Snippet:       "<mark>Kasih</mark> <mark>Tuhan</mark>",
+					},
+				}, nil)
+				return search
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"number":3,"variant":"a","title":"Tiga","firstLine":"Kasih Tuhan","snippet":"\u003cmark\u003eKasih\u003c/mark\u003e \u003cmark\u003eTuhan\u003c/mark\u003e"}]`,
+		},
+		{
+			name: "not found",
+			path: "/kidung-jemaat/search?q=zzz",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().Search(gomock.Any(), "zzz", 0).Return([]repository.SearchResult{}, nil)
+				return search
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name: "empty query",
+			path: "/kidung-jemaat/search?q=+",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				return usecase.NewMockSearch(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "invalid limit",
+			path: "/kidung-jemaat/search?q=kasih&limit=ab",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				return usecase.NewMockSearch(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "no word on the query",
+			path: "/kidung-jemaat/search?q=%22*",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().Search(gomock.Any(), `"*`, 0).Return(nil, repository.ErrInvalidSearchQuery)
+				return search
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   repository.ErrInvalidSearchQuery.Error(),
+		},
+		{
+			name: "failed to search",
+			path: "/kidung-jemaat/search?q=kasih",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().Search(gomock.Any(), "kasih", 0).Return(nil, errors.New("no such table: hymn_search"))
+				return search
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "no such table: hymn_search",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewSearch(tt.initMock(ctrl))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			sh.ServeHTTP(w, r, httprouter.Params{})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestMelodySearchHTTP_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name     string
+		path     string
+		initMock func(ctrl *gomock.Controller) *usecase.MockSearch
+
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "found",
+			path: "/kidung-jemaat/search/melody?q=1+1+5+5&any-key=true&any-rhythm=1",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().SearchMelody(gomock.Any(), "1 1 5 5", true, true, 0).Return([]repository.MelodyResult{
+					{
+						HymnIndicator: repository.HymnIndicator{HymnID: 2, Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+						Title:         "Tiga",
+						Incipit:       "1 1 5 5 6 6 5",
+					},
+				}, nil)
+				return search
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"number":3,"variant":"a","title":"Tiga","incipit":"1 1 5 5 6 6 5"}]`,
+		},
+		{
+			name: "empty query",
+			path: "/kidung-jemaat/search/melody",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				return usecase.NewMockSearch(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "invalid any key",
+			path: "/kidung-jemaat/search/melody?q=1+2+3&any-key=maybe",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				return usecase.NewMockSearch(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "invalid limit",
+			path: "/kidung-jemaat/search/melody?q=1+2+3&limit=-1",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				return usecase.NewMockSearch(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "too short",
+			path: "/kidung-jemaat/search/melody?q=1",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().SearchMelody(gomock.Any(), "1", false, false, 0).Return(nil, repository.ErrInvalidMelodyQuery)
+				return search
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   repository.ErrInvalidMelodyQuery.Error(),
+		},
+		{
+			name: "failed to search",
+			path: "/kidung-jemaat/search/melody?q=1+2+3",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockSearch {
+				search := usecase.NewMockSearch(ctrl)
+				search.EXPECT().SearchMelody(gomock.Any(), "1 2 3", false, false, 0).Return(nil, errors.New("no such table: hymn_melody"))
+				return search
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "no such table: hymn_melody",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mh := NewMelodySearch(tt.initMock(ctrl))
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			mh.ServeHTTP(w, r, httprouter.Params{})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/julienschmidt/httprouter"
+)
+
+const dateLayout = "2006-01-02"
+
+type Tag struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// SuggestedHymn is the hymn for the day, with the tags matching the day
+type SuggestedHymn struct {
+	Number  int    `json:"number"`
+	Variant string `json:"variant,omitempty"`
+	Title   string `json:"title"`
+	Tags    []Tag  `json:"tags"`
+}
+
+// DaySuggestion is the day of the church calendar and its hymns
+type DaySuggestion struct {
+	Date   string          `json:"date"`
+	Season string          `json:"season"`
+	Day    string          `json:"day,omitempty"`
+	Themes []string        `json:"themes"`
+	Hymns  []SuggestedHymn `json:"hymns"`
+}
+
+type SuggestionHTTP struct {
+	planner usecase.Planner
+	now     func() time.Time
+}
+
+// NewSuggestion creates the handler of the hymns suggested for a date
+func NewSuggestion(planner usecase.Planner) *SuggestionHTTP {
+	return &SuggestionHTTP{
+		planner: planner,
+		now:     time.Now,
+	}
+}
+
+// ServeHTTP returns the hymns suggested for the date (yyyy-mm-dd), today when the date is not set
+func (sh *SuggestionHTTP) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	date := sh.now()
+	if raw := r.FormValue("date"); raw != "" {
+		parsed, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			log.Printf("[SuggestionHTTP] invalid date: %v", err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid URL"))
+			return
+		}
+		date = parsed
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		log.Printf("[SuggestionHTTP] invalid limit: %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL"))
+		return
+	}
+
+	suggestion, err := sh.planner.SuggestHymns(r.Context(), date, limit)
+	if err != nil {
+		log.Printf("[SuggestionHTTP] failed to suggest the hymns: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	result := DaySuggestion{
+		Date:   suggestion.Day.Date.Format(dateLayout),
+		Season: string(suggestion.Day.Season),
+		Day:    suggestion.Day.Name,
+		Themes: suggestion.Day.Themes,
+		Hymns:  make([]SuggestedHymn, 0, len(suggestion.Hymns)),
+	}
+	for _, hymn := range suggestion.Hymns {
+		tags := make([]Tag, 0, len(hymn.Matched))
+		for _, tag := range hymn.Matched {
+			tags = append(tags, Tag{Kind: string(tag.Kind), Value: tag.Value})
+		}
+		result.Hymns = append(result.Hymns, SuggestedHymn{
+			Number:  hymn.Number,
+			Variant: hymn.Variant.String,
+			Title:   hymn.Title,
+			Tags:    tags,
+		})
+	}
+	writeJSON(w, result)
+}
@@ -0,0 +1,104 @@
+package adapter
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/liturgy"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestionHTTP_ServeHTTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	today := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	palmSunday := time.Date(2026, time.March, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		path     string
+		initMock func(ctrl *gomock.Controller) *usecase.MockPlanner
+
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "the date",
+			path: "/kidung-jemaat/suggestions?date=2026-03-29&limit=5",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockPlanner {
+				planner := usecase.NewMockPlanner(ctrl)
+				planner.EXPECT().SuggestHymns(gomock.Any(), palmSunday, 5).Return(&usecase.Suggestion{
+					Day: liturgy.DayOf(palmSunday),
+					Hymns: []usecase.SuggestedHymn{
+						{
+							HymnIndicator: repository.HymnIndicator{HymnID: 2, Number: 3, Variant: sql.NullString{String: "a", Valid: true}},
+							Title:         "Tiga",
+							Matched:       []repository.HymnTag{{Kind: repository.TagKindDay, Value: "palm-sunday"}},
+							Score:         3,
+						},
+					},
+				}, nil)
+				return planner
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"date":"2026-03-29","season":"lent","day":"palm-sunday","themes":["lent","palm-sunday"],` +
+				`"hymns":[{"number":3,"variant":"a","title":"Tiga","tags":[{"kind":"day","value":"palm-sunday"}]}]}`,
+		},
+		{
+			name: "today",
+			path: "/kidung-jemaat/suggestions",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockPlanner {
+				planner := usecase.NewMockPlanner(ctrl)
+				planner.EXPECT().SuggestHymns(gomock.Any(), today, 0).Return(&usecase.Suggestion{
+					Day: liturgy.DayOf(today),
+				}, nil)
+				return planner
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"date":"2026-10-18","season":"ordinary","day":"after-pentecost-21","themes":["ordinary"],"hymns":[]}`,
+		},
+		{
+			name: "invalid date",
+			path: "/kidung-jemaat/suggestions?date=29-03-2026",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockPlanner {
+				return usecase.NewMockPlanner(ctrl)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "Invalid URL",
+		},
+		{
+			name: "failed to suggest",
+			path: "/kidung-jemaat/suggestions?date=2026-03-29",
+			initMock: func(ctrl *gomock.Controller) *usecase.MockPlanner {
+				planner := usecase.NewMockPlanner(ctrl)
+				planner.EXPECT().SuggestHymns(gomock.Any(), palmSunday, 0).Return(nil, errors.New("no such table: jdy_hymn_tag"))
+				return planner
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "no such table: jdy_hymn_tag",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewSuggestion(tt.initMock(ctrl))
+			sh.now = func() time.Time { return today }
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			sh.ServeHTTP(w, r, httprouter.Params{})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
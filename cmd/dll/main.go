@@ -16,6 +16,7 @@ import (
 	"github.com/jodi-ivan/numbered-notation-xml/internal/renderer"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/storage"
@@ -38,6 +39,9 @@ func GetEngine() *adapter.RenderString {
 			SQLite: config.SQLiteConfig{
 				DBPath: "/home/jodiivan/go/src/github.com/jodi-ivan/numbered-notation-xml/files/database/kidung-jemaat.db",
 			},
+			Assets: config.AssetsConfig{
+				FontPath: "/home/jodiivan/go/src/github.com/jodi-ivan/numbered-notation-xml/files/var/www/fonts/",
+			},
 		}
 
 		db, err := storage.NewStorage(context.Background(), cfg.SQLite.DBPath)
@@ -48,7 +52,7 @@ func GetEngine() *adapter.RenderString {
 
 		repo = repository.New(context.Background(), db)
 		usecaseMod := usecase.New(cfg, repo, renderer.NewRenderer())
-		stringAdapter = adapter.NewRenderString(usecaseMod)
+		stringAdapter = adapter.NewRenderString(usecaseMod, cfg.Assets.FontPath)
 
 	})
 	return stringAdapter
@@ -58,6 +62,12 @@ func GetEngine() *adapter.RenderString {
 type RenderConfig struct {
 	Verse     int  `json:"verse"`
 	FocusMode bool `json:"focus_mode"`
+	// svg only, inlines the subset of the fonts so the svg is portable
+	EmbedFonts bool `json:"embed_fonts"`
+
+	// png only, the width in pixel takes the precedence over the dpi
+	Width int     `json:"width"`
+	DPI   float64 `json:"dpi"`
 }
 
 //export RenderHymnSVGWithInfo
@@ -103,6 +113,9 @@ func RenderHymnSVGWithInfo(number C.int, variant *C.char, configJson *C.char, to
 				SingleVerseMode: config.FocusMode,
 				Verse:           config.Verse,
 			}
+			if config.EmbedFonts {
+				param.Render = &params.RenderParam{EmbedFonts: true}
+			}
 
 			ctx = params.NewParamContext(ctx, &param)
 		}
@@ -169,6 +182,9 @@ func RenderHymnSVG(number C.int, variant *C.char, configJson *C.char) *C.char {
 				SingleVerseMode: config.FocusMode,
 				Verse:           config.Verse,
 			}
+			if config.EmbedFonts {
+				param.Render = &params.RenderParam{EmbedFonts: true}
+			}
 
 			ctx = params.NewParamContext(ctx, &param)
 		}
@@ -188,6 +204,66 @@ func RenderHymnSVG(number C.int, variant *C.char, configJson *C.char) *C.char {
 	return C.CString(content)
 }
 
+//export RenderHymnPNG
+func RenderHymnPNG(number C.int, variant *C.char, configJson *C.char, size *C.int) *C.char {
+	stateMutex.Lock()
+	defer stateMutex.Unlock()
+
+	ctx := context.Background()
+	e := GetEngine()
+
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println("Panic: ", err)
+			debug.PrintStack()
+		}
+	}()
+
+	goNumber := int(number)
+
+	goVariant := []string{}
+	if variant != nil {
+		goVariant = append(goVariant, C.GoString(variant))
+	}
+
+	config := RenderConfig{}
+	if configJson != nil {
+		jsonStr := C.GoString(configJson)
+		if jsonStr != "" {
+			err := json.Unmarshal([]byte(jsonStr), &config)
+			if err != nil {
+				log.Println("[DLL] Failed to unmarshal config", err.Error())
+				return nil
+			}
+			param := params.Param{
+				SingleVerseMode: config.FocusMode,
+				Verse:           config.Verse,
+			}
+
+			ctx = params.NewParamContext(ctx, &param)
+		}
+	}
+
+	if config.Width < 0 || config.Width > canvas.PNG_MAX_WIDTH || config.DPI < 0 || config.DPI > canvas.PNG_MAX_DPI {
+		log.Printf("[DLL] Invalid png size: width %d, dpi %v\n", config.Width, config.DPI)
+		return nil
+	}
+
+	buff := bytes.NewBuffer(nil)
+	content, err := e.RenderHymnPNG(ctx, buff, canvas.PNGOption{Width: config.Width, DPI: config.DPI}, goNumber, goVariant...)
+	if err != nil {
+		log.Printf("[DLL] Problem creating file: %v\n", err)
+		return nil
+	}
+
+	// the png is binary, the caller reads the size instead of the null terminator.
+	// C++ must free this memory with FreeRenderedString as well
+	if size != nil {
+		*size = C.int(len(content))
+	}
+	return (*C.char)(C.CBytes(content))
+}
+
 //export FreeRenderedString
 func FreeRenderedString(ptr *C.char) {
 	if ptr != nil {
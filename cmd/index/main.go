@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/storage"
+)
+
+// builds the full text index and the melody index of the hymnals, the search of the rest is served from them.
+// the full text index needs the fts5 of the sqlite, e.g.
+//
+//	go run -tags sqlite_fts5 cmd/index/main.go
+//	go run -tags sqlite_fts5 cmd/index/main.go -hymnal=kidung-jemaat
+func main() {
+	env := flag.String("env", "development", "Environment of the config")
+	slug := flag.String("hymnal", "", "Slug of the hymnal, every hymnal when empty")
+
+	flag.Parse()
+
+	if err := buildIndexes(context.Background(), *env, *slug); err != nil {
+		log.Fatalf("Failed to build the search index: %s", err.Error())
+	}
+}
+
+// buildIndexes builds the indexes of the hymnal, every hymnal when the slug is empty.
+// the connections are closed before the error is returned
+func buildIndexes(ctx context.Context, env, slug string) error {
+	cfg, err := config.InitConfig(env)
+	if err != nil {
+		return fmt.Errorf("failed to load config, err : %w", err)
+	}
+
+	hymnals, err := cfg.GetHymnals()
+	if err != nil {
+		return fmt.Errorf("invalid hymnals config, err : %w", err)
+	}
+
+	dbs := map[string]*sqlx.DB{}
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+
+	for _, hymnal := range hymnals {
+		if slug != "" && hymnal.Slug != slug {
+			continue
+		}
+
+		db, ok := dbs[hymnal.DBPath]
+		if !ok {
+			db, err = storage.NewStorage(ctx, hymnal.DBPath)
+			if err != nil {
+				return fmt.Errorf("failed to connect to storage of %s: %w", hymnal.Slug, err)
+			}
+			dbs[hymnal.DBPath] = db
+		}
+
+		hymnalCfg := cfg
+		hymnalCfg.MusicXML = hymnal.MusicXML
+		hymnalCfg.SQLite.DBPath = hymnal.DBPath
+
+		repo := repository.NewWithSchema(ctx, db, repository.NewSchema(hymnal.TablePrefix))
+		if err := usecase.NewSearch(hymnalCfg, repo).BuildIndex(ctx); err != nil {
+			return fmt.Errorf("failed to build the index of %s: %w", hymnal.Slug, err)
+		}
+		log.Printf("[Search] the search index of %s is built", hymnal.Slug)
+	}
+
+	if len(dbs) == 0 {
+		return fmt.Errorf("no hymnal %s on the config", slug)
+	}
+	return nil
+}
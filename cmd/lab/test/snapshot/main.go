@@ -66,7 +66,7 @@ func main() {
 
 	repo := repository.New(context.Background(), db)
 	usecaseMod := usecase.New(cfg, repo, renderer.NewRenderer())
-	stringRender := adapter.NewRenderString(usecaseMod)
+	stringRender := adapter.NewRenderString(usecaseMod, cfg.Assets.FontPath)
 
 	switch *method {
 	case "gen":
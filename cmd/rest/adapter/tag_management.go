@@ -0,0 +1,143 @@
+package adapter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/liturgy"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/julienschmidt/httprouter"
+)
+
+// the theme is written in lowercase with the hyphen, e.g. holy-communion
+var themePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type TagManagement struct {
+	TagRepo repository.Repository
+}
+
+// HymnTags is the tags of the hymn, grouped by the kind
+type HymnTags struct {
+	Themes []string `json:"themes"`
+	Bible  []string `json:"bible"`
+	// the sundays and the feasts of the church calendar, e.g. advent-1 or good-friday
+	Days []string `json:"days"`
+}
+
+// ServeHTTP returns the tags of the hymn, the PUT replaces them
+func (tm *TagManagement) ServeHTTP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	number, variant, err := utils.ParseHymnWithVariant(ps.ByName("number"))
+	if err != nil {
+		log.Printf("invalid hymn number: %v", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid URL hymn"))
+		return
+	}
+
+	variants := []string{}
+	if variant != "" {
+		variants = append(variants, variant)
+	}
+	hymn, err := tm.TagRepo.GetHymnMetaData(ctx, number, variants...)
+	if errors.Is(err, repository.ErrHymnNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		input := HymnTags{}
+		err = json.Unmarshal(b, &input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("error input: :%s", err.Error())))
+			return
+		}
+
+		tags, err := input.toTags()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("error input: :%s", err.Error())))
+			return
+		}
+
+		err = tm.TagRepo.SetHymnTags(ctx, hymn.HymnID, tags)
+		if err != nil {
+			log.Printf("Failed to set the tags: %v", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("Failed to set the tags"))
+			return
+		}
+	}
+
+	tags, err := tm.TagRepo.GetHymnTags(ctx, hymn.HymnID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	result := HymnTags{Themes: []string{}, Bible: []string{}, Days: []string{}}
+	for _, tag := range tags {
+		switch tag.Kind {
+		case repository.TagKindTheme:
+			result.Themes = append(result.Themes, tag.Value)
+		case repository.TagKindBible:
+			result.Bible = append(result.Bible, tag.Value)
+		case repository.TagKindDay:
+			result.Days = append(result.Days, tag.Value)
+		}
+	}
+
+	content, _ := json.Marshal(result)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+func (ht HymnTags) toTags() ([]repository.HymnTag, error) {
+	result := []repository.HymnTag{}
+	for _, theme := range ht.Themes {
+		theme = strings.ToLower(strings.TrimSpace(theme))
+		if !themePattern.MatchString(theme) {
+			return nil, fmt.Errorf("invalid theme %q", theme)
+		}
+		result = append(result, repository.HymnTag{Kind: repository.TagKindTheme, Value: theme})
+	}
+
+	for _, reference := range ht.Bible {
+		reference = strings.TrimSpace(reference)
+		if reference == "" {
+			return nil, fmt.Errorf("empty bible reference")
+		}
+		result = append(result, repository.HymnTag{Kind: repository.TagKindBible, Value: reference})
+	}
+
+	for _, day := range ht.Days {
+		if !liturgy.IsDayName(day) {
+			return nil, fmt.Errorf("invalid day %q", day)
+		}
+		result = append(result, repository.HymnTag{Kind: repository.TagKindDay, Value: day})
+	}
+	return result, nil
+}
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/jodi-ivan/numbered-notation-xml/adapter"
 	lab "github.com/jodi-ivan/numbered-notation-xml/cmd/rest/adapter"
 	"github.com/jodi-ivan/numbered-notation-xml/decorator"
@@ -30,21 +31,56 @@ func main() {
 		return
 	}
 
-	db, err := storage.NewStorage(context.Background(), cfg.SQLite.DBPath)
+	hymnals, err := cfg.GetHymnals()
 	if err != nil {
-		log.Fatalf("Failed to connect to storage: %s", err.Error())
+		log.Fatalf("invalid hymnals config, err : %s", err.Error())
 		return
 	}
 
-	repo := repository.New(context.Background(), db)
+	// the hymnals on the same database share the connection
+	dbs := map[string]*sqlx.DB{}
+	list := []*repository.Collection{}
+	for _, hymnal := range hymnals {
+		hymnalDB, ok := dbs[hymnal.DBPath]
+		if !ok {
+			hymnalDB, err = storage.NewStorage(context.Background(), hymnal.DBPath)
+			if err != nil {
+				log.Fatalf("Failed to connect to storage of %s: %s", hymnal.Slug, err.Error())
+				return
+			}
+			dbs[hymnal.DBPath] = hymnalDB
+		}
+
+		list = append(list, &repository.Collection{
+			Slug:       hymnal.Slug,
+			Title:      hymnal.Title,
+			Repo:       repository.NewWithSchema(context.Background(), hymnalDB, repository.NewSchema(hymnal.TablePrefix)),
+			References: hymnal.References,
+		})
+	}
 
-	usecaseMod := usecase.New(cfg, repo, renderer.NewRenderer())
+	collections, err := repository.NewCollections(list...)
+	if err != nil {
+		log.Fatalf("invalid hymnals config, err : %s", err.Error())
+		return
+	}
 
-	httpRender := adapter.New(
-		decorator.WithVariantRedirect(repo)(usecaseMod),
-	)
+	ws.Register("GET", "/hymnals", adapter.NewHymnals(collections))
+	for i, hymnal := range hymnals {
+		registerHymnal(ws, cfg, hymnal, collections, list[i])
+	}
+
+	// the lab and the diagnostic work on the kidung jemaat, or the first hymnal
+	mainHymnal := 0
+	for i, hymnal := range hymnals {
+		if hymnal.Slug == config.DEFAULT_HYMNAL {
+			mainHymnal = i
+		}
+	}
+	repo := list[mainHymnal].Repo
+	db := dbs[hymnals[mainHymnal].DBPath]
+	usecaseMod := usecase.New(hymnalConfig(cfg, hymnals[mainHymnal]), repo, renderer.NewRenderer())
 
-	ws.Register("GET", "/kidung-jemaat/render/:number", httpRender)
 	//TODO: make the path root as config
 	ws.RegisterStatic("/internal/lab/*filepath", "./files/var/www/html/")
 	ws.RegisterStatic("/assets/fonts/*filepath", "./files/var/www/fonts/")
@@ -85,3 +121,58 @@ func main() {
 	os.Exit(0)
 
 }
+
+// hymnalConfig returns the config with the musicxml of the hymnal
+func hymnalConfig(cfg config.Config, hymnal config.Hymnal) config.Config {
+	cfg.MusicXML = hymnal.MusicXML
+	cfg.SQLite.DBPath = hymnal.DBPath
+	return cfg
+}
+
+// registerHymnal serves the hymnal under its prefix, e.g. /kidung-jemaat/render/:number
+func registerHymnal(ws *webserver.WebServer, cfg config.Config, hymnal config.Hymnal, collections *repository.Collections, collection *repository.Collection) {
+	ctx := context.Background()
+	prefix := "/" + hymnal.Slug
+	repo := collection.Repo
+	usecaseMod := usecase.New(hymnalConfig(cfg, hymnal), repo, renderer.NewRenderer())
+
+	ws.Register("GET", prefix+"/render/:number", adapter.New(
+		decorator.WithVariantRedirect(repo)(usecaseMod),
+		cfg.Assets.FontPath,
+	))
+	ws.Register("GET", prefix+"/midi/:number", adapter.NewMIDI(
+		decorator.WithVariantRedirect(repo)(usecaseMod),
+	))
+	ws.Register("GET", prefix+"/timing/:number", adapter.NewTiming(
+		decorator.WithVariantRedirect(repo)(usecaseMod),
+	))
+
+	catalogue := adapter.NewCollectionCatalogue(collections, collection)
+	ws.Register("GET", prefix+"/hymns", catalogue)
+	ws.Register("GET", prefix+"/hymns/:number", catalogue)
+
+	// the indexes are built by cmd/index, the search is not served without its index
+	searchMod := usecase.NewSearch(hymnalConfig(cfg, hymnal), repo)
+	if err := repo.CheckSearchIndex(ctx); err != nil {
+		// the fts5 is only available with the build tag: go build -tags sqlite_fts5
+		log.Printf("[Search] the search of %s is not served, run cmd/index first. err : %s", hymnal.Slug, err.Error())
+	} else {
+		ws.Register("GET", prefix+"/search", adapter.NewSearch(searchMod))
+	}
+	if err := repo.CheckMelodyIndex(ctx); err != nil {
+		log.Printf("[Search] the melody search of %s is not served, run cmd/index first. err : %s", hymnal.Slug, err.Error())
+	} else {
+		ws.Register("GET", prefix+"/search/melody", adapter.NewMelodySearch(searchMod))
+	}
+
+	if err := repo.CreateTagTable(ctx); err != nil {
+		log.Printf("[Tag] failed to create the table of the tags of %s, err : %s", hymnal.Slug, err.Error())
+	}
+	ws.Register("GET", prefix+"/suggestions", adapter.NewSuggestion(usecase.NewPlanner(repo)))
+
+	tagManagement := &lab.TagManagement{
+		TagRepo: repo,
+	}
+	ws.Register("GET", "/internal"+prefix+"/hymn/:number/tags", tagManagement)
+	ws.Register("PUT", "/internal"+prefix+"/hymn/:number/tags", tagManagement)
+}
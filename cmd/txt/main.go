@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jodi-ivan/numbered-notation-xml/adapter"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/renderer"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/usecase"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/storage"
+)
+
+// prints the hymn as the plain text numbered notation, e.g.
+//
+//	go run cmd/txt/main.go -number=3 -verse=2
+//	go run cmd/txt/main.go -file=./score.musicxml
+func main() {
+	env := flag.String("env", "development", "Environment of the config")
+	number := flag.Int("number", 0, "Number of the hymn")
+	variant := flag.String("variant", "", "Variant of the hymn, e.g. 'a'")
+	verse := flag.Int("verse", 0, "Verse of the lyric under the notes")
+	file := flag.String("file", "", "Path of the musicxml file, rendered without the hymn metadata")
+
+	flag.Parse()
+
+	ctx := params.NewParamContext(context.Background(), &params.Param{
+		Verse:  *verse,
+		Render: &params.RenderParam{Format: params.FormatText},
+	})
+
+	if *file != "" {
+		content, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatalf("Failed to read the musicxml: %s", err.Error())
+		}
+
+		music, err := musicxml.Parse(content)
+		if err != nil {
+			log.Fatalf("Failed to parse the musicxml: %s", err.Error())
+		}
+		usecase.ProcessRepeats(&music)
+
+		canv := canvas.NewTextCanvas(os.Stdout, &adapter.CanvasDelegator{})
+		renderer.NewTextRenderer().Render(ctx, music, canv, nil)
+		return
+	}
+
+	if *number <= 0 {
+		fmt.Println("Either -number or -file is required")
+		os.Exit(2)
+	}
+
+	cfg, err := config.InitConfig(*env)
+	if err != nil {
+		log.Fatalf("failed to load config, err : %s", err.Error())
+	}
+
+	db, err := storage.NewStorage(context.Background(), cfg.SQLite.DBPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to storage: %s", err.Error())
+	}
+	defer db.Close()
+
+	repo := repository.New(context.Background(), db)
+	usecaseMod := usecase.New(cfg, repo, renderer.NewRenderer())
+
+	var variants []string
+	if *variant != "" {
+		variants = append(variants, *variant)
+	}
+
+	result, err := adapter.NewRenderString(usecaseMod, cfg.Assets.FontPath).RenderHymnText(ctx, bytes.NewBuffer(nil), *number, variants...)
+	if err != nil {
+		log.Fatalf("Failed to render the hymn: %s", err.Error())
+	}
+	fmt.Print(result)
+}
@@ -15,3 +15,6 @@ const SPACE_LENGTH = 7
 const TITLE_Y_POS = 35
 
 const AVERAGE_CHARACTER_WIDTH = 8
+
+// the space taken by each grace note before its main note
+const GRACE_NOTE_WIDTH = 8
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"strconv"
+
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 )
@@ -33,3 +35,20 @@ type HymnMetaData struct {
 	*repository.HymnMetadata
 	ParsedVerse map[int][][]LyricWordVerse
 }
+
+// HymnKey returns the number of the hymn with its variant (e.g. 3a), empty when there is no metadata
+func (hm *HymnMetaData) HymnKey() string {
+	if hm == nil || hm.HymnMetadata == nil {
+		return ""
+	}
+
+	return strconv.Itoa(hm.Number) + hm.Variant.String
+}
+
+// TotalVerses returns the number of the verses of the hymn, one when there is no metadata
+func (hm *HymnMetaData) TotalVerses() int {
+	if hm == nil {
+		return 1
+	}
+	return hm.HymnMetadata.TotalVerses()
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHymnMetaData_TotalVerses(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *HymnMetaData
+		want     int
+	}{
+		{
+			name: "no metadata",
+			want: 1,
+		},
+		{
+			name:     "no verse",
+			metadata: &HymnMetaData{HymnMetadata: &repository.HymnMetadata{}},
+			want:     1,
+		},
+		{
+			name: "the first verse is on the score",
+			metadata: &HymnMetaData{HymnMetadata: &repository.HymnMetadata{
+				Verse: map[int]repository.HymnVerse{2: {}, 3: {}, 4: {}},
+			}},
+			want: 4,
+		},
+		{
+			name: "the first verse is stored",
+			metadata: &HymnMetaData{HymnMetadata: &repository.HymnMetadata{
+				Verse: map[int]repository.HymnVerse{1: {}, 2: {}, 3: {}},
+			}},
+			want: 3,
+		},
+		{
+			name: "the verse numbers are not contiguous",
+			metadata: &HymnMetaData{HymnMetadata: &repository.HymnMetadata{
+				Verse: map[int]repository.HymnVerse{2: {}, 5: {}},
+			}},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.metadata.TotalVerses())
+		})
+	}
+}
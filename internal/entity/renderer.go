@@ -53,8 +53,22 @@ var (
 	ArticulationTypesBreathMark ArticulationTypes = "breathMark"
 )
 
+// GraceNote is the ornament rendered before its main note, it takes no beat time
+type GraceNote struct {
+	Note          int
+	Octave        int
+	Strikethrough bool
+	Slash         bool
+
+	AbsoluteNote       string
+	AbsoluteOctave     int
+	AbsoluteAccidental musicxml.NoteAccidental
+}
+
 type NoteRenderer struct {
 	UUID string
+	// the stable id of the note, see NoteID
+	NoteID string
 
 	AbsoluteNote       string
 	AbsoluteOctave     int
@@ -80,11 +94,22 @@ type NoteRenderer struct {
 	Tie           *Slur
 	Width         int
 
+	GraceNotes []GraceNote
+	Dynamics   string
+	Wedges     []musicxml.Wedge
+
+	ChordSymbol string
+	// the new key (e.g. do = f) on the first note of the measure where the key changes
+	KeyChange string
+	// the active clef, nil is the treble clef
+	Clef *musicxml.Clef
+
 	// internal use
 	IndexPosition          int
 	IsAdditional           bool
 	IsLengthTakenFromLyric bool
 	IsNewLine              bool
+	IsPickupMeasure        bool
 	MeasureNumber          int
 
 	LeadingHeader     string
@@ -94,6 +119,16 @@ type NoteRenderer struct {
 	Tuplet            *musicxml.Tuplet
 }
 
+// NoteID is the id of the note that stays the same on every render, it is where the note is written:
+// the hymn, the part, the measure number and the position of the note element in the measure
+func NoteID(hymn, part string, measure, element int) string {
+	id := fmt.Sprintf("%s-%d-%d", part, measure, element)
+	if hymn != "" {
+		id = hymn + "-" + id
+	}
+	return "note-" + id
+}
+
 func (nr *NoteRenderer) GetNonAccidentalAbsoluteNote() string {
 	return fmt.Sprintf("%s%d", nr.AbsoluteNote, nr.AbsoluteOctave)
 }
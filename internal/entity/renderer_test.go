@@ -44,6 +44,38 @@ func TestLyricVal_String(t *testing.T) {
 	}
 }
 
+func TestNoteID(t *testing.T) {
+	tests := []struct {
+		name    string
+		hymn    string
+		part    string
+		measure int
+		element int
+		want    string
+	}{
+		{
+			name:    "with the hymn",
+			hymn:    "3a",
+			part:    "P1",
+			measure: 2,
+			element: 5,
+			want:    "note-3a-P1-2-5",
+		},
+		{
+			name:    "without the hymn",
+			part:    "P2",
+			measure: 1,
+			element: 0,
+			want:    "note-P2-1-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NoteID(tt.hymn, tt.part, tt.measure, tt.element))
+		})
+	}
+}
+
 func TestNoteRenderer_UpdateBeam(t *testing.T) {
 
 	t.Run("UpdateBeam", func(t *testing.T) {
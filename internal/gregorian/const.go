@@ -92,3 +92,9 @@ var noteType = map[float64]musicxml.NoteLength{
 	1:    musicxml.NoteLengthQuarter,
 	2:    musicxml.NoteLengthHalf,
 }
+
+const (
+	GRACE_NOTE_FONT_SIZE = `style="font-size:20px"`
+	GRACE_STEM_X         = 5.5
+	GRACE_STEM_LENGTH    = 16
+)
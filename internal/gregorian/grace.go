@@ -0,0 +1,42 @@
+package gregorian
+
+import (
+	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	stfline "github.com/jodi-ivan/numbered-notation-xml/internal/staff/lines"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+// RenderGraceNotes renders the grace notes as small noteheads with the stem up before the main note.
+// the acciaccatura (slashed grace note) has the slash across its stem.
+func RenderGraceNotes(canv canvas.Canvas, staffLines stfline.LineStaff, note *entity.NoteRenderer) {
+	if len(note.GraceNotes) == 0 {
+		return
+	}
+
+	canv.Group(`class="grace-notes"`, GRACE_NOTE_FONT_SIZE)
+	defer canv.Gend()
+
+	xPos := float64(note.PositionX - len(note.GraceNotes)*constant.GRACE_NOTE_WIDTH)
+	for _, grace := range note.GraceNotes {
+		if grace.AbsoluteNote == "" {
+			xPos += constant.GRACE_NOTE_WIDTH
+			continue
+		}
+
+		yPos := staffLines.GetYPosWithClef(rune(grace.AbsoluteNote[0]), grace.AbsoluteOctave, note.Clef)
+		canv.TextUnescaped(xPos, yPos, beanNoteHex[musicxml.NoteLengthQuarter])
+
+		stemX := int(xPos + GRACE_STEM_X)
+		stemTop := int(yPos) - GRACE_STEM_LENGTH
+		canv.Line(stemX, int(yPos), stemX, stemTop, "fill:none;stroke:#000000;stroke-width:0.8")
+
+		if grace.Slash {
+			canv.Line(stemX-3, int(yPos)-4, stemX+4, stemTop+3, "fill:none;stroke:#000000;stroke-width:0.8")
+		}
+
+		RenderLedgerLine(canv, entity.NewCoordinate(xPos, yPos), staffLines.GetTopLine(), staffLines.GetBottomLine())
+		xPos += constant.GRACE_NOTE_WIDTH
+	}
+}
@@ -28,6 +28,7 @@ type gregorianInteractor struct{}
 func (gi *gregorianInteractor) RenderStaffLine(ctx context.Context, staffPos, y int, canv canvas.Canvas, notes []*entity.NoteRenderer, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature) VMargin {
 
 	lineStaff := lines.NewLineStaff(timeSignature, keySignature)
+	lineStaff.Clef = getLineClef(notes)
 	lineStaff.Render(canv, y, notes[0].MeasureNumber, staffPos == 0)
 	margin := VMargin{
 		Top:           entity.NewCoordinate(0, float64(lineStaff.GetTopLine())),
@@ -42,6 +43,7 @@ func (gi *gregorianInteractor) RenderStaffLine(ctx context.Context, staffPos, y
 	currentMeasure := 0
 
 	groupBeamSlurTies := rhythm.GetGroupSlueTies(notes, lineStaff) // mock this
+	currentClef := lineStaff.Clef
 
 	for i, note := range notes {
 
@@ -90,6 +92,11 @@ func (gi *gregorianInteractor) RenderStaffLine(ctx context.Context, staffPos, y
 			continue
 		}
 
+		if isClefChanged(currentClef, note.Clef) {
+			lines.RenderClef(canv, float64(note.PositionX-lines.CLEF_CHANGE_X_OFFSET), lineStaff.GetTopLine(), note.Clef, true)
+		}
+		currentClef = note.Clef
+
 		var noteMargin VMargin
 		pairs := []rhythm.SlurTieGroup{}
 		noteMargin, groupBeam, pairs = RenderNote(ctx, canv, lineStaff, groupBeam, groupBeamSlurTies, i, notes, timeSignature, keySignature) // mock this
@@ -126,3 +133,24 @@ func (gi *gregorianInteractor) RenderStaffLine(ctx context.Context, staffPos, y
 
 	return margin
 }
+
+// getLineClef returns the clef of the first note on the line
+func getLineClef(notes []*entity.NoteRenderer) *musicxml.Clef {
+	for _, note := range notes {
+		if note.AbsoluteNote != "" && !note.IsRest {
+			return note.Clef
+		}
+	}
+	return nil
+}
+
+func isClefChanged(prev, next *musicxml.Clef) bool {
+	before, after := musicxml.TrebleClef, musicxml.TrebleClef
+	if prev != nil {
+		before = *prev
+	}
+	if next != nil {
+		after = *next
+	}
+	return !before.IsEqual(after)
+}
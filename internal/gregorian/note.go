@@ -65,7 +65,7 @@ func setMarginTop(notes []*entity.NoteRenderer, lineStaff lines.LineStaff) {
 		if note.Note == 0 {
 			continue
 		}
-		beanPos := lineStaff.GetYPos(rune(note.AbsoluteNote[0]), note.AbsoluteOctave)
+		beanPos := lineStaff.GetYPosWithClef(rune(note.AbsoluteNote[0]), note.AbsoluteOctave, note.Clef)
 		maxY := beanPos
 
 		if note.StemDirection == 1 {
@@ -84,7 +84,7 @@ func setMarginTop(notes []*entity.NoteRenderer, lineStaff lines.LineStaff) {
 func getAdditionalNotes(ctx context.Context, ts timesig.TimeSignature, notes []*entity.NoteRenderer, staffLines lines.LineStaff, notePos, accumulativeDirection int, groupBeam *[][]entity.CoordinateWithNoteLength) *entity.NoteRenderer {
 	note := notes[notePos]
 
-	yPos := staffLines.GetYPos(rune(note.AbsoluteNote[0]), note.AbsoluteOctave)
+	yPos := staffLines.GetYPosWithClef(rune(note.AbsoluteNote[0]), note.AbsoluteOctave, note.Clef)
 
 	// check it can represent by a note
 	nonDottedValue := ts.GetNoteLength(ctx, note.MeasureNumber, musicxml.Note{Type: note.NoteLength})
@@ -203,7 +203,7 @@ func RenderNote(ctx context.Context, canv canvas.Canvas, staffLines stfline.Line
 	}
 
 	pairs := []rhythm.SlurTieGroup{}
-	yPos := staffLines.GetYPos(rune(note.AbsoluteNote[0]), note.AbsoluteOctave)
+	yPos := staffLines.GetYPosWithClef(rune(note.AbsoluteNote[0]), note.AbsoluteOctave, note.Clef)
 	currentPos := entity.NewCoordinate(float64(note.PositionX), yPos)
 	margin.Set(currentPos)
 
@@ -274,6 +274,8 @@ func RenderNote(ctx context.Context, canv canvas.Canvas, staffLines stfline.Line
 
 	xPos := float64(note.PositionX)
 
+	RenderGraceNotes(canv, staffLines, note)
+
 	isDotted := isDottedNote(notes, notePos, timeSignature)
 	renderBean(canv,
 		entity.NewCoordinate(xPos, yPos),
@@ -18,7 +18,7 @@ import (
 
 type Header interface {
 	RenderSheetHeader(ctx context.Context, canv canvas.Canvas, credit []musicxml.Credit, metadata *entity.HymnMetaData)
-	RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature)
+	RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature, tempo ...musicxml.Metronome)
 }
 
 type headerInteractor struct {
@@ -41,9 +41,9 @@ func hasTitleNotes(verseNotes *entity.HymnMetaData) bool {
 	}
 	return false
 }
-func (hi *headerInteractor) renderTitle(ctx context.Context, canv canvas.Canvas, credit []musicxml.Credit, metadata *entity.HymnMetaData) {
-	relativeY := constant.TITLE_Y_POS
 
+// WorkTitle is the title on top of the sheet, the hymn number is added when the metadata exists
+func WorkTitle(credit []musicxml.Credit, metadata *entity.HymnMetaData) string {
 	workTitle := ""
 	for _, v := range credit {
 		if v.Type == musicxml.CreditTypeTitle {
@@ -59,11 +59,18 @@ func (hi *headerInteractor) renderTitle(ctx context.Context, canv canvas.Canvas,
 		if metadata.TitleFootnotes.Valid || hasTitleNotes(metadata) {
 			workTitle += TITLE_FOOTNOTES
 		}
-		if metadata.IsForKids.Int16 == 1 {
-			canv.TextUnescaped(
-				constant.LAYOUT_INDENT_LENGTH, float64(relativeY),
-				FOR_KIDS_ELMNT)
-		}
+	}
+	return workTitle
+}
+
+func (hi *headerInteractor) renderTitle(ctx context.Context, canv canvas.Canvas, credit []musicxml.Credit, metadata *entity.HymnMetaData) {
+	relativeY := constant.TITLE_Y_POS
+
+	workTitle := WorkTitle(credit, metadata)
+	if metadata != nil && metadata.IsForKids.Int16 == 1 {
+		canv.TextUnescaped(
+			constant.LAYOUT_INDENT_LENGTH, float64(relativeY),
+			FOR_KIDS_ELMNT)
 	}
 	titleWidth := hi.Lyric.CalculateLyricWidth(workTitle)
 	titleX := (constant.LAYOUT_WIDTH / 2) - (titleWidth * 0.5)
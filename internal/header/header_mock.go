@@ -40,15 +40,20 @@ func (m *MockHeader) EXPECT() *MockHeaderMockRecorder {
 }
 
 // RenderKeyandTimeSignatures mocks base method.
-func (m *MockHeader) RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature) {
+func (m *MockHeader) RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature, tempo ...musicxml.Metronome) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RenderKeyandTimeSignatures", ctx, canv, key, timeSignature)
+	varargs := []interface{}{ctx, canv, key, timeSignature}
+	for _, a := range tempo {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "RenderKeyandTimeSignatures", varargs...)
 }
 
 // RenderKeyandTimeSignatures indicates an expected call of RenderKeyandTimeSignatures.
-func (mr *MockHeaderMockRecorder) RenderKeyandTimeSignatures(ctx, canv, key, timeSignature interface{}) *gomock.Call {
+func (mr *MockHeaderMockRecorder) RenderKeyandTimeSignatures(ctx, canv, key, timeSignature interface{}, tempo ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderKeyandTimeSignatures", reflect.TypeOf((*MockHeader)(nil).RenderKeyandTimeSignatures), ctx, canv, key, timeSignature)
+	varargs := append([]interface{}{ctx, canv, key, timeSignature}, tempo...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderKeyandTimeSignatures", reflect.TypeOf((*MockHeader)(nil).RenderKeyandTimeSignatures), varargs...)
 }
 
 // RenderSheetHeader mocks base method.
@@ -6,13 +6,36 @@ import (
 
 	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 )
 
-func (hi *headerInteractor) RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature) {
+// RenderKeyandTimeSignatures renders the key, the time signature and the tempo marking (when given) on the header
+func (hi *headerInteractor) RenderKeyandTimeSignatures(ctx context.Context, canv canvas.Canvas, key keysig.KeySignature, timeSignature timesig.TimeSignature, tempo ...musicxml.Metronome) {
 
 	relativeY := constant.TITLE_Y_POS + SIGNATURES_Y_POS
+	humanized := HumanizedKey(ctx, key)
+	canv.Text(constant.LAYOUT_INDENT_LENGTH, relativeY, humanized)
+	xPos := constant.LAYOUT_INDENT_LENGTH + (3 * constant.LOWERCASE_LENGTH) + int(hi.Lyric.CalculateLyricWidth(humanized))
+
+	humanizedTimeSignature := ""
+	if !timeSignature.IsEmpty() {
+		humanizedTimeSignature = timeSignature.GetHumanized()
+		canv.Text(xPos, relativeY, humanizedTimeSignature)
+	}
+
+	if len(tempo) > 0 {
+		if humanizedTimeSignature != "" {
+			xPos += (3 * constant.LOWERCASE_LENGTH) + int(hi.Lyric.CalculateLyricWidth(humanizedTimeSignature))
+		}
+		canv.Text(xPos, relativeY, tempo[0].String())
+	}
+
+}
+
+// HumanizedKey is the key on the first measure (e.g. do = d), followed by the other keys when the key changes more than once
+func HumanizedKey(ctx context.Context, key keysig.KeySignature) string {
 	currKeySig := key.GetKeyOnMeasure(ctx, 1)
 	humanized := currKeySig.String()
 	if len(key.Signatures) > 2 {
@@ -24,11 +47,5 @@ func (hi *headerInteractor) RenderKeyandTimeSignatures(ctx context.Context, canv
 			humanized += " - " + strings.Split(v.String(), "=")[1]
 		}
 	}
-	canv.Text(constant.LAYOUT_INDENT_LENGTH, relativeY, humanized)
-
-	if !timeSignature.IsEmpty() {
-		humanizedTimeSignature := timeSignature.GetHumanized()
-		canv.Text(constant.LAYOUT_INDENT_LENGTH+(3*constant.LOWERCASE_LENGTH)+int(hi.Lyric.CalculateLyricWidth(humanized)), relativeY, humanizedTimeSignature)
-	}
-
+	return humanized
 }
@@ -22,6 +22,7 @@ func Test_headerInteractor_RenderKeyandTimeSignatures(t *testing.T) {
 		lyricMock     func(*gomock.Controller) *lyric.MockLyric
 		key           keysig.KeySignature
 		timeSignature timesig.TimeSignature
+		tempo         []musicxml.Metronome
 	}{
 		{
 			name: "BAU",
@@ -60,6 +61,48 @@ func Test_headerInteractor_RenderKeyandTimeSignatures(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "with tempo",
+			canv: func(c *gomock.Controller) *canvas.MockCanvas {
+				canv := canvas.NewMockCanvas(c)
+				canv.EXPECT().Text(50, 60, "do = c")
+				canv.EXPECT().Text(175, 60, "4 ketuk")
+				canv.EXPECT().Text(285, 60, "♩ = 88")
+				return canv
+			},
+			lyricMock: func(c *gomock.Controller) *lyric.MockLyric {
+				li := lyric.NewMockLyric(c)
+				li.EXPECT().CalculateLyricWidth("do = c").Return(80.0)
+				li.EXPECT().CalculateLyricWidth("4 ketuk").Return(65.0)
+				return li
+			},
+			key: keysig.NewKeySignature(context.Background(), []musicxml.Measure{
+				{
+					Attribute: &musicxml.Attribute{
+						Key: &musicxml.KeySignature{
+							Fifth: 0,
+							Mode:  "Major",
+						},
+					},
+				},
+			}),
+			timeSignature: timesig.NewTimeSignatures(context.Background(), []musicxml.Measure{
+				{
+					Attribute: &musicxml.Attribute{
+						Time: &struct {
+							Beats    int "xml:\"beats\""
+							BeatType int "xml:\"beat-type\""
+						}{
+							Beats:    4,
+							BeatType: 4,
+						},
+					},
+				},
+			}),
+			tempo: []musicxml.Metronome{
+				{BeatUnit: musicxml.NoteLengthQuarter, PerMinute: "88"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,7 +114,7 @@ func Test_headerInteractor_RenderKeyandTimeSignatures(t *testing.T) {
 			if tt.canv != nil {
 				canv = tt.canv(ctrl)
 			}
-			hi.RenderKeyandTimeSignatures(context.Background(), canv, tt.key, tt.timeSignature)
+			hi.RenderKeyandTimeSignatures(context.Background(), canv, tt.key, tt.timeSignature, tt.tempo...)
 		})
 	}
 }
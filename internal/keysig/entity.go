@@ -1,5 +1,10 @@
 package keysig
 
+import "github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+
+// the octave of the do of the key, the do without the octave mark
+const DEFAULT_DO_OCTAVE = 4
+
 type KeySignatureMode int
 
 const (
@@ -8,14 +13,26 @@ const (
 	KeySignatureModeDorian     KeySignatureMode = 2
 	KeySignatureModePhrygian   KeySignatureMode = 3
 	KeySignatureModeMixolydian KeySignatureMode = 4
+	KeySignatureModeLydian     KeySignatureMode = 5
+	KeySignatureModeLocrian    KeySignatureMode = 6
 )
 
 func (ksm KeySignatureMode) String() string {
-	return []string{"major", "minor", "dorian", "phrygian", "mixolydian"}[int(ksm)]
+	return []string{"major", "minor", "dorian", "phrygian", "mixolydian", "lydian", "locrian"}[int(ksm)]
 }
 
 func (ksm KeySignatureMode) GetNumberedRoot() string {
-	return []string{"do", "la", "re", "mi", "sol"}[int(ksm)]
+	return []string{"do", "la", "re", "mi", "sol", "fa", "si"}[int(ksm)]
+}
+
+var letters = []string{"C", "D", "E", "F", "G", "A", "B"}
+
+// the accidental of the alter on the non-traditional key
+var alterAccidentals = map[float64]musicxml.NoteAccidental{
+	-2: musicxml.NoteAccidentalDoubleFlat,
+	-1: musicxml.NoteAccidentalFlat,
+	1:  musicxml.NoteAccidentalSharp,
+	2:  musicxml.NoteAccidentalDoubleSharp,
 }
 
 var accidentalsSet = map[int][]string{
@@ -54,6 +71,12 @@ var modeRoot = map[string]map[int]string{
 		0:  "E",
 		-1: "A", -2: "D", -3: "G", -4: "C", -5: "F", -6: "Bb", -7: "Eb",
 	},
+	"lydian": map[int]string{
+		// 4th degree of Major
+		7: "F#", 6: "B", 5: "E", 4: "A", 3: "D", 2: "G", 1: "C",
+		0:  "F",
+		-1: "Bb", -2: "Eb", -3: "Ab", -4: "Db", -5: "Gb", -6: "Cb", -7: "Fb",
+	},
 	"mixolydian": map[int]string{
 		// 5th degree of Major
 		7: "G#", 6: "C#", 5: "F#", 4: "B", 3: "E", 2: "A", 1: "D",
@@ -66,6 +89,12 @@ var modeRoot = map[string]map[int]string{
 		0:  "A",
 		-1: "D", -2: "G", -3: "C", -4: "F", -5: "Bb", -6: "Eb", -7: "Ab",
 	},
+	"locrian": map[int]string{
+		// 7th degree of Major
+		7: "B#", 6: "E#", 5: "A#", 4: "D#", 3: "G#", 2: "C#", 1: "F#",
+		0:  "B",
+		-1: "E", -2: "A", -3: "D", -4: "G", -5: "C", -6: "F", -7: "Bb",
+	},
 }
 
 var modeSteps = map[string][]float64{
@@ -114,4 +143,22 @@ var modeSteps = map[string][]float64{
 		0.5, // la -> si (The flat 7th)
 		1,   // si -> do
 	},
+	"lydian": []float64{
+		1,   // do -> re
+		1,   // re -> mi
+		1,   // mi -> fa (The raised 4th)
+		0.5, // fa -> sol
+		1,   // sol -> la
+		1,   // la -> si (ti)
+		0.5, // si -> do
+	},
+	"locrian": []float64{
+		0.5, // do -> re
+		1,   // re -> mi
+		1,   // mi -> fa
+		0.5, // fa -> sol (The flat 5th)
+		1,   // sol -> la
+		1,   // la -> si (ti)
+		1,   // si -> do
+	},
 }
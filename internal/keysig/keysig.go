@@ -3,11 +3,18 @@ package keysig
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
 )
 
+// KeyAlter is the altered letter of the non-traditional key
+type KeyAlter struct {
+	Step  string
+	Alter float64
+}
+
 type Key struct {
 	Key       string
 	Mode      Mode
@@ -16,6 +23,13 @@ type Key struct {
 	Measure   int
 	Start     bool
 	Prev      *Key
+	// the element of the measure where the key changes, zero is the start of the measure. see musicxml.AttributeChange
+	Element int
+	// the altered letters of the non-traditional key (<key-step> and <key-alter>), in the order of the key signature.
+	// empty on the traditional key, the fifth is the nearest traditional key
+	Alters []KeyAlter
+	// the octave of the do without the octave mark, zero is the default octave
+	DoOctave int
 }
 
 type KeySignature struct {
@@ -23,16 +37,30 @@ type KeySignature struct {
 	Signatures []Key
 }
 
-// TODO: support more than once key known (processed) signature changes. currently only support once or all keys
+// NewKeySignature collects the keys of the measures, the key restated without any change is skipped
 func NewKeySignature(ctx context.Context, measures []musicxml.Measure) KeySignature {
 	signatures := []Key{}
 
+	add := func(key Key) {
+		if len(signatures) > 0 && signatures[len(signatures)-1].IsSame(key) {
+			return
+		}
+		signatures = append(signatures, key)
+	}
+
 	for _, measure := range measures {
 		if measure.Attribute != nil && measure.Attribute.Key != nil {
 			key := NewKey(measure.Attribute.Key)
 			key.Measure = measure.Number
-			signatures = append(signatures, key)
+			add(key)
+		}
 
+		for _, change := range measure.AttributeChanges {
+			if change.Attribute.Key != nil {
+				key := NewKey(change.Attribute.Key)
+				key.Measure, key.Element = measure.Number, change.Element
+				add(key)
+			}
 		}
 	}
 
@@ -43,39 +71,34 @@ func NewKeySignature(ctx context.Context, measures []musicxml.Measure) KeySignat
 
 }
 
+// GetKeyOnMeasure returns the key of the measure, the last key started on or before the measure.
+// the previous key is attached on the key changes
 func (ks *KeySignature) GetKeyOnMeasure(ctx context.Context, measure int) Key {
+	return ks.GetKeyAt(ctx, measure, 0)
+}
+
+// GetKeyAt returns the key of the element of the measure, the key changed in the middle of the measure
+// is used from its element onward
+func (ks *KeySignature) GetKeyAt(ctx context.Context, measure, element int) Key {
+	if len(ks.Signatures) == 0 {
+		return NewKey(&musicxml.KeySignature{})
+	}
 	if len(ks.Signatures) == 1 {
 		return ks.Signatures[0]
 	}
 
-	// get the time
-	currentTime := ks.Signatures[0]
-
-	counter := 0
-	var prev Key
-	prev = ks.Signatures[0]
-	found := true
-
-	for currentTime.Measure <= measure && counter < len(ks.Signatures) {
-		prev = currentTime
-		currentTime = ks.Signatures[counter]
-		counter++
-
-		if currentTime.Measure <= measure && counter == len(ks.Signatures) {
-			found = false
+	current := 0
+	for i, key := range ks.Signatures {
+		if key.Measure < measure || (key.Measure == measure && key.Element <= element) {
+			current = i
 		}
-
 	}
 
-	result := prev
-	result.Prev = &currentTime
-	if !found {
-		result = ks.Signatures[len(ks.Signatures)-1]
-		if len(ks.Signatures) > 1 {
-			result.Prev = &ks.Signatures[len(ks.Signatures)-2]
-		}
+	result := ks.Signatures[current]
+	if current > 0 {
+		result.Prev = &ks.Signatures[current-1]
 	}
-	result.Start = result.Measure == measure
+	result.Start = result.Measure == measure && result.Element == element
 	return result
 }
 
@@ -85,15 +108,90 @@ func NewKey(key *musicxml.KeySignature) Key {
 		keyMode = "major"
 	}
 	fifths := key.Fifth
-
+	alters := []KeyAlter{}
+	for i, step := range key.Steps {
+		if i < len(key.Alters) && key.Alters[i] != 0 {
+			alters = append(alters, KeyAlter{Step: step, Alter: key.Alters[i]})
+		}
+	}
 	mode := NewMode(keyMode)
+	if len(key.Steps) > 0 {
+		fifths, alters = nearestFifth(mode, alters)
+	}
 
-	return Key{
-		Fifth:     fifths,
-		Key:       mode.GetRoot(fifths),
-		Mode:      mode,
-		Humanized: mode.GetHumanized(fifths),
+	result := Key{
+		Fifth:    fifths,
+		DoOctave: key.DoOctave,
 	}
+	if len(alters) > 0 {
+		result.Alters = alters
+		// the root follows the alter of its letter on the key
+		root := string(modeRoot[mode.String()][fifths][0])
+		mode.rootLettered = root + result.GetKeyAccidental(root).GetAccidental()
+	}
+
+	result.Key = mode.GetRoot(fifths)
+	result.Humanized = mode.GetHumanized(fifths)
+	result.Mode = mode
+
+	return result
+}
+
+// nearestFifth finds the traditional key of the altered letters, the key with the fewest different letters
+// and the root of the mode kept. the altered letters are returned only when none of the traditional key matches
+func nearestFifth(mode Mode, alters []KeyAlter) (int, []KeyAlter) {
+	bestFifth, bestDiff := 0, len(letters)+1
+	for _, fifth := range []int{0, -1, 1, -2, 2, -3, 3, -4, 4, -5, 5, -6, 6, -7, 7} {
+		traditional := Key{Fifth: fifth}
+		altered := Key{Alters: alters}
+		diff := 0
+		for _, letter := range letters {
+			if traditional.GetKeyAccidental(letter) != altered.GetKeyAccidental(letter) {
+				diff++
+			}
+		}
+
+		if diff == 0 {
+			return fifth, nil
+		}
+
+		root := string(modeRoot[mode.String()][fifth][0])
+		if traditional.GetKeyAccidental(root) != altered.GetKeyAccidental(root) {
+			diff++
+		}
+		if diff < bestDiff {
+			bestFifth, bestDiff = fifth, diff
+		}
+	}
+
+	return bestFifth, alters
+}
+
+// RelativeMajor returns the major key of the same key signature, e.g. C major of A minor
+func (ks Key) RelativeMajor() Key {
+	if ks.Mode.Mode == KeySignatureModeMajor {
+		return ks
+	}
+
+	result := ks
+	result.Mode = NewMode("major")
+	if len(ks.Alters) > 0 {
+		root := string(modeRoot[result.Mode.String()][ks.Fifth][0])
+		result.Mode.rootLettered = root + ks.GetKeyAccidental(root).GetAccidental()
+	}
+	result.Key = result.Mode.GetRoot(result.Fifth)
+	result.Humanized = result.Mode.GetHumanized(result.Fifth)
+	return result
+}
+
+// IsSame returns true when both keys have the same tonic and the same accidentals
+func (ks Key) IsSame(other Key) bool {
+	return ks.Fifth == other.Fifth && ks.Mode.Mode == other.Mode.Mode && slices.Equal(ks.Alters, other.Alters)
+}
+
+// IsChanged returns true on the measure where the key changes from the previous key
+func (ks Key) IsChanged() bool {
+	return ks.Start && ks.Prev != nil
 }
 
 func (ks *Key) String() string {
@@ -106,20 +204,49 @@ func (ks *Key) GetBasedPitch() string {
 	return result
 }
 
+// GetDoOctave returns the octave of the do without the octave mark
+func (ks Key) GetDoOctave() int {
+	if ks.DoOctave == 0 {
+		return DEFAULT_DO_OCTAVE
+	}
+	return ks.DoOctave
+}
+
 func (ks *Key) GetAccidentals() []string {
+	if len(ks.Alters) > 0 {
+		result := make([]string, 0, len(ks.Alters))
+		for _, alter := range ks.Alters {
+			result = append(result, alter.Step)
+		}
+		return result
+	}
 	return accidentalsSet[ks.Fifth]
 }
-func (ks Key) GetPitchWithAccidental(note musicxml.Note) string {
-	pitch := note.Pitch.Step
-	var accidental musicxml.NoteAccidental
 
-	if utils.Contains(accidentalsSet[ks.Fifth], pitch) >= 0 {
+// GetKeyAccidental returns the accidental of the letter on the key signature, empty when the letter is not altered
+func (ks Key) GetKeyAccidental(letter string) musicxml.NoteAccidental {
+	if len(ks.Alters) > 0 {
+		for _, alter := range ks.Alters {
+			if alter.Step == letter {
+				return alterAccidentals[alter.Alter]
+			}
+		}
+		return ""
+	}
+
+	if utils.Contains(accidentalsSet[ks.Fifth], letter) >= 0 {
 		if ks.Fifth > 0 {
-			accidental = musicxml.NoteAccidentalSharp
+			return musicxml.NoteAccidentalSharp
 		} else if ks.Fifth < 0 {
-			accidental = musicxml.NoteAccidentalFlat
+			return musicxml.NoteAccidentalFlat
 		}
 	}
+	return ""
+}
+
+func (ks Key) GetPitchWithAccidental(note musicxml.Note) string {
+	pitch := note.Pitch.Step
+	accidental := ks.GetKeyAccidental(pitch)
 
 	if note.Accidental != "" {
 		accidental = note.Accidental
@@ -134,13 +261,9 @@ func (ks Key) GetLetteredKeySignature() string {
 }
 
 func (ks Key) BuildScale() []string {
-
-	letters := []string{"C", "D", "E", "F", "G", "A", "B"}
-
 	tonic := modeRoot[ks.Mode.Mode.String()][ks.Fifth]
 	tonicLetter := string(tonic[0])
 
-	var scaleLetters []string
 	startIndex := 0
 	for i, l := range letters {
 		if l == tonicLetter {
@@ -149,35 +272,10 @@ func (ks Key) BuildScale() []string {
 		}
 	}
 
-	for i := 0; i < 7; i++ {
-		scaleLetters = append(scaleLetters, letters[(startIndex+i)%7])
-	}
-
-	accidentals := accidentalsSet[ks.Fifth]
-	isSharpKey := ks.Fifth > 0
-	isFlatKey := ks.Fifth < 0
-
 	var scale []string
-
-	for _, letter := range scaleLetters {
-
-		altered := false
-
-		for _, accLetter := range accidentals {
-			if letter == accLetter {
-				if isSharpKey {
-					scale = append(scale, letter+"#")
-				} else if isFlatKey {
-					scale = append(scale, letter+"b")
-				}
-				altered = true
-				break
-			}
-		}
-
-		if !altered {
-			scale = append(scale, letter)
-		}
+	for i := 0; i < 7; i++ {
+		letter := letters[(startIndex+i)%7]
+		scale = append(scale, letter+ks.GetKeyAccidental(letter).GetAccidental())
 	}
 
 	return scale
@@ -1,6 +1,7 @@
 package keysig
 
 import (
+	"context"
 	"testing"
 
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
@@ -47,6 +48,60 @@ func TestNewKeySignature(t *testing.T) {
 				Humanized: "la = a",
 			},
 		},
+		{
+			name: "F lydian",
+			args: args{
+				key: &musicxml.KeySignature{
+					Mode: "lydian",
+				},
+			},
+			want: Key{
+				Key: "F",
+				Mode: Mode{
+					rootLettered: "F",
+					humanized:    "fa = f",
+					Mode:         KeySignatureModeLydian,
+				},
+				Humanized: "fa = f",
+			},
+		},
+		{
+			name: "non-traditional key of the traditional letters, expecting to be E major",
+			args: args{
+				key: &musicxml.KeySignature{
+					Steps:  []string{"F", "C", "G", "D"},
+					Alters: []float64{1, 1, 1, 1},
+				},
+			},
+			want: Key{
+				Key:   "E",
+				Fifth: 4,
+				Mode: Mode{
+					rootLettered: "E",
+					humanized:    "do = e",
+				},
+				Humanized: "do = e",
+			},
+		},
+		{
+			name: "non-traditional key, expecting to keep the letters",
+			args: args{
+				key: &musicxml.KeySignature{
+					Steps:  []string{"B", "F", "C"},
+					Alters: []float64{-1, 1, 0},
+				},
+			},
+			want: Key{
+				Key:   "G",
+				Fifth: 1,
+				Mode: Mode{
+					rootLettered: "G",
+					humanized:    "do = g",
+				},
+				Humanized: "do = g",
+				Alters:    []KeyAlter{{Step: "B", Alter: -1}, {Step: "F", Alter: 1}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -153,6 +208,28 @@ func TestKeySignature_GetPitchWithAccidental(t *testing.T) {
 	}
 }
 
+func TestKey_GetKeyAccidental(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    Key
+		letter string
+		want   musicxml.NoteAccidental
+	}{
+		{name: "sharp key", key: Key{Fifth: 2}, letter: "C", want: musicxml.NoteAccidentalSharp},
+		{name: "flat key", key: Key{Fifth: -2}, letter: "E", want: musicxml.NoteAccidentalFlat},
+		{name: "not on the key", key: Key{Fifth: -2}, letter: "F", want: ""},
+		{name: "non-traditional flat", key: Key{Fifth: 1, Alters: []KeyAlter{{Step: "B", Alter: -1}, {Step: "F", Alter: 1}}}, letter: "B", want: musicxml.NoteAccidentalFlat},
+		{name: "non-traditional sharp", key: Key{Fifth: 1, Alters: []KeyAlter{{Step: "B", Alter: -1}, {Step: "F", Alter: 1}}}, letter: "F", want: musicxml.NoteAccidentalSharp},
+		{name: "non-traditional double sharp", key: Key{Alters: []KeyAlter{{Step: "F", Alter: 2}}}, letter: "F", want: musicxml.NoteAccidentalDoubleSharp},
+		{name: "non-traditional ignores the fifth", key: Key{Fifth: 1, Alters: []KeyAlter{{Step: "B", Alter: -1}}}, letter: "F", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.key.GetKeyAccidental(tt.letter))
+		})
+	}
+}
+
 func TestKeySignature_String(t *testing.T) {
 
 	t.Run("Getter", func(t *testing.T) {
@@ -293,26 +370,35 @@ func TestKeySignature_BuildScale(t *testing.T) {
 		{name: "E minor (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "minor"}, want: []string{"E", "F#", "G", "A", "B", "C", "D"}},
 		{name: "B minor (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "minor"}, want: []string{"B", "C#", "D", "E", "F#", "G", "A"}},
 
-		// // --- PHRYGIAN ---
-		// {name: "D phrygian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "phrygian"}, want: []string{"D", "Eb", "F", "G", "A", "Bb", "C"}},
-		// {name: "A phrygian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "phrygian"}, want: []string{"A", "Bb", "C", "D", "E", "F", "G"}},
-		// {name: "E phrygian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "phrygian"}, want: []string{"E", "F", "G", "A", "B", "C", "D"}},
-		// {name: "B phrygian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "phrygian"}, want: []string{"B", "C", "D", "E", "F#", "G", "A"}},
-		// {name: "F# phrygian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "phrygian"}, want: []string{"F#", "G", "A", "B", "C#", "D", "E"}},
+		// --- PHRYGIAN ---
+		{name: "D phrygian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "phrygian"}, want: []string{"D", "Eb", "F", "G", "A", "Bb", "C"}},
+		{name: "A phrygian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "phrygian"}, want: []string{"A", "Bb", "C", "D", "E", "F", "G"}},
+		{name: "E phrygian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "phrygian"}, want: []string{"E", "F", "G", "A", "B", "C", "D"}},
+		{name: "B phrygian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "phrygian"}, want: []string{"B", "C", "D", "E", "F#", "G", "A"}},
+		{name: "F# phrygian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "phrygian"}, want: []string{"F#", "G", "A", "B", "C#", "D", "E"}},
 
 		// --- LYDIAN ---
-		// {name: "Eb lydian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "lydian"}, want: []string{"Eb", "F", "G", "A", "Bb", "C", "D"}},
-		// {name: "Bb lydian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "lydian"}, want: []string{"Bb", "C", "D", "E", "F", "G", "A"}},
-		// {name: "F lydian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "lydian"}, want: []string{"F", "G", "A", "B", "C", "D", "E"}},
-		// {name: "C lydian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "lydian"}, want: []string{"C", "D", "E", "F#", "G", "A", "B"}},
-		// {name: "G lydian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "lydian"}, want: []string{"G", "A", "B", "C#", "D", "E", "F#"}},
+		{name: "Eb lydian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "lydian"}, want: []string{"Eb", "F", "G", "A", "Bb", "C", "D"}},
+		{name: "Bb lydian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "lydian"}, want: []string{"Bb", "C", "D", "E", "F", "G", "A"}},
+		{name: "F lydian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "lydian"}, want: []string{"F", "G", "A", "B", "C", "D", "E"}},
+		{name: "C lydian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "lydian"}, want: []string{"C", "D", "E", "F#", "G", "A", "B"}},
+		{name: "G lydian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "lydian"}, want: []string{"G", "A", "B", "C#", "D", "E", "F#"}},
+
+		// --- LOCRIAN ---
+		{name: "A locrian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "locrian"}, want: []string{"A", "Bb", "C", "D", "Eb", "F", "G"}},
+		{name: "E locrian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "locrian"}, want: []string{"E", "F", "G", "A", "Bb", "C", "D"}},
+		{name: "B locrian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "locrian"}, want: []string{"B", "C", "D", "E", "F", "G", "A"}},
+		{name: "F# locrian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "locrian"}, want: []string{"F#", "G", "A", "B", "C", "D", "E"}},
+		{name: "C# locrian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "locrian"}, want: []string{"C#", "D", "E", "F#", "G", "A", "B"}},
+
+		// --- the aliases ---
+		{name: "D aeolian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "aeolian"}, want: []string{"D", "E", "F", "G", "A", "Bb", "C"}},
+		{name: "G ionian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "Ionian"}, want: []string{"G", "A", "B", "C", "D", "E", "F#"}},
 
-		// // --- LOCRIAN ---
-		// {name: "A locrian (-2)", key: &musicxml.KeySignature{Fifth: -2, Mode: "locrian"}, want: []string{"A", "Bb", "C", "D", "Eb", "F", "G"}},
-		// {name: "E locrian (-1)", key: &musicxml.KeySignature{Fifth: -1, Mode: "locrian"}, want: []string{"E", "F", "G", "A", "Bb", "C", "D"}},
-		// {name: "B locrian (0)", key: &musicxml.KeySignature{Fifth: 0, Mode: "locrian"}, want: []string{"B", "C", "D", "E", "F", "G", "A"}},
-		// {name: "F# locrian (1)", key: &musicxml.KeySignature{Fifth: 1, Mode: "locrian"}, want: []string{"F#", "G", "A", "B", "C", "D", "E"}},
-		// {name: "C# locrian (2)", key: &musicxml.KeySignature{Fifth: 2, Mode: "locrian"}, want: []string{"C#", "D", "E", "F#", "G", "A", "B"}},
+		// --- NON-TRADITIONAL ---
+		{name: "D major spelled by the letters", key: &musicxml.KeySignature{Steps: []string{"F", "C"}, Alters: []float64{1, 1}}, want: []string{"D", "E", "F#", "G", "A", "B", "C#"}},
+		{name: "G major with the flat 6th", key: &musicxml.KeySignature{Steps: []string{"B", "F"}, Alters: []float64{-1, 1}}, want: []string{"G", "A", "Bb", "C", "D", "E", "F#"}},
+		{name: "G minor with the leading tone", key: &musicxml.KeySignature{Mode: "minor", Steps: []string{"B", "E", "F"}, Alters: []float64{-1, -1, 1}}, want: []string{"G", "A", "Bb", "C", "D", "Eb", "F#"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -322,3 +408,113 @@ func TestKeySignature_BuildScale(t *testing.T) {
 		})
 	}
 }
+
+func TestKey_RelativeMajor(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       *musicxml.KeySignature
+		want      string
+		wantScale []string
+	}{
+		{name: "the major is kept", key: &musicxml.KeySignature{Fifth: 2}, want: "D", wantScale: []string{"D", "E", "F#", "G", "A", "B", "C#"}},
+		{name: "A minor", key: &musicxml.KeySignature{Fifth: 0, Mode: "minor"}, want: "C", wantScale: []string{"C", "D", "E", "F", "G", "A", "B"}},
+		{name: "D dorian", key: &musicxml.KeySignature{Fifth: 0, Mode: "dorian"}, want: "C", wantScale: []string{"C", "D", "E", "F", "G", "A", "B"}},
+		{name: "G minor with the leading tone", key: &musicxml.KeySignature{Mode: "minor", Steps: []string{"B", "E", "F"}, Alters: []float64{-1, -1, 1}}, want: "Bb", wantScale: []string{"Bb", "C", "D", "Eb", "F#", "G", "A"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewKey(tt.key).RelativeMajor()
+			assert.Equal(t, KeySignatureModeMajor, got.Mode.Mode)
+			assert.Equal(t, tt.want, got.Key)
+			assert.Equal(t, tt.wantScale, got.BuildScale())
+		})
+	}
+}
+
+func TestNewKeySignature_measures(t *testing.T) {
+	measures := []musicxml.Measure{
+		{Number: 1, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: 2}}},
+		{Number: 2},
+		{Number: 3, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: 2}}},
+		{Number: 4, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: -1}}},
+	}
+
+	got := NewKeySignature(context.Background(), measures)
+	assert.True(t, got.IsMixed)
+	if assert.Len(t, got.Signatures, 2, "the restated key is skipped") {
+		assert.Equal(t, 1, got.Signatures[0].Measure)
+		assert.Equal(t, 4, got.Signatures[1].Measure)
+	}
+}
+
+func TestKeySignature_GetKeyOnMeasure(t *testing.T) {
+	ks := NewKeySignature(context.Background(), []musicxml.Measure{
+		{Number: 1, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: 2}}},
+		{Number: 5, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: -1}}},
+		{Number: 9, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: 0}}},
+	})
+
+	tests := []struct {
+		name        string
+		measure     int
+		wantKey     string
+		wantPrev    string
+		wantChanged bool
+	}{
+		{name: "the first key", measure: 1, wantKey: "D"},
+		{name: "before the first change", measure: 4, wantKey: "D"},
+		{name: "the first change", measure: 5, wantKey: "F", wantPrev: "D", wantChanged: true},
+		{name: "after the first change", measure: 6, wantKey: "F", wantPrev: "D"},
+		{name: "the second change", measure: 9, wantKey: "C", wantPrev: "F", wantChanged: true},
+		{name: "after the last change", measure: 12, wantKey: "C", wantPrev: "F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ks.GetKeyOnMeasure(context.Background(), tt.measure)
+			assert.Equal(t, tt.wantKey, got.Key)
+			assert.Equal(t, tt.wantChanged, got.IsChanged())
+			if tt.wantPrev == "" {
+				assert.Nil(t, got.Prev)
+				return
+			}
+			if assert.NotNil(t, got.Prev) {
+				assert.Equal(t, tt.wantPrev, got.Prev.Key)
+			}
+		})
+	}
+
+	t.Run("no key is C major", func(t *testing.T) {
+		empty := KeySignature{}
+		got := empty.GetKeyOnMeasure(context.Background(), 1)
+		assert.Equal(t, "do = c", got.String())
+	})
+}
+
+func TestKeySignature_GetKeyAt(t *testing.T) {
+	ks := NewKeySignature(context.Background(), []musicxml.Measure{
+		{Number: 1, Attribute: &musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: 2}}},
+		{Number: 3, AttributeChanges: []musicxml.AttributeChange{
+			{Element: 4, Attribute: musicxml.Attribute{Key: &musicxml.KeySignature{Fifth: -1}}},
+		}},
+	})
+
+	tests := []struct {
+		name    string
+		measure int
+		element int
+		wantKey string
+	}{
+		{name: "before the measure of the change", measure: 2, element: 6, wantKey: "D"},
+		{name: "before the change", measure: 3, element: 2, wantKey: "D"},
+		{name: "after the change", measure: 3, element: 5, wantKey: "F"},
+		{name: "the next measure", measure: 4, element: 0, wantKey: "F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ks.GetKeyAt(context.Background(), tt.measure, tt.element)
+			assert.Equal(t, tt.wantKey, got.Key)
+		})
+	}
+
+	assert.Equal(t, "D", ks.GetKeyOnMeasure(context.Background(), 3).Key)
+}
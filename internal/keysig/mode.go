@@ -2,6 +2,7 @@ package keysig
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Mode struct {
@@ -10,14 +11,20 @@ type Mode struct {
 	Mode         KeySignatureMode
 }
 
+// NewMode returns the mode of the key, the ionian is the major and the aeolian is the minor
 func NewMode(mode string) Mode {
 	modeMapper := map[string]KeySignatureMode{
 		"major":      KeySignatureModeMajor,
+		"ionian":     KeySignatureModeMajor,
 		"minor":      KeySignatureModeMinor,
+		"aeolian":    KeySignatureModeMinor,
 		"dorian":     KeySignatureModeDorian,
 		"phrygian":   KeySignatureModePhrygian,
+		"lydian":     KeySignatureModeLydian,
 		"mixolydian": KeySignatureModeMixolydian,
+		"locrian":    KeySignatureModeLocrian,
 	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
 	currentMode := modeMapper[mode]
 
 	if mode == "" {
@@ -60,3 +67,13 @@ func (m *Mode) GetScaleSteps() []float64 {
 func (m *Mode) String() string {
 	return m.Mode.String()
 }
+
+// GetFifth returns the fifths of the key signature with the root on the mode, e.g. Bb on the major is -2
+func (m Mode) GetFifth(root string) (int, bool) {
+	for fifth, r := range modeRoot[m.Mode.String()] {
+		if r == root {
+			return fifth, true
+		}
+	}
+	return 0, false
+}
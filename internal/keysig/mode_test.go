@@ -32,6 +32,38 @@ func TestMode_GetHumanized(t *testing.T) {
 			},
 			want: "do = f",
 		},
+		{
+			name: "A aeolian",
+			m:    NewMode("aeolian"),
+			args: args{
+				fifth: 0,
+			},
+			want: "la = a",
+		},
+		{
+			name: "E dorian",
+			m:    NewMode("dorian"),
+			args: args{
+				fifth: 2,
+			},
+			want: "re = e",
+		},
+		{
+			name: "Bb lydian",
+			m:    NewMode("lydian"),
+			args: args{
+				fifth: -1,
+			},
+			want: "fa = bes",
+		},
+		{
+			name: "B locrian",
+			m:    NewMode("locrian"),
+			args: args{
+				fifth: 0,
+			},
+			want: "si = b",
+		},
 		{
 			name: "D major",
 			m: Mode{
@@ -63,6 +95,16 @@ func TestMode_GetScaleSteps(t *testing.T) {
 			m:    NewMode("major"),
 			want: []float64{1, 1, 0.5, 1, 1, 1, 0.5},
 		},
+		{
+			name: "Lydian",
+			m:    NewMode("lydian"),
+			want: []float64{1, 1, 1, 0.5, 1, 1, 0.5},
+		},
+		{
+			name: "Locrian",
+			m:    NewMode("locrian"),
+			want: []float64{0.5, 1, 1, 0.5, 1, 1, 1},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -72,3 +114,25 @@ func TestMode_GetScaleSteps(t *testing.T) {
 		})
 	}
 }
+
+func TestMode_GetFifth(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      Mode
+		root   string
+		want   int
+		wantOk bool
+	}{
+		{name: "Bb major", m: NewMode("major"), root: "Bb", want: -2, wantOk: true},
+		{name: "F# minor", m: NewMode("minor"), root: "F#", want: 3, wantOk: true},
+		{name: "C major", m: NewMode(""), root: "C", want: 0, wantOk: true},
+		{name: "not on the circle", m: NewMode("major"), root: "E#", want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.m.GetFifth(tt.root)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
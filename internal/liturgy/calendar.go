@@ -0,0 +1,244 @@
+package liturgy
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Season string
+
+const (
+	SeasonAdvent    Season = "advent"
+	SeasonChristmas Season = "christmas"
+	SeasonEpiphany  Season = "epiphany"
+	SeasonLent      Season = "lent"
+	SeasonEaster    Season = "easter"
+	// the sundays after pentecost until the advent
+	SeasonOrdinary Season = "ordinary"
+)
+
+// the theme of the feast, the feast without the theme takes the theme of the season
+var feastThemes = map[string]string{
+	"christmas-eve":       "christmas",
+	"christmas":           "christmas",
+	"new-year-eve":        "new-year",
+	"new-year":            "new-year",
+	"epiphany":            "epiphany",
+	"baptism-of-the-lord": "baptism",
+	"transfiguration":     "transfiguration",
+	"palm-sunday":         "palm-sunday",
+	"maundy-thursday":     "communion",
+	"good-friday":         "good-friday",
+	"ascension":           "ascension",
+	"pentecost":           "pentecost",
+	"trinity":             "trinity",
+	"reformation":         "reformation",
+	"christ-the-king":     "christ-the-king",
+}
+
+// the first and the last numbered sundays of the season, e.g. advent-1 to advent-4.
+// the first sunday after the epiphany and the pentecost are named after the feast
+var numberedSundays = map[string][2]int{
+	"advent":          {1, 4},
+	"after-christmas": {1, 2},
+	"after-epiphany":  {2, 9},
+	"lent":            {1, 5},
+	"easter":          {2, 7},
+	"after-pentecost": {2, 28},
+}
+
+// Day is the date on the church calendar
+type Day struct {
+	Date   time.Time
+	Season Season
+	// the sunday or the feast, e.g. advent-1 or good-friday. empty on the weekday without feast
+	Name string
+	// the season and the theme of the feast
+	Themes []string
+}
+
+// Easter returns the easter sunday of the year on the gregorian calendar (the anonymous gregorian computus)
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return date(year, time.Month(month), day)
+}
+
+// FirstAdvent returns the first sunday of the advent, the fourth sunday before the christmas
+func FirstAdvent(year int) time.Time {
+	christmas := date(year, time.December, 25)
+	back := int(christmas.Weekday())
+	if back == 0 {
+		back = 7
+	}
+	return christmas.AddDate(0, 0, -back-21)
+}
+
+// DayOf returns the day of the church calendar, the time of the day is ignored
+func DayOf(t time.Time) Day {
+	today := date(t.Year(), t.Month(), t.Day())
+	season, name := seasonOf(today)
+
+	result := Day{
+		Date:   today,
+		Season: season,
+		Name:   name,
+		Themes: []string{string(season)},
+	}
+	if theme, ok := feastThemes[name]; ok && theme != string(season) {
+		result.Themes = append(result.Themes, theme)
+	}
+	return result
+}
+
+// IsDayName tells whether the name is a sunday or a feast of the calendar
+func IsDayName(name string) bool {
+	if _, ok := feastThemes[name]; ok {
+		return true
+	}
+	switch name {
+	case "ash-wednesday", "holy-saturday", "easter":
+		return true
+	}
+
+	for prefix, limit := range numberedSundays {
+		raw := strings.TrimPrefix(name, prefix+"-")
+		if raw == name {
+			continue
+		}
+		number, err := strconv.Atoi(raw)
+		if err == nil && number >= limit[0] && number <= limit[1] && numbered(prefix, number) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func seasonOf(today time.Time) (Season, string) {
+	year := today.Year()
+	sunday := today.Weekday() == time.Sunday
+	christmas := date(year, time.December, 25)
+	epiphany := date(year, time.January, 6)
+	easter := Easter(year)
+	ashWednesday := easter.AddDate(0, 0, -46)
+	pentecost := easter.AddDate(0, 0, 49)
+	advent := FirstAdvent(year)
+
+	switch {
+	case today.Before(epiphany):
+		// the christmas of the last year
+		name := ""
+		if today.Day() == 1 {
+			name = "new-year"
+		} else if sunday {
+			name = numbered("after-christmas", days(date(year-1, time.December, 25), today)/7+1)
+		}
+		return SeasonChristmas, name
+
+	case today.Before(ashWednesday):
+		name := ""
+		if today.Equal(epiphany) {
+			name = "epiphany"
+		} else if today.Equal(ashWednesday.AddDate(0, 0, -3)) {
+			name = "transfiguration"
+		} else if sunday {
+			number := (days(epiphany, today)-1)/7 + 1
+			name = numbered("after-epiphany", number)
+			if number == 1 {
+				name = "baptism-of-the-lord"
+			}
+		}
+		return SeasonEpiphany, name
+
+	case today.Before(easter):
+		name := ""
+		switch days(today, easter) {
+		case 46:
+			name = "ash-wednesday"
+		case 7:
+			name = "palm-sunday"
+		case 3:
+			name = "maundy-thursday"
+		case 2:
+			name = "good-friday"
+		case 1:
+			name = "holy-saturday"
+		default:
+			if sunday {
+				name = numbered("lent", days(ashWednesday, today)/7+1)
+			}
+		}
+		return SeasonLent, name
+
+	case !today.After(pentecost):
+		name := ""
+		switch days(easter, today) {
+		case 0:
+			name = "easter"
+		case 39:
+			name = "ascension"
+		case 49:
+			name = "pentecost"
+		default:
+			if sunday {
+				name = numbered("easter", days(easter, today)/7+1)
+			}
+		}
+		return SeasonEaster, name
+
+	case today.Before(advent):
+		name := ""
+		if today.Month() == time.October && today.Day() == 31 {
+			name = "reformation"
+		} else if today.Equal(advent.AddDate(0, 0, -7)) {
+			name = "christ-the-king"
+		} else if today.Equal(pentecost.AddDate(0, 0, 7)) {
+			name = "trinity"
+		} else if sunday {
+			name = numbered("after-pentecost", days(pentecost, today)/7)
+		}
+		return SeasonOrdinary, name
+
+	case today.Before(christmas):
+		name := ""
+		if today.Day() == 24 {
+			name = "christmas-eve"
+		} else if sunday {
+			name = numbered("advent", days(advent, today)/7+1)
+		}
+		return SeasonAdvent, name
+	}
+
+	name := ""
+	if today.Equal(christmas) {
+		name = "christmas"
+	} else if today.Day() == 31 {
+		name = "new-year-eve"
+	} else if sunday {
+		name = numbered("after-christmas", days(christmas, today)/7+1)
+	}
+	return SeasonChristmas, name
+}
+
+func numbered(prefix string, number int) string {
+	return prefix + "-" + strconv.Itoa(number)
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,121 @@
+package liturgy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want string
+	}{
+		{year: 2008, want: "2008-03-23"},
+		{year: 2019, want: "2019-04-21"},
+		{year: 2024, want: "2024-03-31"},
+		{year: 2025, want: "2025-04-20"},
+		{year: 2026, want: "2026-04-05"},
+		{year: 2038, want: "2038-04-25"},
+		{year: 2285, want: "2285-03-22"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, Easter(tt.year).Format("2006-01-02"))
+		})
+	}
+}
+
+func TestFirstAdvent(t *testing.T) {
+	assert.Equal(t, "2022-11-27", FirstAdvent(2022).Format("2006-01-02")) // the christmas on sunday
+	assert.Equal(t, "2024-12-01", FirstAdvent(2024).Format("2006-01-02"))
+	assert.Equal(t, "2026-11-29", FirstAdvent(2026).Format("2006-01-02"))
+}
+
+func TestDayOf(t *testing.T) {
+	tests := []struct {
+		date       string
+		wantSeason Season
+		wantName   string
+		wantThemes []string
+	}{
+		{date: "2026-01-01", wantSeason: SeasonChristmas, wantName: "new-year", wantThemes: []string{"christmas", "new-year"}},
+		{date: "2026-01-04", wantSeason: SeasonChristmas, wantName: "after-christmas-2", wantThemes: []string{"christmas"}},
+		{date: "2026-01-06", wantSeason: SeasonEpiphany, wantName: "epiphany", wantThemes: []string{"epiphany"}},
+		{date: "2026-01-11", wantSeason: SeasonEpiphany, wantName: "baptism-of-the-lord", wantThemes: []string{"epiphany", "baptism"}},
+		{date: "2026-01-18", wantSeason: SeasonEpiphany, wantName: "after-epiphany-2", wantThemes: []string{"epiphany"}},
+		{date: "2026-02-15", wantSeason: SeasonEpiphany, wantName: "transfiguration", wantThemes: []string{"epiphany", "transfiguration"}},
+		{date: "2026-02-18", wantSeason: SeasonLent, wantName: "ash-wednesday", wantThemes: []string{"lent"}},
+		{date: "2026-02-22", wantSeason: SeasonLent, wantName: "lent-1", wantThemes: []string{"lent"}},
+		{date: "2026-03-22", wantSeason: SeasonLent, wantName: "lent-5", wantThemes: []string{"lent"}},
+		{date: "2026-03-29", wantSeason: SeasonLent, wantName: "palm-sunday", wantThemes: []string{"lent", "palm-sunday"}},
+		{date: "2026-04-02", wantSeason: SeasonLent, wantName: "maundy-thursday", wantThemes: []string{"lent", "communion"}},
+		{date: "2026-04-03", wantSeason: SeasonLent, wantName: "good-friday", wantThemes: []string{"lent", "good-friday"}},
+		{date: "2026-04-05", wantSeason: SeasonEaster, wantName: "easter", wantThemes: []string{"easter"}},
+		{date: "2026-04-12", wantSeason: SeasonEaster, wantName: "easter-2", wantThemes: []string{"easter"}},
+		{date: "2026-05-14", wantSeason: SeasonEaster, wantName: "ascension", wantThemes: []string{"easter", "ascension"}},
+		{date: "2026-05-24", wantSeason: SeasonEaster, wantName: "pentecost", wantThemes: []string{"easter", "pentecost"}},
+		{date: "2026-05-31", wantSeason: SeasonOrdinary, wantName: "trinity", wantThemes: []string{"ordinary", "trinity"}},
+		{date: "2026-06-07", wantSeason: SeasonOrdinary, wantName: "after-pentecost-2", wantThemes: []string{"ordinary"}},
+		{date: "2026-07-15", wantSeason: SeasonOrdinary, wantName: "", wantThemes: []string{"ordinary"}},
+		{date: "2026-10-31", wantSeason: SeasonOrdinary, wantName: "reformation", wantThemes: []string{"ordinary", "reformation"}},
+		{date: "2026-11-22", wantSeason: SeasonOrdinary, wantName: "christ-the-king", wantThemes: []string{"ordinary", "christ-the-king"}},
+		{date: "2026-11-29", wantSeason: SeasonAdvent, wantName: "advent-1", wantThemes: []string{"advent"}},
+		{date: "2026-12-20", wantSeason: SeasonAdvent, wantName: "advent-4", wantThemes: []string{"advent"}},
+		{date: "2026-12-24", wantSeason: SeasonAdvent, wantName: "christmas-eve", wantThemes: []string{"advent", "christmas"}},
+		{date: "2026-12-25", wantSeason: SeasonChristmas, wantName: "christmas", wantThemes: []string{"christmas"}},
+		{date: "2026-12-27", wantSeason: SeasonChristmas, wantName: "after-christmas-1", wantThemes: []string{"christmas"}},
+		{date: "2026-12-31", wantSeason: SeasonChristmas, wantName: "new-year-eve", wantThemes: []string{"christmas", "new-year"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", tt.date)
+			assert.NoError(t, err)
+
+			got := DayOf(date.Add(19 * time.Hour))
+			assert.Equal(t, date, got.Date)
+			assert.Equal(t, tt.wantSeason, got.Season)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, tt.wantThemes, got.Themes)
+		})
+	}
+}
+
+func TestDayOf_everyDayIsNamed(t *testing.T) {
+	// every name given by the calendar is accepted as the tag
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for date := start; date.Year() < 2100; date = date.AddDate(0, 0, 1) {
+		day := DayOf(date)
+		if date.Weekday() == time.Sunday && day.Name == "" {
+			t.Fatalf("sunday %s has no name", date.Format("2006-01-02"))
+		}
+		if day.Name != "" && !IsDayName(day.Name) {
+			t.Fatalf("%s of %s is not a day name", day.Name, date.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestIsDayName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "advent-1", want: true},
+		{name: "advent-5", want: false},
+		{name: "advent-01", want: false},
+		{name: "after-pentecost-28", want: true},
+		{name: "after-pentecost-1", want: false},
+		{name: "easter", want: true},
+		{name: "easter-7", want: true},
+		{name: "good-friday", want: true},
+		{name: "ash-wednesday", want: true},
+		{name: "holiday", want: false},
+		{name: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsDayName(tt.name))
+		})
+	}
+}
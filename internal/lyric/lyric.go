@@ -2,6 +2,7 @@ package lyric
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"regexp"
 	"strings"
@@ -205,6 +206,15 @@ func (li *lyricInteractor) RenderLyrics(ctx context.Context, y int, canv canvas.
 			if sty != "" {
 				styles = append(styles, sty)
 			}
+			if n.NoteID != "" {
+				// the syllable is highlighted along with its note on the playback.
+				// the verse is only set on the verse inserted to the score, otherwise it is the line of the lyric
+				verseNo := l.Verse
+				if verseNo == 0 {
+					verseNo = i + 1
+				}
+				styles = append(styles, fmt.Sprintf(`data-note="%s"`, n.NoteID), fmt.Sprintf(`data-verse="%d"`, verseNo))
+			}
 			canv.Text(xPos, int(yPos), lyricVal, styles...)
 			elisionOpacity := "stroke-opacity:0.6"
 			if sty == "" {
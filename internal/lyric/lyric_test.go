@@ -225,6 +225,46 @@ func Test_lyricInteractor_RenderLyrics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with the note id",
+			canv: func(c *gomock.Controller) *canvas.MockCanvas {
+				canv := canvas.NewMockCanvas(c)
+				canv.EXPECT().Group("class='lyric'", "style='font-family:Caladea;font-size:16px'")
+				canv.EXPECT().Text(50, 125, "Ha", `data-note="note-1-P1-1-2"`, `data-verse="1"`)
+				canv.EXPECT().Text(60, 125, "le", `data-note="note-1-P1-1-3"`, `data-verse="3"`)
+				canv.EXPECT().Gend()
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX: 50,
+					PositionY: 100,
+					NoteID:    "note-1-P1-1-2",
+					Lyric: []entity.Lyric{
+						{
+							Text: []entity.Text{
+								{Value: "Ha"},
+							},
+							Syllabic: musicxml.LyricSyllabicTypeBegin,
+						},
+					},
+				},
+				{
+					PositionX: 60,
+					PositionY: 100,
+					NoteID:    "note-1-P1-1-3",
+					Lyric: []entity.Lyric{
+						{
+							Text: []entity.Text{
+								{Value: "le"},
+							},
+							Syllabic: musicxml.LyricSyllabicTypeEnd,
+							Verse:    3,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "no prefix - and notes - with elsion",
 			canv: func(c *gomock.Controller) *canvas.MockCanvas {
@@ -0,0 +1,158 @@
+package melody
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/moveabledo"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/rhythm"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
+)
+
+// the minimum notes of the query, fewer notes would match almost every hymn
+const MIN_QUERY_NOTES = 3
+
+// the notes of the incipit, the opening of the melody
+const INCIPIT_NOTES = 12
+
+// the semitones of the degrees on the major scale, used to read the query
+var majorSteps = []int{0, 2, 4, 5, 7, 9, 11}
+
+// Note is the note of the melody as it is written on the numbered notation, the octave is ignored
+type Note struct {
+	// the digit, followed by the slash when it is altered. e.g. 5 or 4/
+	Numbered string
+	// the pitch class, 0 - 11
+	Pitch int
+}
+
+// Extract returns the melody of the main part, the rests and the tied notes are skipped.
+// the digits are counted from the do of the relative major, e.g. the A minor scale is 6 7 1 2 3 4 5,
+// so the query is read on the major scale on every mode
+func Extract(ctx context.Context, music musicxml.MusicXML) []Note {
+	measures := music.MainPart().Measures
+	keySignature := keysig.NewKeySignature(ctx, measures)
+
+	result := []Note{}
+	for _, measure := range measures {
+		measure.Build()
+
+		for _, note := range measure.Notes {
+			if note.Rest != nil || isTiedContinuation(note) {
+				continue
+			}
+
+			key := keySignature.GetKeyAt(ctx, measure.Number, note.Element).RelativeMajor()
+
+			numbered, _, strikethrough := moveabledo.GetNumberedNotation(key, note)
+			if numbered == 0 {
+				continue
+			}
+
+			result = append(result, Note{
+				Numbered: digit(numbered, strikethrough),
+				Pitch:    utils.PitchSemitone(key.GetPitchWithAccidental(note)),
+			})
+		}
+	}
+
+	return result
+}
+
+// the held note is the same note, it is not sung again
+func isTiedContinuation(note musicxml.Note) bool {
+	return rhythm.HasTies(note) && note.Notations.Tied.Type == musicxml.NoteSlurTypeStop
+}
+
+// ParseQuery reads the melody written as the plain text of the numbered notation, e.g. `1 1 5 5 6 6 5`.
+// the slash raises the note (4/), the octave marks (1' 5,) are ignored, so are the rests, the barlines and the dots.
+// the query is read as the major scale, the tune on the minor key is written from la (6), e.g. `6 7 1 2 3`
+func ParseQuery(query string) []Note {
+	result := []Note{}
+	for _, token := range strings.Fields(query) {
+		token = strings.TrimRight(token, "',")
+		if len(token) == 0 || token[0] < '1' || token[0] > '7' {
+			continue
+		}
+
+		degree := int(token[0] - '0')
+		raised := strings.HasPrefix(token[1:], "/")
+		if len(strings.TrimPrefix(token[1:], "/")) > 0 {
+			// not a note, e.g. the verse number 1.
+			continue
+		}
+
+		pitch := majorSteps[degree-1]
+		if raised {
+			pitch = (pitch + 1) % 12
+		}
+		result = append(result, Note{
+			Numbered: digit(degree, raised),
+			Pitch:    pitch,
+		})
+	}
+
+	return result
+}
+
+// Degrees encodes the digits of the melody for the matching.
+// the repeated notes are merged when the rhythm is ignored, the same tune with the different syllables still matches
+func Degrees(notes []Note, anyRhythm bool) string {
+	tokens := []string{}
+	for i, note := range notes {
+		if anyRhythm && i > 0 && notes[i-1].Numbered == note.Numbered {
+			continue
+		}
+		tokens = append(tokens, note.Numbered)
+	}
+	return encode(tokens)
+}
+
+// Intervals encodes the steps between the notes in semitones for the matching regardless the key.
+// the step is taken to the nearest, e.g. 1 to 5 is 5 semitones down
+func Intervals(notes []Note, anyRhythm bool) string {
+	tokens := []string{}
+	for i := 1; i < len(notes); i++ {
+		step := ((notes[i].Pitch-notes[i-1].Pitch)%12 + 12) % 12
+		if step > 6 {
+			step -= 12
+		}
+		if anyRhythm && step == 0 {
+			continue
+		}
+		tokens = append(tokens, strconv.Itoa(step))
+	}
+	return encode(tokens)
+}
+
+// Incipit returns the digits of the opening of the melody
+func Incipit(notes []Note) string {
+	if len(notes) > INCIPIT_NOTES {
+		notes = notes[:INCIPIT_NOTES]
+	}
+
+	result := make([]string, 0, len(notes))
+	for _, note := range notes {
+		result = append(result, note.Numbered)
+	}
+	return strings.Join(result, " ")
+}
+
+// the tokens are wrapped with the spaces, a part of the melody is matched with the whole tokens only
+func encode(tokens []string) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return " " + strings.Join(tokens, " ") + " "
+}
+
+func digit(numbered int, strikethrough bool) string {
+	result := strconv.Itoa(numbered)
+	if strikethrough {
+		result += "/"
+	}
+	return result
+}
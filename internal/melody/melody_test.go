@@ -0,0 +1,132 @@
+package melody
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+const melodyMusicXML = `<?xml version="1.0" encoding="UTF-8"?>
+<score-partwise version="3.1">
+  <part-list><score-part id="P1"><part-name>Voice</part-name></score-part></part-list>
+  <part id="P1">
+    <measure number="1">
+      <attributes><divisions>1</divisions><key><fifths>1</fifths></key><time><beats>4</beats><beat-type>4</beat-type></time></attributes>
+      <note><pitch><step>G</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>D</step><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>C</step><alter>1</alter><octave>5</octave></pitch><duration>1</duration><type>quarter</type><accidental>sharp</accidental></note>
+      <note><rest/><duration>1</duration><type>quarter</type></note>
+    </measure>
+    <measure number="2">
+      <note><pitch><step>D</step><octave>5</octave></pitch><duration>2</duration><type>half</type>
+        <notations><tied type="start"/></notations></note>
+      <note><pitch><step>D</step><octave>5</octave></pitch><duration>2</duration><type>half</type>
+        <notations><tied type="stop"/></notations></note>
+    </measure>
+    <measure number="3">
+      <attributes><key><fifths>0</fifths></key></attributes>
+      <note><pitch><step>C</step><octave>5</octave></pitch><duration>4</duration><type>whole</type></note>
+    </measure>
+  </part>
+</score-partwise>`
+
+func TestExtract(t *testing.T) {
+	music, err := musicxml.Parse([]byte(melodyMusicXML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Note{
+		{Numbered: "1", Pitch: 7},
+		{Numbered: "5", Pitch: 2},
+		{Numbered: "4/", Pitch: 1},
+		{Numbered: "5", Pitch: 2},
+		// the key changes to C
+		{Numbered: "1", Pitch: 0},
+	}, Extract(context.Background(), music))
+
+	assert.Equal(t, []Note{}, Extract(context.Background(), musicxml.MusicXML{}))
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []Note
+	}{
+		{
+			name:  "digits",
+			query: "1 1 5 5",
+			want:  []Note{{"1", 0}, {"1", 0}, {"5", 7}, {"5", 7}},
+		},
+		{
+			name:  "copied from the plain text",
+			query: "|: 1    . 2      3   4/  5   6  1'  , |   5, . 0  .  :|",
+			want:  []Note{{"1", 0}, {"2", 2}, {"3", 4}, {"4/", 6}, {"5", 7}, {"6", 9}, {"1", 0}, {"5", 7}},
+		},
+		{
+			name:  "not the notes",
+			query: "1. 8 9 do 12",
+			want:  []Note{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseQuery(tt.query))
+		})
+	}
+}
+
+func TestDegrees(t *testing.T) {
+	notes := ParseQuery("1 1 5 5 6 6 5 4/")
+
+	assert.Equal(t, " 1 1 5 5 6 6 5 4/ ", Degrees(notes, false))
+	assert.Equal(t, " 1 5 6 5 4/ ", Degrees(notes, true))
+	assert.Equal(t, "", Degrees(nil, false))
+}
+
+func TestIntervals(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		anyRhythm bool
+		want      string
+	}{
+		{
+			name:  "the nearest step",
+			query: "1 1 5 5 6 6 5",
+			want:  " 0 -5 0 2 0 -2 ",
+		},
+		{
+			name:      "the repeated notes are merged",
+			query:     "1 1 5 5 6 6 5",
+			anyRhythm: true,
+			want:      " -5 2 -2 ",
+		},
+		{
+			name:  "the same tune on the other note",
+			query: "5 5 2 2 3 3 2",
+			want:  " 0 -5 0 2 0 -2 ",
+		},
+		{
+			name:  "the tritone is up",
+			query: "4 7 4",
+			want:  " 6 6 ",
+		},
+		{
+			name:  "single note",
+			query: "1",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Intervals(ParseQuery(tt.query), tt.anyRhythm))
+		})
+	}
+}
+
+func TestIncipit(t *testing.T) {
+	assert.Equal(t, "1 1 5 5 6 6 5", Incipit(ParseQuery("1 1 5 5 6 6 5")))
+	assert.Equal(t, "1 2 3 4 5 6 7 1 2 3 4 5", Incipit(ParseQuery("1 2 3 4 5 6 7 1 2 3 4 5 6 7")))
+}
@@ -0,0 +1,167 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+)
+
+const (
+	// the general midi program of the parts
+	PROGRAM_CHURCH_ORGAN = 19
+
+	DEFAULT_VELOCITY = 80
+
+	// the channel of the percussion, it is skipped by the parts
+	PERCUSSION_CHANNEL = 9
+)
+
+const (
+	metaTrackName     = 0x03
+	metaEndOfTrack    = 0x2F
+	metaTempo         = 0x51
+	metaTimeSignature = 0x58
+
+	statusNoteOff       = 0x80
+	statusNoteOn        = 0x90
+	statusProgramChange = 0xC0
+)
+
+// event is the midi event on the absolute tick
+type event struct {
+	tick int
+	// the note off goes before the note on of the same tick
+	order int
+	data  []byte
+}
+
+// Write writes the score as the standard midi file type 1.
+// the first track holds the tempo and the time signature, every part is on its own track and channel
+func Write(w io.Writer, score playback.Score) error {
+	tracks := [][]event{conductorTrack(score)}
+	for i, track := range score.Tracks {
+		channel := i % 15
+		if channel >= PERCUSSION_CHANNEL {
+			channel++
+		}
+		tracks = append(tracks, partTrack(track, channel))
+	}
+
+	header := make([]byte, 14)
+	copy(header, "MThd")
+	binary.BigEndian.PutUint32(header[4:], 6)
+	binary.BigEndian.PutUint16(header[8:], 1)
+	binary.BigEndian.PutUint16(header[10:], uint16(len(tracks)))
+	binary.BigEndian.PutUint16(header[12:], playback.TICKS_PER_QUARTER)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, track := range tracks {
+		if _, err := w.Write(encodeTrack(track, score.Length)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func conductorTrack(score playback.Score) []event {
+	result := []event{}
+	for _, tempo := range score.Tempos {
+		if tempo.BPM <= 0 {
+			continue
+		}
+		microseconds := uint32(math.Round(60000000 / tempo.BPM))
+		result = append(result, event{
+			tick: tempo.Start,
+			data: []byte{0xFF, metaTempo, 3, byte(microseconds >> 16), byte(microseconds >> 8), byte(microseconds)},
+		})
+	}
+
+	for _, time := range score.Times {
+		if time.Beat <= 0 || time.BeatType <= 0 {
+			continue
+		}
+		denominator := byte(math.Round(math.Log2(float64(time.BeatType))))
+		// the metronome clicks on every quarter, there are eight 32nd notes on the quarter
+		result = append(result, event{
+			tick: time.Start,
+			data: []byte{0xFF, metaTimeSignature, 4, byte(time.Beat), denominator, 24, 8},
+		})
+	}
+
+	return result
+}
+
+func partTrack(track playback.Track, channel int) []event {
+	name := append([]byte{0xFF, metaTrackName}, variableLength(len(track.Name))...)
+	result := []event{
+		{data: append(name, track.Name...)},
+		{data: []byte{statusProgramChange | byte(channel), PROGRAM_CHURCH_ORGAN}},
+	}
+
+	for _, n := range track.Notes {
+		if n.Key < 0 || n.Key > 127 || n.Duration <= 0 {
+			continue
+		}
+		result = append(result,
+			event{
+				tick:  n.Start,
+				order: 1,
+				data:  []byte{statusNoteOn | byte(channel), byte(n.Key), DEFAULT_VELOCITY},
+			},
+			event{
+				tick: n.Start + n.Duration,
+				data: []byte{statusNoteOff | byte(channel), byte(n.Key), 0},
+			},
+		)
+	}
+	return result
+}
+
+// encodeTrack writes the chunk of the track, the events are sorted by their tick
+func encodeTrack(events []event, length int) []byte {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].tick != events[j].tick {
+			return events[i].tick < events[j].tick
+		}
+		return events[i].order < events[j].order
+	})
+
+	body := &bytes.Buffer{}
+	tick := 0
+	for _, e := range events {
+		body.Write(variableLength(e.tick - tick))
+		body.Write(e.data)
+		tick = e.tick
+	}
+
+	end := 0
+	if length > tick {
+		end = length - tick
+	}
+	body.Write(variableLength(end))
+	body.Write([]byte{0xFF, metaEndOfTrack, 0})
+
+	result := make([]byte, 8, 8+body.Len())
+	copy(result, "MTrk")
+	binary.BigEndian.PutUint32(result[4:], uint32(body.Len()))
+	return append(result, body.Bytes()...)
+}
+
+// variableLength encodes the delta time, 7 bits per byte and the highest bit is set on all except the last byte
+func variableLength(value int) []byte {
+	if value < 0 {
+		value = 0
+	}
+
+	result := []byte{byte(value & 0x7F)}
+	for value >>= 7; value > 0; value >>= 7 {
+		result = append([]byte{byte(value&0x7F) | 0x80}, result...)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	score := playback.Score{
+		Tracks: []playback.Track{
+			{
+				Name: "S",
+				Notes: []playback.Note{
+					{Key: 60, Start: 0, Duration: 480},
+					{Key: 62, Start: 480, Duration: 480},
+				},
+			},
+		},
+		Tempos: []playback.Tempo{{Start: 0, BPM: 120}},
+		Times:  []playback.TimeChange{{Start: 0, Beat: 2, BeatType: 4}},
+		Length: 960,
+	}
+
+	buf := &bytes.Buffer{}
+	err := Write(buf, score)
+	assert.NoError(t, err)
+
+	want := []byte{
+		'M', 'T', 'h', 'd', 0, 0, 0, 6, 0, 1, 0, 2, 0x01, 0xE0,
+
+		'M', 'T', 'r', 'k', 0, 0, 0, 20,
+		0x00, 0xFF, 0x51, 3, 0x07, 0xA1, 0x20,
+		0x00, 0xFF, 0x58, 4, 2, 2, 24, 8,
+		0x87, 0x40, 0xFF, 0x2F, 0,
+
+		'M', 'T', 'r', 'k', 0, 0, 0, 30,
+		0x00, 0xFF, 0x03, 1, 'S',
+		0x00, 0xC0, PROGRAM_CHURCH_ORGAN,
+		0x00, 0x90, 60, DEFAULT_VELOCITY,
+		0x83, 0x60, 0x80, 60, 0,
+		0x00, 0x90, 62, DEFAULT_VELOCITY,
+		0x83, 0x60, 0x80, 62, 0,
+		0x00, 0xFF, 0x2F, 0,
+	}
+	assert.Equal(t, want, buf.Bytes())
+}
+
+func Test_variableLength(t *testing.T) {
+	tests := []struct {
+		value int
+		want  []byte
+	}{
+		{value: 0, want: []byte{0x00}},
+		{value: 0x7F, want: []byte{0x7F}},
+		{value: 0x80, want: []byte{0x81, 0x00}},
+		{value: 960, want: []byte{0x87, 0x40}},
+		{value: 0x0FFFFFFF, want: []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+		{value: -1, want: []byte{0x00}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, variableLength(tt.value))
+	}
+}
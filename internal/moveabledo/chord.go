@@ -0,0 +1,42 @@
+package moveabledo
+
+import (
+	"fmt"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
+)
+
+// convertStepToDegree returns the numbered degree of the chord root / bass relative to the do,
+// prefixed by the accidental when the pitch is outside the scale (e.g. #4, b7)
+func convertStepToDegree(ks keysig.Key, step string, alter float64) string {
+	pitch := step + musicxml.AlterSymbol(alter)
+	degree, altered := ConvertPitchToNumbered(ks, pitch)
+	if degree == 0 {
+		return pitch
+	}
+
+	if !altered {
+		return fmt.Sprintf("%d", degree)
+	}
+
+	prefix := "b"
+	if utils.ComparePitch(pitch, ks.BuildScale()[degree-1]) > 0 {
+		prefix = "#"
+	}
+	return fmt.Sprintf("%s%d", prefix, degree)
+}
+
+// GetNumberedChord returns the chord symbol in the numbered degree relative to the do of the key, e.g. D/F# in G major is 5/7
+func GetNumberedChord(ks keysig.Key, harmony musicxml.Harmony) string {
+	if harmony.IsNoChord() {
+		return harmony.String()
+	}
+
+	result := convertStepToDegree(ks, harmony.Root.Step, harmony.Root.Alter) + harmony.Kind.Suffix()
+	if harmony.Bass != nil {
+		result += "/" + convertStepToDegree(ks, harmony.Bass.Step, harmony.Bass.Alter)
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package moveabledo
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNumberedChord(t *testing.T) {
+	gMajor := keysig.Key{Fifth: 1, Key: "g", Mode: keysig.NewMode("major"), Humanized: "do = g"}
+	minorSeventh := "m7"
+
+	tests := []struct {
+		name    string
+		ks      keysig.Key
+		harmony musicxml.Harmony
+		want    string
+	}{
+		{
+			name: "tonic",
+			ks:   gMajor,
+			harmony: musicxml.Harmony{
+				Root: musicxml.HarmonyRoot{Step: "G"},
+				Kind: musicxml.HarmonyKind{Value: "major"},
+			},
+			want: "1",
+		},
+		{
+			name: "dominant over the leading tone",
+			ks:   gMajor,
+			harmony: musicxml.Harmony{
+				Root: musicxml.HarmonyRoot{Step: "D"},
+				Kind: musicxml.HarmonyKind{Value: "major"},
+				Bass: &musicxml.HarmonyBass{Step: "F", Alter: 1},
+			},
+			want: "5/7",
+		},
+		{
+			name: "written kind text",
+			ks:   gMajor,
+			harmony: musicxml.Harmony{
+				Root: musicxml.HarmonyRoot{Step: "E"},
+				Kind: musicxml.HarmonyKind{Value: "minor-seventh", Text: &minorSeventh},
+			},
+			want: "6m7",
+		},
+		{
+			name: "outside the scale",
+			ks:   gMajor,
+			harmony: musicxml.Harmony{
+				Root: musicxml.HarmonyRoot{Step: "F"},
+				Kind: musicxml.HarmonyKind{Value: "major"},
+			},
+			want: "b7",
+		},
+		{
+			name: "no chord",
+			ks:   gMajor,
+			harmony: musicxml.Harmony{
+				Kind: musicxml.HarmonyKind{Value: "none"},
+			},
+			want: "N.C.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetNumberedChord(tt.ks, tt.harmony))
+		})
+	}
+}
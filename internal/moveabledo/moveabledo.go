@@ -1,6 +1,8 @@
 package moveabledo
 
 import (
+	"math"
+
 	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
@@ -50,24 +52,18 @@ func GetNumberedNotation(ks keysig.Key, note musicxml.Note) (numberedNote int, o
 	return numberedNote, octave, strikethrough
 }
 
+// GetOctave returns the octave mark of the note, the octave of the do of the key has no mark
 func GetOctave(ks keysig.Key, note musicxml.Note) int {
-	pitch := ks.GetPitchWithAccidental(note)
-	if ks.Mode.Mode == keysig.KeySignatureModeMajor && ks.Fifth == 0 { // C major
-		return note.Pitch.Octave - 4
-	}
-
-	offset := 0
-
-	if ks.Fifth == -2 && ks.Mode.Mode == keysig.KeySignatureModeMajor { // Bb Major or A major or F#min
-		offset = +1
-	}
+	distance := writtenSemitones(ks.GetPitchWithAccidental(note), note.Pitch.Octave) - writtenSemitones(ks.GetBasedPitch(), ks.GetDoOctave())
+	return int(math.Floor(float64(distance) / 12))
+}
 
-	do := ks.GetBasedPitch()
-	direction := utils.ComparePitch(do, pitch)
-	if direction == 1 { // below do
-		return note.Pitch.Octave - 4 - 1 + offset
+// writtenSemitones returns the semitones of the pitch from C0. the octave belongs to the letter, e.g. Cb4 is below C4
+func writtenSemitones(pitch string, octave int) int {
+	if pitch == "" {
+		return octave * 12
 	}
-
-	return note.Pitch.Octave - 4 + offset
-
+	letter := utils.PitchSemitone(pitch[:1])
+	accidental := (utils.PitchSemitone(pitch)-letter+18)%12 - 6
+	return octave*12 + letter + accidental
 }
@@ -358,6 +358,42 @@ func TestConvertPitchToNumbered(t *testing.T) {
 			wantNumbered: 4,
 			wantStrike:   true,
 		},
+		{
+			name: "F lydian input B",
+			args: args{
+				ks:    keysig.NewKey(&musicxml.KeySignature{Fifth: 0, Mode: "lydian"}),
+				pitch: "B",
+			},
+			wantNumbered: 4,
+			wantStrike:   false,
+		},
+		{
+			name: "B locrian input F",
+			args: args{
+				ks:    keysig.NewKey(&musicxml.KeySignature{Fifth: 0, Mode: "locrian"}),
+				pitch: "F",
+			},
+			wantNumbered: 5,
+			wantStrike:   false,
+		},
+		{
+			name: "G minor with the leading tone input F#",
+			args: args{
+				ks:    keysig.NewKey(&musicxml.KeySignature{Mode: "minor", Steps: []string{"B", "E", "F"}, Alters: []float64{-1, -1, 1}}),
+				pitch: "F#",
+			},
+			wantNumbered: 7,
+			wantStrike:   false,
+		},
+		{
+			name: "G minor with the leading tone input F",
+			args: args{
+				ks:    keysig.NewKey(&musicxml.KeySignature{Mode: "minor", Steps: []string{"B", "E", "F"}, Alters: []float64{-1, -1, 1}}),
+				pitch: "F",
+			},
+			wantNumbered: 7,
+			wantStrike:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -378,6 +414,7 @@ func TestGetOctave(t *testing.T) {
 		Humanized string
 		Fifth     int
 		Mode      keysig.KeySignatureMode
+		DoOctave  int
 	}
 	type args struct {
 		note musicxml.Note
@@ -536,6 +573,49 @@ func TestGetOctave(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "C4 - re - on Bb major with the do on the third octave",
+			fields: fields{
+				Key:       "bb",
+				Humanized: "do = bes",
+				Fifth:     -2,
+				Mode:      keysig.KeySignatureModeMajor,
+				DoOctave:  3,
+			},
+			args: args{
+				note: musicxml.Note{
+					Pitch: struct {
+						Step   string `xml:"step"`
+						Octave int    `xml:"octave"`
+					}{
+						Step:   "C",
+						Octave: 4,
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "Cb5 - do - on Cb major",
+			fields: fields{
+				Key:       "cb",
+				Humanized: "do = ces",
+				Fifth:     -7,
+				Mode:      keysig.KeySignatureModeMajor,
+			},
+			args: args{
+				note: musicxml.Note{
+					Pitch: struct {
+						Step   string `xml:"step"`
+						Octave int    `xml:"octave"`
+					}{
+						Step:   "C",
+						Octave: 5,
+					},
+				},
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -543,6 +623,8 @@ func TestGetOctave(t *testing.T) {
 				Key:       tt.fields.Key,
 				Humanized: tt.fields.Humanized,
 				Fifth:     tt.fields.Fifth,
+				Mode:      keysig.NewMode(tt.fields.Mode.String()),
+				DoOctave:  tt.fields.DoOctave,
 			}
 			if got := GetOctave(ks, tt.args.note); got != tt.want {
 				t.Errorf("KeySignature.GetOctave() = %v, want %v", got, tt.want)
@@ -0,0 +1,36 @@
+package musicxml
+
+type ClefSign string
+
+const (
+	ClefSignG          ClefSign = "G"
+	ClefSignF          ClefSign = "F"
+	ClefSignC          ClefSign = "C"
+	ClefSignPercussion ClefSign = "percussion"
+)
+
+type Clef struct {
+	// the staff number, only the first staff is rendered
+	Number       int      `xml:"number,attr"`
+	Sign         ClefSign `xml:"sign"`
+	Line         int      `xml:"line"`
+	OctaveChange int      `xml:"clef-octave-change"`
+}
+
+// TrebleClef is the clef used when the score does not declare any
+var TrebleClef = Clef{Sign: ClefSignG, Line: 2}
+
+// GetClef returns the clef of the first staff, nil when the attributes does not change the clef
+func (a Attribute) GetClef() *Clef {
+	for i, c := range a.Clef {
+		if c.Number <= 1 {
+			return &a.Clef[i]
+		}
+	}
+	return nil
+}
+
+// IsEqual compares the clef regardless the staff number
+func (c Clef) IsEqual(other Clef) bool {
+	return c.Sign == other.Sign && c.Line == other.Line && c.OctaveChange == other.OctaveChange
+}
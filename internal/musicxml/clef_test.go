@@ -0,0 +1,43 @@
+package musicxml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttribute_GetClef(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Clef
+	}{
+		{
+			name:    "without clef",
+			content: `<divisions>1</divisions>`,
+		},
+		{
+			name:    "bass clef",
+			content: `<clef><sign>F</sign><line>4</line></clef>`,
+			want:    &Clef{Sign: ClefSignF, Line: 4},
+		},
+		{
+			name:    "treble 8vb",
+			content: `<clef><sign>G</sign><line>2</line><clef-octave-change>-1</clef-octave-change></clef>`,
+			want:    &Clef{Sign: ClefSignG, Line: 2, OctaveChange: -1},
+		},
+		{
+			name:    "grand staff",
+			content: `<clef number="2"><sign>F</sign><line>4</line></clef><clef number="1"><sign>G</sign><line>2</line></clef>`,
+			want:    &Clef{Number: 1, Sign: ClefSignG, Line: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr := Attribute{}
+			elmnt := NewElement(ElementAttributes, tt.content)
+			assert.NoError(t, elmnt.Decode(&attr))
+			assert.Equal(t, tt.want, attr.GetClef())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package musicxml
 
 import (
 	"encoding/xml"
+	"strings"
 )
 
 type TextAlignment string
@@ -11,40 +12,124 @@ const (
 	TextAlignmentLeft  TextAlignment = "left"
 )
 
+// the children of the measure
+const (
+	ElementNote       = "note"
+	ElementDirection  = "direction"
+	ElementAttributes = "attributes"
+	ElementBackup     = "backup"
+	ElementForward    = "forward"
+	ElementHarmony    = "harmony"
+	ElementBarline    = "barline"
+	ElementPrint      = "print"
+	ElementSound      = "sound"
+)
+
+// Element is a child of the measure, kept in the document order
 type Element struct {
-	// FIXME: mechanism to check wheter it is notes / direction
-	// currently by checking pitch | rest -> notes
-	// checking direction-type -> direction
-	Content string `xml:",innerxml"`
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",innerxml"`
 }
 
-func (e *Element) ParseAsNote() (Note, error) {
-	wrapped := `<note>`
-	wrapped += e.Content
-	wrapped += `</note>`
+func NewElement(name, content string, attrs ...xml.Attr) Element {
+	return Element{
+		XMLName: xml.Name{Local: name},
+		Attrs:   attrs,
+		Content: content,
+	}
+}
 
+// Name returns the tag name of the element
+func (e *Element) Name() string {
+	return e.XMLName.Local
+}
+
+// IsNote tells whether the element is a note, including the rest, the grace, the cue and the chord
+func (e *Element) IsNote() bool {
+	return e.Name() == ElementNote
+}
+
+// IsDirection tells whether the element is a direction
+func (e *Element) IsDirection() bool {
+	return e.Name() == ElementDirection
+}
+
+// Decode decodes the whole element, including its attributes into v
+func (e *Element) Decode(v any) error {
+	return e.decodeAs(e.Name(), v)
+}
+
+func (e *Element) decodeAs(name string, v any) error {
+	wrapped := &strings.Builder{}
+	wrapped.WriteString("<" + name)
+	for _, attr := range e.Attrs {
+		wrapped.WriteString(" " + attr.Name.Local + `="`)
+		xml.EscapeText(wrapped, []byte(attr.Value))
+		wrapped.WriteString(`"`)
+	}
+	wrapped.WriteString(">")
+	wrapped.WriteString(e.Content)
+	wrapped.WriteString("</" + name + ">")
+
+	return xml.Unmarshal([]byte(wrapped.String()), v)
+}
+
+func (e *Element) ParseAsNote() (Note, error) {
 	result := Note{}
 
-	err := xml.Unmarshal([]byte(wrapped), &result)
+	err := e.decodeAs(ElementNote, &result)
 	return result, err
 }
 
 func (e *Element) ParseAsDirection() (*Direction, error) {
-	wrapped := `<direction>`
-	wrapped += e.Content
-	wrapped += `</direction>`
-
 	result := &Direction{}
 
-	err := xml.Unmarshal([]byte(wrapped), &result)
+	err := e.decodeAs(ElementDirection, &result)
 	return result, err
 }
 
 type MusicXML struct {
-	XMLName xml.Name `xml:"score-partwise"`
-	Credit  []Credit `xml:"credit"`
-	Part    Part     `xml:"part"`
-	Work    Work     `xml:"work"`
+	XMLName  xml.Name `xml:"score-partwise"`
+	Credit   []Credit `xml:"credit"`
+	PartList PartList `xml:"part-list"`
+	Parts    []Part   `xml:"part"`
+	Work     Work     `xml:"work"`
+}
+
+// MainPart returns the leading part of the score (soprano / melody).
+// the key, time signature and the lyric are taken from this part
+func (mx *MusicXML) MainPart() *Part {
+	if len(mx.Parts) == 0 {
+		return &Part{}
+	}
+	return &mx.Parts[0]
+}
+
+// NamedParts returns the parts with the name declared in the part-list
+func (mx *MusicXML) NamedParts() []Part {
+	result := make([]Part, len(mx.Parts))
+	for i, part := range mx.Parts {
+		for _, sp := range mx.PartList.ScorePart {
+			if sp.ID == part.ID {
+				part.Name = sp.Name
+				part.Abbreviation = sp.Abbreviation
+				break
+			}
+		}
+		result[i] = part
+	}
+	return result
+}
+
+type PartList struct {
+	ScorePart []ScorePart `xml:"score-part"`
+}
+
+type ScorePart struct {
+	ID           string `xml:"id,attr"`
+	Name         string `xml:"part-name"`
+	Abbreviation string `xml:"part-abbreviation"`
 }
 
 type CreditType string
@@ -73,12 +158,20 @@ type locationAttr struct {
 type Part struct {
 	ID       string    `xml:"id,attr"`
 	Measures []Measure `xml:"measure"`
+
+	// taken from the part-list
+	Name         string `xml:"-"`
+	Abbreviation string `xml:"-"`
+
+	Layer *VoiceLayer `xml:"-"`
 }
 
 type MeasureText struct {
 	Text          string
 	RelativeY     float64
 	TextAlignment TextAlignment
+	// the segno or coda sign, rendered instead of the text
+	Symbol NavigationType
 }
 
 type PrintNewSystemType string
@@ -95,14 +188,23 @@ type Print struct {
 type KeySignature struct {
 	Fifth int    `xml:"fifths"`
 	Mode  string `xml:"mode"`
+	// the non-traditional key, the letters and their alter come in pairs (e.g. B -1, F 1)
+	Steps  []string  `xml:"key-step"`
+	Alters []float64 `xml:"key-alter"`
+	// the octave of the do, the first degree without the octave mark. it is not on the musicxml,
+	// the transposition moves it with the notes. zero is the fourth octave
+	DoOctave int `xml:"-"`
 }
 
 type Attribute struct {
-	Key  *KeySignature `xml:"key"`
-	Time *struct {
+	// the ticks of the quarter note used by the duration of the notes
+	Divisions int           `xml:"divisions"`
+	Key       *KeySignature `xml:"key"`
+	Time      *struct {
 		Beats    int `xml:"beats"`
 		BeatType int `xml:"beat-type"`
 	} `xml:"time"`
+	Clef []Clef `xml:"clef"`
 }
 
 type NoteLength string
@@ -193,6 +295,24 @@ type Dot struct {
 
 type Rest struct {
 	Name xml.Name `xml:"rest"`
+	// the whole measure rest, the type of the note can be omitted
+	Measure string `xml:"measure,attr"`
+}
+
+type Chord struct {
+	Name xml.Name `xml:"chord"`
+}
+
+type Grace struct {
+	Name xml.Name `xml:"grace"`
+	// the acciaccatura, the grace note with slash
+	Slash string `xml:"slash,attr"`
+}
+
+// Unpitched is the note of the percussion, positioned by the display step and octave
+type Unpitched struct {
+	DisplayStep   string `xml:"display-step"`
+	DisplayOctave int    `xml:"display-octave"`
 }
 
 type Tie struct {
@@ -204,6 +324,19 @@ type Tie struct {
 type Direction struct {
 	Placement     string          `xml:"placement,attr"`
 	DirectionType []DirectionType `xml:"direction-type"`
+	Sound         *Sound          `xml:"sound"`
+}
+
+// Sound holds the playback of the navigation markings and the tempo, the value of the navigation is the name of the segno / coda target
+type Sound struct {
+	Segno    string `xml:"segno,attr"`
+	Coda     string `xml:"coda,attr"`
+	DaCapo   Bool   `xml:"dacapo,attr"`
+	DalSegno string `xml:"dalsegno,attr"`
+	ToCoda   string `xml:"tocoda,attr"`
+	Fine     string `xml:"fine,attr"`
+	// the quarter notes per minute
+	Tempo float64 `xml:"tempo,attr"`
 }
 
 type DirectionDashesType string
@@ -227,6 +360,12 @@ type DirectionType struct {
 		Value string `xml:",chardata"`
 	} `xml:"rehearsal"`
 	Dashes *DirectionDashes `xml:"dashes"`
+	Segno  *struct{}        `xml:"segno"`
+	Coda   *struct{}        `xml:"coda"`
+
+	Dynamics  *Dynamics  `xml:"dynamics"`
+	Wedge     *Wedge     `xml:"wedge"`
+	Metronome *Metronome `xml:"metronome"`
 }
 
 type Bool string
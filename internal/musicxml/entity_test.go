@@ -40,15 +40,15 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: pitch},
-						{Content: direction},
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, direction),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				Appendix: []Element{
-					{Content: pitch},
-					{Content: direction},
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, direction),
 				},
 				Notes: []Note{
 					Note{
@@ -56,7 +56,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -79,7 +81,7 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: "<pitch>Nope"},
+						NewElement(ElementNote, "<pitch>Nope"),
 					},
 				}
 			},
@@ -90,17 +92,17 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: pitch},
-						{Content: direction},
-						{Content: pitch},
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, direction),
+						NewElement(ElementNote, pitch),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				Appendix: []Element{
-					{Content: pitch},
-					{Content: direction},
-					{Content: pitch},
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, direction),
+					NewElement(ElementNote, pitch),
 				},
 				Notes: []Note{
 					{
@@ -108,7 +110,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -122,7 +126,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -136,6 +142,7 @@ func TestMeasure_Build(t *testing.T) {
 							{Text: "Refrein"},
 						},
 						IndexPosition: 2,
+						Element:       2,
 					},
 				},
 				NewLineIndex: map[int]bool{},
@@ -146,7 +153,7 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: "<direction-type>Nope"},
+						NewElement(ElementDirection, "<direction-type>Nope"),
 					},
 				}
 			},
@@ -158,18 +165,18 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: pitch},
-						{Content: `<direction-type><words>__layout=br</words></direction-type>`},
-						{Content: pitch},
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, `<direction-type><words>__layout=br</words></direction-type>`),
+						NewElement(ElementNote, pitch),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				NewLineIndex: map[int]bool{1: true},
 				Appendix: []Element{
-					{Content: pitch},
-					{Content: `<direction-type><words>__layout=br</words></direction-type>`},
-					{Content: pitch},
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, `<direction-type><words>__layout=br</words></direction-type>`),
+					NewElement(ElementNote, pitch),
 				},
 				Notes: []Note{
 					{
@@ -177,7 +184,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -194,7 +203,10 @@ func TestMeasure_Build(t *testing.T) {
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
 						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
 						IndexPosition: 2,
+						Element:       2,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -213,17 +225,17 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: pitch},
-						{Content: `<direction-type><words>D.C. al Fine</words></direction-type>`},
-						{Content: pitch},
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, `<direction-type><words>D.C. al Fine</words></direction-type>`),
+						NewElement(ElementNote, pitch),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				Appendix: []Element{
-					{Content: pitch},
-					{Content: `<direction-type><words>D.C. al Fine</words></direction-type>`},
-					{Content: pitch},
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, `<direction-type><words>D.C. al Fine</words></direction-type>`),
+					NewElement(ElementNote, pitch),
 				},
 				Notes: []Note{
 					{
@@ -231,7 +243,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -248,7 +262,76 @@ func TestMeasure_Build(t *testing.T) {
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
 						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
 						IndexPosition: 2,
+						Element:       2,
+						MeasureText: []MeasureText{
+							{Text: "D.C. al Fine"},
+						},
+						Lyric: []Lyric{
+							{
+								Number:   1,
+								Syllabic: LyricSyllabicTypeEnd,
+								Text: []LyricText{
+									{Value: "rap"},
+								},
+							},
+						},
+					},
+				},
+				NewLineIndex: map[int]bool{},
+			},
+		},
+		{
+			name: "segno sign",
+			m: func() *Measure {
+				return &Measure{
+					Appendix: []Element{
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, `<direction-type><segno/></direction-type>`),
+						NewElement(ElementNote, pitch),
+					},
+				}
+			},
+			wantMeasure: &Measure{
+				Appendix: []Element{
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, `<direction-type><segno/></direction-type>`),
+					NewElement(ElementNote, pitch),
+				},
+				Notes: []Note{
+					{
+						Pitch: struct {
+							Step   string `xml:"step"`
+							Octave int    `xml:"octave"`
+						}{Step: "G", Octave: 4},
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
+						Lyric: []Lyric{
+							{
+								Number:   1,
+								Syllabic: LyricSyllabicTypeEnd,
+								Text: []LyricText{
+									{Value: "rap"},
+								},
+							},
+						},
+					},
+					{
+						Pitch: struct {
+							Step   string `xml:"step"`
+							Octave int    `xml:"octave"`
+						}{Step: "G", Octave: 4},
+						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
+						IndexPosition: 2,
+						Element:       2,
+						MeasureText: []MeasureText{
+							{Symbol: NavigationSegno},
+						},
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -268,19 +351,19 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: pitch},
-						{Content: `<direction-type><words>Rit.</words></direction-type><direction-type><dashes type="start" number="1" /> </direction-type>`},
-						{Content: pitch},
-						{Content: `<direction-type><dashes type="stop" number="1" /> </direction-type>`},
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, `<direction-type><words>Rit.</words></direction-type><direction-type><dashes type="start" number="1" /> </direction-type>`),
+						NewElement(ElementNote, pitch),
+						NewElement(ElementDirection, `<direction-type><dashes type="stop" number="1" /> </direction-type>`),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				Appendix: []Element{
-					{Content: pitch},
-					{Content: `<direction-type><words>Rit.</words></direction-type><direction-type><dashes type="start" number="1" /> </direction-type>`},
-					{Content: pitch},
-					{Content: `<direction-type><dashes type="stop" number="1" /> </direction-type>`},
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, `<direction-type><words>Rit.</words></direction-type><direction-type><dashes type="start" number="1" /> </direction-type>`),
+					NewElement(ElementNote, pitch),
+					NewElement(ElementDirection, `<direction-type><dashes type="stop" number="1" /> </direction-type>`),
 				},
 				Notes: []Note{
 					{
@@ -288,7 +371,9 @@ func TestMeasure_Build(t *testing.T) {
 							Step   string `xml:"step"`
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
-						Type: NoteLengthHalf,
+						Type:     NoteLengthHalf,
+						Voice:    "1",
+						Duration: 4,
 						Lyric: []Lyric{
 							{
 								Number:   1,
@@ -305,7 +390,10 @@ func TestMeasure_Build(t *testing.T) {
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
 						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
 						IndexPosition: 2,
+						Element:       2,
 						MeasureText: []MeasureText{
 							{Text: "Rit."},
 						},
@@ -337,17 +425,17 @@ func TestMeasure_Build(t *testing.T) {
 			m: func() *Measure {
 				return &Measure{
 					Appendix: []Element{
-						{Content: `<direction-type><rehearsal>1</rehearsal></direction-type>`},
-						{Content: pitch},
-						{Content: pitch},
+						NewElement(ElementDirection, `<direction-type><rehearsal>1</rehearsal></direction-type>`),
+						NewElement(ElementNote, pitch),
+						NewElement(ElementNote, pitch),
 					},
 				}
 			},
 			wantMeasure: &Measure{
 				Appendix: []Element{
-					{Content: `<direction-type><rehearsal>1</rehearsal></direction-type>`},
-					{Content: pitch},
-					{Content: pitch},
+					NewElement(ElementDirection, `<direction-type><rehearsal>1</rehearsal></direction-type>`),
+					NewElement(ElementNote, pitch),
+					NewElement(ElementNote, pitch),
 				},
 				Notes: []Note{
 					{
@@ -356,7 +444,10 @@ func TestMeasure_Build(t *testing.T) {
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
 						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
 						IndexPosition: 1,
+						Element:       1,
 						MeasureText:   []MeasureText{{}},
 						Lyric: []Lyric{
 							Lyric{
@@ -374,7 +465,10 @@ func TestMeasure_Build(t *testing.T) {
 							Octave int    `xml:"octave"`
 						}{Step: "G", Octave: 4},
 						IndexPosition: 2,
+						Element:       2,
 						Type:          NoteLengthHalf,
+						Voice:         "1",
+						Duration:      4,
 						Lyric: []Lyric{
 							Lyric{
 								Number:   1,
@@ -0,0 +1,108 @@
+package musicxml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type WedgeType string
+
+const (
+	WedgeTypeCrescendo  WedgeType = "crescendo"
+	WedgeTypeDiminuendo WedgeType = "diminuendo"
+	WedgeTypeStop       WedgeType = "stop"
+	WedgeTypeContinue   WedgeType = "continue"
+)
+
+// Dynamics is the dynamic marking (p, mf, f, ...), every mark is an empty element
+type Dynamics struct {
+	Marks []struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	} `xml:",any"`
+}
+
+// String returns the marking as it is written, e.g. "mf" or "sfz"
+func (d Dynamics) String() string {
+	result := ""
+	for _, mark := range d.Marks {
+		if mark.XMLName.Local == "other-dynamics" {
+			result += strings.TrimSpace(mark.Value)
+			continue
+		}
+		result += mark.XMLName.Local
+	}
+	return result
+}
+
+// Wedge is the crescendo / diminuendo hairpin
+type Wedge struct {
+	Type   WedgeType `xml:"type,attr"`
+	Number int       `xml:"number,attr"`
+
+	// the stop is written after the last note of the measure, it ends on the right side of the note
+	Trailing bool `xml:"-"`
+	// the type of the hairpin closed by the stop, when it is started on the previous line
+	Opening WedgeType `xml:"-"`
+}
+
+// Metronome is the tempo marking, e.g. ♩ = 88
+type Metronome struct {
+	BeatUnit    NoteLength `xml:"beat-unit"`
+	BeatUnitDot []*Dot     `xml:"beat-unit-dot"`
+	PerMinute   string     `xml:"per-minute"`
+}
+
+var metronomeBeatUnit = map[NoteLength]string{
+	NoteLengthWhole:   "𝅝",
+	NoteLengthHalf:    "𝅗𝅥",
+	NoteLengthQuarter: "♩",
+	NoteLengthEighth:  "♪",
+}
+
+// String returns the humanized tempo marking
+func (m Metronome) String() string {
+	unit, ok := metronomeBeatUnit[m.BeatUnit]
+	if !ok {
+		unit = metronomeBeatUnit[NoteLengthQuarter]
+	}
+	unit += strings.Repeat(".", len(m.BeatUnitDot))
+
+	return fmt.Sprintf("%s = %s", unit, strings.TrimSpace(m.PerMinute))
+}
+
+// IsExpression tells whether the direction is a dynamics, hairpin or tempo marking
+func (d Direction) IsExpression() bool {
+	if len(d.DirectionType) == 0 {
+		return false
+	}
+	initial := d.DirectionType[0]
+	return initial.Dynamics != nil || initial.Wedge != nil || initial.Metronome != nil
+}
+
+// GetTempo returns the tempo marking written on the first measure of the part
+func (p Part) GetTempo() *Metronome {
+	if len(p.Measures) == 0 {
+		return nil
+	}
+
+	for _, elmnt := range p.Measures[0].Appendix {
+		if !elmnt.IsDirection() {
+			continue
+		}
+
+		d, err := elmnt.ParseAsDirection()
+		if err != nil {
+			continue
+		}
+
+		for _, dt := range d.DirectionType {
+			if dt.Metronome != nil {
+				return dt.Metronome
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package musicxml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirection_Expression(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		wantDynamics  string
+		wantWedge     *Wedge
+		wantMetronome string
+	}{
+		{
+			name:         "dynamics",
+			content:      `<direction-type><dynamics><m/><f/></dynamics></direction-type>`,
+			wantDynamics: "mf",
+		},
+		{
+			name:         "other dynamics",
+			content:      `<direction-type><dynamics><other-dynamics>poco f</other-dynamics></dynamics></direction-type>`,
+			wantDynamics: "poco f",
+		},
+		{
+			name:      "wedge",
+			content:   `<direction-type><wedge type="crescendo" number="1"/></direction-type>`,
+			wantWedge: &Wedge{Type: WedgeTypeCrescendo, Number: 1},
+		},
+		{
+			name:          "dotted metronome",
+			content:       `<direction-type><metronome><beat-unit>half</beat-unit><beat-unit-dot/><per-minute>60</per-minute></metronome></direction-type>`,
+			wantMetronome: "𝅗𝅥. = 60",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elmnt := NewElement(ElementDirection, tt.content)
+			d, err := elmnt.ParseAsDirection()
+			assert.NoError(t, err)
+			assert.True(t, d.IsExpression())
+
+			dt := d.DirectionType[0]
+			if tt.wantDynamics != "" {
+				assert.Equal(t, tt.wantDynamics, dt.Dynamics.String())
+			}
+			assert.Equal(t, tt.wantWedge, dt.Wedge)
+			if tt.wantMetronome != "" {
+				assert.Equal(t, tt.wantMetronome, dt.Metronome.String())
+			}
+		})
+	}
+}
+
+func TestMeasure_BuildExpression(t *testing.T) {
+	note := `<pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>`
+	measure := Measure{
+		Number: 2,
+		Appendix: []Element{
+			NewElement(ElementDirection, `<direction-type><metronome><beat-unit>quarter</beat-unit><per-minute>72</per-minute></metronome></direction-type>`),
+			NewElement(ElementDirection, `<direction-type><dynamics><p/></dynamics></direction-type>`),
+			NewElement(ElementDirection, `<direction-type><wedge type="crescendo" number="1"/></direction-type>`),
+			NewElement(ElementNote, note),
+			NewElement(ElementNote, note),
+			NewElement(ElementDirection, `<direction-type><wedge type="stop" number="1"/></direction-type>`),
+		},
+	}
+
+	assert.NoError(t, measure.Build())
+	assert.Len(t, measure.Notes, 2)
+
+	first := measure.Notes[0]
+	assert.Equal(t, "p", first.Dynamics)
+	assert.Equal(t, []Wedge{{Type: WedgeTypeCrescendo, Number: 1}}, first.Wedges)
+	assert.Equal(t, []MeasureText{{Text: "♩ = 72"}}, first.MeasureText)
+
+	assert.Equal(t, []Wedge{{Type: WedgeTypeStop, Number: 1, Trailing: true}}, measure.Notes[1].Wedges)
+}
+
+func TestMeasure_BuildTempoOnPickup(t *testing.T) {
+	tempo := NewElement(ElementDirection, `<direction-type><metronome><beat-unit>quarter</beat-unit><per-minute>72</per-minute></metronome></direction-type>`)
+	note := NewElement(ElementNote, `<pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>`)
+	measures := []Measure{
+		{RawNumber: "0", Implicit: true, Appendix: []Element{tempo, note}},
+		{RawNumber: "1", Number: 1, Appendix: []Element{tempo, note}},
+	}
+	NormalizeMeasureNumbers(measures)
+
+	assert.NoError(t, measures[0].Build())
+	assert.Empty(t, measures[0].Notes[0].MeasureText, "the tempo of the first measure is on the header")
+
+	assert.NoError(t, measures[1].Build())
+	assert.Equal(t, []MeasureText{{Text: "♩ = 72"}}, measures[1].Notes[0].MeasureText)
+}
+
+func TestPart_GetTempo(t *testing.T) {
+	part := Part{
+		Measures: []Measure{
+			{
+				Appendix: []Element{
+					NewElement(ElementDirection, `<direction-type><words>Andante</words></direction-type><direction-type><metronome><beat-unit>quarter</beat-unit><per-minute>88</per-minute></metronome></direction-type>`),
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, &Metronome{BeatUnit: NoteLengthQuarter, PerMinute: "88"}, part.GetTempo())
+	assert.Nil(t, Part{}.GetTempo())
+}
@@ -0,0 +1,86 @@
+package musicxml
+
+import (
+	"math"
+	"strings"
+)
+
+// Harmony is the chord symbol written above the note, e.g. D/F#
+type Harmony struct {
+	Root HarmonyRoot  `xml:"root"`
+	Kind HarmonyKind  `xml:"kind"`
+	Bass *HarmonyBass `xml:"bass"`
+}
+
+type HarmonyRoot struct {
+	Step  string  `xml:"root-step"`
+	Alter float64 `xml:"root-alter"`
+}
+
+type HarmonyBass struct {
+	Step  string  `xml:"bass-step"`
+	Alter float64 `xml:"bass-alter"`
+}
+
+type HarmonyKind struct {
+	Value string `xml:",chardata"`
+	// the suffix as it is written, it overrides the kind
+	Text *string `xml:"text,attr"`
+}
+
+var harmonyKindSuffix = map[string]string{
+	"major":              "",
+	"minor":              "m",
+	"augmented":          "+",
+	"diminished":         "dim",
+	"dominant":           "7",
+	"major-seventh":      "maj7",
+	"minor-seventh":      "m7",
+	"diminished-seventh": "dim7",
+	"augmented-seventh":  "+7",
+	"half-diminished":    "m7b5",
+	"major-minor":        "m(maj7)",
+	"major-sixth":        "6",
+	"minor-sixth":        "m6",
+	"dominant-ninth":     "9",
+	"major-ninth":        "maj9",
+	"minor-ninth":        "m9",
+	"suspended-second":   "sus2",
+	"suspended-fourth":   "sus4",
+	"power":              "5",
+}
+
+// Suffix returns the chord quality written after the root, e.g. "m7"
+func (hk HarmonyKind) Suffix() string {
+	if hk.Text != nil {
+		return *hk.Text
+	}
+	return harmonyKindSuffix[strings.TrimSpace(hk.Value)]
+}
+
+// IsNoChord tells whether the harmony is the N.C. (no chord) mark
+func (h Harmony) IsNoChord() bool {
+	return strings.TrimSpace(h.Kind.Value) == "none"
+}
+
+// AlterSymbol returns the accidental of the alter, e.g. "#" or "b"
+func AlterSymbol(alter float64) string {
+	steps := int(math.Round(alter))
+	if steps > 0 {
+		return strings.Repeat("#", steps)
+	}
+	return strings.Repeat("b", -steps)
+}
+
+// String returns the chord name, e.g. "Em7" or "D/F#"
+func (h Harmony) String() string {
+	if h.IsNoChord() {
+		return "N.C."
+	}
+
+	result := h.Root.Step + AlterSymbol(h.Root.Alter) + h.Kind.Suffix()
+	if h.Bass != nil {
+		result += "/" + h.Bass.Step + AlterSymbol(h.Bass.Alter)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package musicxml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHarmony_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "major",
+			content: `<root><root-step>G</root-step></root><kind>major</kind>`,
+			want:    "G",
+		},
+		{
+			name:    "slash chord",
+			content: `<root><root-step>D</root-step></root><kind>major</kind><bass><bass-step>F</bass-step><bass-alter>1</bass-alter></bass>`,
+			want:    "D/F#",
+		},
+		{
+			name:    "minor seventh",
+			content: `<root><root-step>E</root-step></root><kind>minor-seventh</kind>`,
+			want:    "Em7",
+		},
+		{
+			name:    "written kind text",
+			content: `<root><root-step>B</root-step><root-alter>-1</root-alter></root><kind text="sus">suspended-fourth</kind>`,
+			want:    "Bbsus",
+		},
+		{
+			name:    "no chord",
+			content: `<root><root-step>C</root-step></root><kind>none</kind>`,
+			want:    "N.C.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Harmony{}
+			elmnt := NewElement(ElementHarmony, tt.content)
+			assert.NoError(t, elmnt.Decode(&h))
+			assert.Equal(t, tt.want, h.String())
+		})
+	}
+}
+
+func TestMeasure_BuildHarmony(t *testing.T) {
+	note := `<pitch><step>G</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>`
+	measure := Measure{
+		Number: 1,
+		Appendix: []Element{
+			NewElement(ElementHarmony, `<root><root-step>G</root-step></root><kind>major</kind>`),
+			NewElement(ElementNote, note),
+			NewElement(ElementNote, note),
+		},
+	}
+
+	assert.NoError(t, measure.Build())
+	assert.Len(t, measure.Notes, 2)
+	assert.Equal(t, "G", measure.Notes[0].Harmony.String())
+	assert.Nil(t, measure.Notes[1].Harmony)
+}
@@ -0,0 +1,68 @@
+package musicxml
+
+import "strconv"
+
+// parseMeasureNumber returns the measure number, zero when it is not a number (e.g. "X1")
+func parseMeasureNumber(raw string) int {
+	number, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// IsPickup tells whether the measure is the anacrusis, the incomplete measure before the first measure
+func (m Measure) IsPickup() bool {
+	return m.Implicit && m.Number == 0
+}
+
+// NormalizeMeasureNumbers makes the measure numbers of every part unique, the first measure is marked.
+// the pickup measure is number 0 so the first complete measure is always number 1.
+// the measure with a non numeric number (e.g. "X1") or the repeated number takes the first number after the previous one
+// that is not written on any measure, the written numbers of the other measures are kept.
+func (mx *MusicXML) NormalizeMeasureNumbers() {
+	for i := range mx.Parts {
+		NormalizeMeasureNumbers(mx.Parts[i].Measures)
+	}
+}
+
+func NormalizeMeasureNumbers(measures []Measure) {
+	if len(measures) == 0 {
+		return
+	}
+
+	measures[0].First = true
+
+	// the first measure written as 0 is the pickup even without the implicit attribute
+	if measures[0].RawNumber == "0" {
+		measures[0].Implicit = true
+	}
+
+	prev := 0
+	if measures[0].Implicit {
+		measures[0].Number = 0
+		prev = -1
+	}
+
+	written := map[int]bool{}
+	for i := range measures {
+		if _, err := strconv.Atoi(measures[i].RawNumber); err == nil {
+			written[measures[i].Number] = true
+		}
+	}
+
+	used := map[int]bool{}
+	for i := range measures {
+		_, err := strconv.Atoi(measures[i].RawNumber)
+		isPickup := i == 0 && measures[i].Implicit
+		if !isPickup && (err != nil || used[measures[i].Number]) {
+			number := prev + 1
+			for written[number] || used[number] {
+				number++
+			}
+			measures[i].Number = number
+		}
+		used[measures[i].Number] = true
+		prev = measures[i].Number
+	}
+}
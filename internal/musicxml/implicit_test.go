@@ -0,0 +1,79 @@
+package musicxml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeMeasureNumbers(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantNumbers  []int
+		wantImplicit []bool
+	}{
+		{
+			name:         "without pickup",
+			content:      `<measure number="1"></measure><measure number="2"></measure>`,
+			wantNumbers:  []int{1, 2},
+			wantImplicit: []bool{false, false},
+		},
+		{
+			name:         "pickup numbered 0",
+			content:      `<measure number="0"></measure><measure number="1"></measure><measure number="2"></measure>`,
+			wantNumbers:  []int{0, 1, 2},
+			wantImplicit: []bool{true, false, false},
+		},
+		{
+			name:         "pickup with the non numeric number",
+			content:      `<measure number="X1" implicit="yes"></measure><measure number="1"></measure><measure number="2"></measure>`,
+			wantNumbers:  []int{0, 1, 2},
+			wantImplicit: []bool{true, false, false},
+		},
+		{
+			name:         "implicit pickup numbered 1",
+			content:      `<measure number="1" implicit="yes"></measure><measure number="2"></measure>`,
+			wantNumbers:  []int{0, 2},
+			wantImplicit: []bool{true, false},
+		},
+		{
+			name:         "split measure in the middle",
+			content:      `<measure number="1"></measure><measure number="2"></measure><measure number="X2" implicit="yes"></measure><measure number="3"></measure>`,
+			wantNumbers:  []int{1, 2, 4, 3},
+			wantImplicit: []bool{false, false, true, false},
+		},
+		{
+			name:         "non numeric number in the middle",
+			content:      `<measure number="1"></measure><measure number="X1"></measure><measure number="2"></measure><measure number="3"></measure>`,
+			wantNumbers:  []int{1, 4, 2, 3},
+			wantImplicit: []bool{false, false, false, false},
+		},
+		{
+			name:         "non numeric number on the gap",
+			content:      `<measure number="1"></measure><measure number="X1"></measure><measure number="3"></measure>`,
+			wantNumbers:  []int{1, 2, 3},
+			wantImplicit: []bool{false, false, false},
+		},
+		{
+			name:         "repeated number",
+			content:      `<measure number="1"></measure><measure number="2"></measure><measure number="2"></measure><measure number="3"></measure>`,
+			wantNumbers:  []int{1, 2, 4, 3},
+			wantImplicit: []bool{false, false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			music, err := Parse([]byte(`<score-partwise><part id="P1">` + tt.content + `</part></score-partwise>`))
+			assert.NoError(t, err)
+
+			measures := music.MainPart().Measures
+			assert.Len(t, measures, len(tt.wantNumbers))
+			for i, m := range measures {
+				assert.Equal(t, tt.wantNumbers[i], m.Number)
+				assert.Equal(t, tt.wantImplicit[i], m.Implicit)
+			}
+			assert.Equal(t, tt.wantImplicit[0] && tt.wantNumbers[0] == 0, measures[0].IsPickup())
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package musicxml
 
 import (
+	"encoding/xml"
 	"log"
-	"strings"
+	"slices"
 )
 
 type RepeatInfoType string
@@ -26,6 +27,7 @@ type RepeatInfo struct {
 }
 
 type Measure struct {
+	// all the children of the measure in the document order, see UnmarshalXML
 	Appendix     []Element    `xml:",any"`
 	Number       int          `xml:"number,attr"`
 	Attribute    *Attribute   `xml:"attributes" json:",omitempty"`
@@ -40,24 +42,205 @@ type Measure struct {
 	RightMeasureText *MeasureText   `xml:"-"`
 	PrefixHeader     map[int]string `xml:"-"`
 	RepeatInfo       *RepeatInfo
+
+	// the voice and chord position to build, nil builds the first voice
+	Layer *VoiceLayer `xml:"-"`
+
+	// the attributes written after the first note, e.g. the clef change in the middle of the measure
+	AttributeChanges []AttributeChange `xml:"-" json:",omitempty"`
+
+	// the number as it is written, e.g. "X1". see NormalizeMeasureNumbers
+	RawNumber string `xml:"-"`
+	// the measure is not counted, e.g. the pickup measure
+	Implicit bool `xml:"-"`
+	// the first measure of the part, its tempo is written on the header. see Part.GetTempo
+	First bool `xml:"-"`
+}
+
+// AttributeChange is the attributes element written in the middle of the measure, active from the element onward
+type AttributeChange struct {
+	// the position of the attributes on the Appendix
+	Element   int
+	Attribute Attribute
+}
+
+// UnmarshalXML decodes the children of the measure token by token, so none of them is dropped and the order is kept.
+// the attributes, barline and print are decoded into their own field as well.
+// the attributes before the first note are the Attribute, the later ones are the AttributeChanges
+func (m *Measure) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "number":
+			m.RawNumber = attr.Value
+			m.Number = parseMeasureNumber(attr.Value)
+		case "implicit":
+			m.Implicit = Bool(attr.Value) == BoolYes
+		}
+	}
+
+	hasNote := false
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			elmnt := Element{}
+			if err := d.DecodeElement(&elmnt, &t); err != nil {
+				return err
+			}
+
+			switch elmnt.Name() {
+			case ElementAttributes:
+				if !hasNote {
+					if m.Attribute == nil {
+						m.Attribute = &Attribute{}
+					}
+					err = elmnt.Decode(m.Attribute)
+					break
+				}
+
+				change := AttributeChange{Element: len(m.Appendix)}
+				err = elmnt.Decode(&change.Attribute)
+				m.AttributeChanges = append(m.AttributeChanges, change)
+
+			case ElementNote:
+				hasNote = true
+
+			case ElementBarline:
+				barline := Barline{}
+				err = elmnt.Decode(&barline)
+				m.Barline = append(m.Barline, barline)
+
+			case ElementPrint:
+				m.Print = &Print{}
+				err = elmnt.Decode(m.Print)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			m.Appendix = append(m.Appendix, elmnt)
+
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// ClefAt returns the clef changed on the measure on or before the element, nil when the clef is not changed
+func (m Measure) ClefAt(element int) *Clef {
+	var result *Clef
+	if m.Attribute != nil {
+		result = m.Attribute.GetClef()
+	}
+	for _, change := range m.AttributeChanges {
+		if change.Element > element {
+			break
+		}
+		if clef := change.Attribute.GetClef(); clef != nil {
+			result = clef
+		}
+	}
+	return result
+}
+
+// isLayoutElement tells whether the element is decoded into its own field, not counted on the note position
+func isLayoutElement(elmnt Element) bool {
+	switch elmnt.Name() {
+	case ElementAttributes, ElementBarline, ElementPrint:
+		return true
+	}
+	return false
 }
 
 func (m *Measure) Build() error {
 	m.NewLineIndex = map[int]bool{}
 	var measureText []MeasureText
-	foundDirectionType := 0
+
+	notes := map[int]Note{}
+	voices := []string{}
 	for i, elmnt := range m.Appendix {
-		cleanedContent := strings.TrimSpace(elmnt.Content)
-		if strings.HasPrefix(cleanedContent, "\u003cpitch\u003e") ||
-			strings.Contains(cleanedContent, "\u003crest /\u003e") ||
-			strings.Contains(cleanedContent, "\u003crest/\u003e") {
-			n, err := elmnt.ParseAsNote()
-			if err != nil {
-				log.Println("error parsing note, err:", err.Error(), "\n\n", elmnt.Content)
-				return err
+		if !elmnt.IsNote() {
+			continue
+		}
+
+		n, err := elmnt.ParseAsNote()
+		if err != nil {
+			log.Println("error parsing note, err:", err.Error(), "\n\n", elmnt.Content)
+			return err
+		}
+
+		if n.Unpitched != nil {
+			n.Pitch.Step, n.Pitch.Octave = n.Unpitched.DisplayStep, n.Unpitched.DisplayOctave
+		}
+
+		n.Element = i
+		notes[i] = n
+		if !slices.Contains(voices, n.GetVoice()) {
+			voices = append(voices, n.GetVoice())
+		}
+	}
+	voice := m.Layer.selectVoice(voices)
+	secondary := m.Layer != nil && m.Layer.Secondary
+
+	// the notes of the other voices and the stacked notes are not counted on the note position
+	foundDirectionType, skippedNote := 0, 0
+	graceNotes := []Note{}
+	dynamics, wedges := "", []Wedge{}
+	var harmony *Harmony
+	i := -1
+	for ei, elmnt := range m.Appendix {
+		if isLayoutElement(elmnt) {
+			continue
+		}
+		i++
+
+		if n, ok := notes[ei]; ok {
+			if n.GetVoice() != voice {
+				skippedNote++
+				continue
+			}
+
+			if n.IsChord() {
+				skippedNote++
+				if len(m.Notes) > 0 {
+					head := &m.Notes[len(m.Notes)-1]
+					head.ChordNotes = append(head.ChordNotes, n)
+				}
+				continue
+			}
+
+			if n.IsGrace() {
+				// the grace notes belong to the next main note
+				skippedNote++
+				graceNotes = append(graceNotes, n)
+				continue
 			}
 
 			n.IndexPosition = i //+ m.StartIndex
+			if secondary {
+				// the lyric only follows the first row of the part
+				n.Lyric = nil
+			}
+
+			if len(graceNotes) > 0 {
+				n.GraceNotes = graceNotes
+				graceNotes = []Note{}
+			}
+
+			if harmony != nil {
+				n.Harmony = harmony
+				harmony = nil
+			}
+
+			if dynamics != "" || len(wedges) > 0 {
+				n.Dynamics, n.Wedges = dynamics, wedges
+				dynamics, wedges = "", []Wedge{}
+			}
 
 			if len(measureText) > 0 {
 				if n.MeasureText == nil {
@@ -68,7 +251,15 @@ func (m *Measure) Build() error {
 				measureText = []MeasureText{}
 			}
 			m.Notes = append(m.Notes, n)
-		} else if strings.HasPrefix(cleanedContent, "\u003cdirection-type\u003e") {
+		} else if secondary {
+			continue
+		} else if elmnt.Name() == ElementHarmony {
+			// the chord symbol belongs to the next note
+			harmony = &Harmony{}
+			if err := elmnt.Decode(harmony); err != nil {
+				return err
+			}
+		} else if elmnt.IsDirection() {
 			d, err := elmnt.ParseAsDirection()
 			if err != nil {
 				return err
@@ -78,11 +269,37 @@ func (m *Measure) Build() error {
 			}
 			initalDirection := d.DirectionType[0]
 
+			if d.IsExpression() {
+				// the dynamics and hairpins belong to the next note
+				for _, dt := range d.DirectionType {
+					switch {
+					case dt.Dynamics != nil:
+						dynamics += dt.Dynamics.String()
+					case dt.Wedge != nil:
+						wedges = append(wedges, *dt.Wedge)
+					case dt.Metronome != nil:
+						// the tempo of the first measure is on the header
+						if !m.First {
+							measureText = append(measureText, MeasureText{Text: dt.Metronome.String()})
+						}
+					case dt.Word.Value != "":
+						measureText = append(measureText, MeasureText{
+							Text:      dt.Word.Value,
+							RelativeY: dt.Word.RelativeY,
+						})
+					}
+				}
+				continue
+			}
+
 			if initalDirection.Word.Value == "__layout=br" {
-				m.NewLineIndex[i-foundDirectionType] = true
+				m.NewLineIndex[i-foundDirectionType-skippedNote] = true
 				foundDirectionType++
-			} else if initalDirection.Word.Value == "D.C. al Fine" {
-				continue
+			} else if symbol := initalDirection.Symbol(); symbol != "" {
+				measureText = append(measureText, MeasureText{
+					Symbol:    symbol,
+					RelativeY: initalDirection.Word.RelativeY,
+				})
 			} else {
 				measureText = append(measureText, MeasureText{
 					Text:      initalDirection.Word.Value,
@@ -94,7 +311,7 @@ func (m *Measure) Build() error {
 				if m.PrefixHeader == nil {
 					m.PrefixHeader = map[int]string{}
 				}
-				m.PrefixHeader[i-foundDirectionType] = initalDirection.Rehearshal.Value
+				m.PrefixHeader[i-foundDirectionType-skippedNote] = initalDirection.Rehearshal.Value
 			}
 
 			if len(d.DirectionType) == 2 && d.DirectionType[1].Dashes != nil || (len(d.DirectionType) == 1 && d.DirectionType[0].Dashes != nil) {
@@ -120,5 +337,16 @@ func (m *Measure) Build() error {
 		m.RightMeasureText = &measureText[0]
 	}
 
+	// written after the last note, e.g. the end of the hairpin
+	if last := len(m.Notes) - 1; last >= 0 && (dynamics != "" || len(wedges) > 0) {
+		for i := range wedges {
+			wedges[i].Trailing = true
+		}
+		m.Notes[last].Dynamics += dynamics
+		m.Notes[last].Wedges = append(m.Notes[last].Wedges, wedges...)
+	}
+
+	m.Layer.applyChord(m.Notes)
+
 	return nil
 }
@@ -0,0 +1,94 @@
+package musicxml
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure_UnmarshalXML(t *testing.T) {
+	doc := `<measure number="3">
+		<attributes><divisions>2</divisions><time><beats>4</beats><beat-type>4</beat-type></time></attributes>
+		<direction placement="above"><direction-type><words>Refrein</words></direction-type></direction>
+		<harmony><root><root-step>C</root-step></root></harmony>
+		<note><grace slash="yes"/><pitch><step>D</step><octave>4</octave></pitch><voice>1</voice><type>eighth</type></note>
+		<note><pitch><step>C</step><octave>4</octave></pitch><duration>4</duration><voice>1</voice><type>half</type></note>
+		<note><unpitched><display-step>E</display-step><display-octave>4</display-octave></unpitched><duration>2</duration><voice>1</voice><type>quarter</type></note>
+		<sound tempo="80"/>
+		<backup><duration>8</duration></backup>
+		<note><rest measure="yes"/><duration>8</duration><voice>2</voice></note>
+		<barline location="right"><bar-style>light-heavy</bar-style></barline>
+		<print new-system="yes"/>
+	</measure>`
+
+	m := Measure{}
+	assert.NoError(t, xml.Unmarshal([]byte(doc), &m))
+
+	names := []string{}
+	for _, elmnt := range m.Appendix {
+		names = append(names, elmnt.Name())
+	}
+
+	assert.Equal(t, 3, m.Number)
+	assert.Equal(t, []string{
+		ElementAttributes, ElementDirection, ElementHarmony,
+		ElementNote, ElementNote, ElementNote,
+		ElementSound, ElementBackup, ElementNote,
+		ElementBarline, ElementPrint,
+	}, names)
+	assert.Equal(t, 4, m.Attribute.Time.Beats)
+	assert.Len(t, m.Barline, 1)
+	assert.Equal(t, BarLineStyle("light-heavy"), m.Barline[0].BarStyle)
+	assert.Equal(t, PrintNewSystemType(PrintNewSystemTypeYes), m.Print.NewSystem)
+
+	direction, err := m.Appendix[1].ParseAsDirection()
+	assert.NoError(t, err)
+	assert.Equal(t, "above", direction.Placement)
+
+	assert.NoError(t, m.Build())
+	assert.Len(t, m.Notes, 2)
+	assert.Equal(t, "C", m.Notes[0].Pitch.Step)
+	assert.Equal(t, []MeasureText{{Text: "Refrein"}}, m.Notes[0].MeasureText)
+	assert.Len(t, m.Notes[0].GraceNotes, 1)
+	assert.Equal(t, "yes", m.Notes[0].GraceNotes[0].Grace.Slash)
+	assert.Equal(t, "E", m.Notes[1].Pitch.Step)
+	assert.Equal(t, 4, m.Notes[1].Pitch.Octave)
+
+	second := m
+	second.Notes = nil
+	second.Layer = &VoiceLayer{Voice: "2", Secondary: true}
+	assert.NoError(t, second.Build())
+	assert.Len(t, second.Notes, 1)
+	assert.Equal(t, "yes", second.Notes[0].Rest.Measure)
+}
+
+func TestMeasure_AttributeChanges(t *testing.T) {
+	doc := `<measure number="4">
+		<attributes><divisions>2</divisions><key><fifths>2</fifths></key><clef><sign>G</sign><line>2</line></clef></attributes>
+		<note><pitch><step>D</step><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type></note>
+		<attributes><clef><sign>F</sign><line>4</line></clef></attributes>
+		<note><pitch><step>A</step><octave>3</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type></note>
+		<attributes><key><fifths>-1</fifths></key></attributes>
+		<note><pitch><step>F</step><octave>3</octave></pitch><duration>4</duration><voice>1</voice><type>half</type></note>
+	</measure>`
+
+	m := Measure{}
+	assert.NoError(t, xml.Unmarshal([]byte(doc), &m))
+
+	assert.Equal(t, 2, m.Attribute.Key.Fifth, "the later attributes does not overwrite the start of the measure")
+	assert.Equal(t, ClefSignG, m.Attribute.GetClef().Sign)
+	if assert.Len(t, m.AttributeChanges, 2) {
+		assert.Equal(t, 2, m.AttributeChanges[0].Element)
+		assert.Equal(t, ClefSignF, m.AttributeChanges[0].Attribute.GetClef().Sign)
+		assert.Equal(t, 4, m.AttributeChanges[1].Element)
+		assert.Equal(t, -1, m.AttributeChanges[1].Attribute.Key.Fifth)
+	}
+
+	assert.NoError(t, m.Build())
+	clefs := []ClefSign{}
+	for _, n := range m.Notes {
+		clefs = append(clefs, m.ClefAt(n.Element).Sign)
+	}
+	assert.Equal(t, []ClefSign{ClefSignG, ClefSignF, ClefSignF}, clefs)
+}
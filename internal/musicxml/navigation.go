@@ -0,0 +1,109 @@
+package musicxml
+
+import "strings"
+
+type NavigationType string
+
+const (
+	// the targets of the jumps
+	NavigationSegno NavigationType = "segno"
+	NavigationCoda  NavigationType = "coda"
+
+	// the jumps and the markers followed on the second pass
+	NavigationDaCapo   NavigationType = "dacapo"
+	NavigationDalSegno NavigationType = "dalsegno"
+	NavigationToCoda   NavigationType = "tocoda"
+	NavigationFine     NavigationType = "fine"
+)
+
+// Symbol returns the sign written on the direction, empty when it is not a segno or a coda
+func (dt DirectionType) Symbol() NavigationType {
+	switch {
+	case dt.Segno != nil:
+		return NavigationSegno
+	case dt.Coda != nil:
+		return NavigationCoda
+	}
+	return ""
+}
+
+// navigationFromWords reads the navigation of the written text, for the score without the sound playback
+func navigationFromWords(words string) NavigationType {
+	w := strings.ToLower(strings.TrimSpace(words))
+	switch {
+	case strings.HasPrefix(w, "d.c.") || strings.HasPrefix(w, "da capo"):
+		return NavigationDaCapo
+	case strings.HasPrefix(w, "d.s.") || strings.HasPrefix(w, "dal segno"):
+		return NavigationDalSegno
+	case strings.HasPrefix(w, "to coda"):
+		return NavigationToCoda
+	case w == "fine":
+		return NavigationFine
+	}
+	return ""
+}
+
+func (s *Sound) navigations() []NavigationType {
+	if s == nil {
+		return nil
+	}
+
+	result := []NavigationType{}
+	if s.Segno != "" {
+		result = append(result, NavigationSegno)
+	}
+	if s.Coda != "" {
+		result = append(result, NavigationCoda)
+	}
+	if s.DaCapo == BoolYes {
+		result = append(result, NavigationDaCapo)
+	}
+	if s.DalSegno != "" {
+		result = append(result, NavigationDalSegno)
+	}
+	if s.ToCoda != "" {
+		result = append(result, NavigationToCoda)
+	}
+	if s.Fine != "" {
+		result = append(result, NavigationFine)
+	}
+	return result
+}
+
+// GetNavigation returns the navigation markings of the measure: the signs, the written text and the sound playback.
+// it reads the raw children, so it can be used before the measure is built
+func (m Measure) GetNavigation() map[NavigationType]bool {
+	result := map[NavigationType]bool{}
+	for _, elmnt := range m.Appendix {
+		var sound *Sound
+		switch {
+		case elmnt.IsDirection():
+			d, err := elmnt.ParseAsDirection()
+			if err != nil {
+				continue
+			}
+
+			for _, dt := range d.DirectionType {
+				if symbol := dt.Symbol(); symbol != "" {
+					result[symbol] = true
+				}
+				if nav := navigationFromWords(dt.Word.Value); nav != "" {
+					result[nav] = true
+				}
+			}
+			sound = d.Sound
+
+		case elmnt.Name() == ElementSound:
+			sound = &Sound{}
+			if err := elmnt.Decode(sound); err != nil {
+				continue
+			}
+		}
+
+		for _, nav := range sound.navigations() {
+			result[nav] = true
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,61 @@
+package musicxml
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure_GetNavigation(t *testing.T) {
+	tests := []struct {
+		name    string
+		measure Measure
+		want    map[NavigationType]bool
+	}{
+		{
+			name: "no navigation",
+			measure: Measure{
+				Appendix: []Element{
+					NewElement(ElementDirection, `<direction-type><words>Rit.</words></direction-type>`),
+				},
+			},
+			want: map[NavigationType]bool{},
+		},
+		{
+			name: "segno and coda signs",
+			measure: Measure{
+				Appendix: []Element{
+					NewElement(ElementDirection, `<direction-type><segno/></direction-type>`),
+					NewElement(ElementDirection, `<direction-type><coda/></direction-type>`),
+				},
+			},
+			want: map[NavigationType]bool{NavigationSegno: true, NavigationCoda: true},
+		},
+		{
+			name: "written text without sound",
+			measure: Measure{
+				Appendix: []Element{
+					NewElement(ElementDirection, `<direction-type><words>D.S. al Coda</words></direction-type>`),
+					NewElement(ElementDirection, `<direction-type><words>Fine</words></direction-type>`),
+				},
+			},
+			want: map[NavigationType]bool{NavigationDalSegno: true, NavigationFine: true},
+		},
+		{
+			name: "sound inside the direction and on the measure",
+			measure: Measure{
+				Appendix: []Element{
+					NewElement(ElementDirection, `<direction-type><words>al Coda</words></direction-type><sound tocoda="coda"/>`),
+					NewElement(ElementSound, "", xml.Attr{Name: xml.Name{Local: "dacapo"}, Value: "yes"}),
+				},
+			},
+			want: map[NavigationType]bool{NavigationToCoda: true, NavigationDaCapo: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.measure.GetNavigation())
+		})
+	}
+}
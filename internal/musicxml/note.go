@@ -1,5 +1,7 @@
 package musicxml
 
+import "strings"
+
 type Note struct {
 	Pitch struct {
 		Step   string `xml:"step"`
@@ -12,11 +14,54 @@ type Note struct {
 	Accidental NoteAccidental `xml:"accidental"`
 	Dot        []*Dot         `xml:"dot"`
 	Rest       *Rest          `xml:"rest"`
+	Chord      *Chord         `xml:"chord"`
+	Grace      *Grace         `xml:"grace"`
+	Unpitched  *Unpitched     `xml:"unpitched"`
+	Voice      string         `xml:"voice"`
+	Staff      int            `xml:"staff"`
+	Duration   int            `xml:"duration"`
 
 	TimeModification *TimeModification `xml:"time-modification"`
 
 	MeasureText   []MeasureText `xml:"-"`
 	IndexPosition int           `xml:"-"`
+	// the position of the note element in the measure, it identifies the note
+	Element int `xml:"-"`
+	// the other notes stacked on this note (<chord/>)
+	ChordNotes []Note `xml:"-"`
+	// the grace notes played before this note
+	GraceNotes []Note `xml:"-"`
+	// the dynamics and the hairpins written on this note
+	Dynamics string  `xml:"-"`
+	Wedges   []Wedge `xml:"-"`
+	// the chord symbol written above this note
+	Harmony *Harmony `xml:"-"`
+}
+
+// GetVoice returns the voice of the note, the note without voice belongs to the first voice
+func (n Note) GetVoice() string {
+	if n.Voice == "" {
+		return DEFAULT_VOICE
+	}
+	return n.Voice
+}
+
+// IsChord tells whether the note is stacked on the previous note
+func (n Note) IsChord() bool {
+	return n.Chord != nil
+}
+
+// IsGrace tells whether the note is an ornament that takes no time (<grace/>)
+func (n Note) IsGrace() bool {
+	return n.Grace != nil
+}
+
+// IsHigherThan compares the pitch of two notes, regardless the accidental
+func (n Note) IsHigherThan(other Note) bool {
+	if n.Pitch.Octave != other.Pitch.Octave {
+		return n.Pitch.Octave > other.Pitch.Octave
+	}
+	return strings.Index(PITCH_STEPS, n.Pitch.Step) > strings.Index(PITCH_STEPS, other.Pitch.Step)
 }
 
 func (n Note) IsBreathMark() bool {
@@ -1 +1,227 @@
 package musicxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	ROOT_PARTWISE = "score-partwise"
+	ROOT_TIMEWISE = "score-timewise"
+
+	MXL_CONTAINER_PATH = "META-INF/container.xml"
+)
+
+var (
+	ErrUnknownRoot  = errors.New("musicxml: unknown root element")
+	ErrEmptyArchive = errors.New("musicxml: no score found in the compressed archive")
+
+	zipSignature    = []byte("PK\x03\x04")
+	scoreExtensions = []string{".musicxml", ".xml"}
+)
+
+// TimewiseMusicXML is the score-timewise document, the parts are nested inside the measures
+type TimewiseMusicXML struct {
+	XMLName  xml.Name          `xml:"score-timewise"`
+	Credit   []Credit          `xml:"credit"`
+	PartList PartList          `xml:"part-list"`
+	Work     Work              `xml:"work"`
+	Measures []TimewiseMeasure `xml:"measure"`
+}
+
+type TimewiseMeasure struct {
+	Number   string         `xml:"number,attr"`
+	Implicit Bool           `xml:"implicit,attr"`
+	Parts    []TimewisePart `xml:"part"`
+}
+
+// TimewisePart holds the same content of the measure in the score-partwise
+type TimewisePart struct {
+	ID string `xml:"id,attr"`
+	Measure
+}
+
+// UnmarshalXML decodes the part id, the children are decoded by the measure
+func (tp *TimewisePart) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "id" {
+			tp.ID = attr.Value
+		}
+	}
+
+	return tp.Measure.UnmarshalXML(d, start)
+}
+
+// MXLContainer is the META-INF/container.xml of the compressed musicxml
+type MXLContainer struct {
+	RootFiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// Parse reads the musicxml document, either the uncompressed (.musicxml / .xml) or the compressed one (.mxl).
+// the score-timewise is converted into score-partwise.
+func Parse(content []byte) (MusicXML, error) {
+	if bytes.HasPrefix(content, zipSignature) {
+		uncompressed, err := Uncompress(content)
+		if err != nil {
+			return MusicXML{}, err
+		}
+		content = uncompressed
+	}
+
+	root, err := rootElement(content)
+	if err != nil {
+		return MusicXML{}, err
+	}
+
+	switch root {
+	case ROOT_PARTWISE:
+		var music MusicXML
+		err = xml.Unmarshal(content, &music)
+		if err != nil {
+			return MusicXML{}, err
+		}
+		music.NormalizeMeasureNumbers()
+		return music, nil
+
+	case ROOT_TIMEWISE:
+		var timewise TimewiseMusicXML
+		err = xml.Unmarshal(content, &timewise)
+		if err != nil {
+			return MusicXML{}, err
+		}
+		music := timewise.ToPartwise()
+		music.NormalizeMeasureNumbers()
+		return music, nil
+	}
+
+	return MusicXML{}, ErrUnknownRoot
+}
+
+// Uncompress returns the score document of the compressed musicxml (.mxl).
+// the score is the first rootfile on the container, or the first musicxml file when the container is missing.
+func Uncompress(content []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	scorePath := ""
+	if container, ok := files[MXL_CONTAINER_PATH]; ok {
+		raw, err := readZipFile(container)
+		if err != nil {
+			return nil, err
+		}
+
+		var mc MXLContainer
+		if err := xml.Unmarshal(raw, &mc); err != nil {
+			return nil, err
+		}
+
+		if len(mc.RootFiles) > 0 {
+			scorePath = mc.RootFiles[0].FullPath
+		}
+	}
+
+	if scorePath == "" {
+		for _, f := range archive.File {
+			ext := strings.ToLower(path.Ext(f.Name))
+			if !strings.HasPrefix(f.Name, "META-INF/") && slices.Contains(scoreExtensions, ext) {
+				scorePath = f.Name
+				break
+			}
+		}
+	}
+
+	score, ok := files[scorePath]
+	if !ok {
+		return nil, ErrEmptyArchive
+	}
+
+	return readZipFile(score)
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func rootElement(content []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", ErrUnknownRoot
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// ToPartwise converts the score-timewise into score-partwise.
+// the parts are ordered by the part-list, then by their first appearance.
+func (tw TimewiseMusicXML) ToPartwise() MusicXML {
+	result := MusicXML{
+		XMLName:  xml.Name{Local: ROOT_PARTWISE},
+		Credit:   tw.Credit,
+		PartList: tw.PartList,
+		Work:     tw.Work,
+	}
+
+	partIndex := map[string]int{}
+	for _, sp := range tw.PartList.ScorePart {
+		partIndex[sp.ID] = len(result.Parts)
+		result.Parts = append(result.Parts, Part{ID: sp.ID})
+	}
+
+	for _, measure := range tw.Measures {
+		for _, part := range measure.Parts {
+			idx, ok := partIndex[part.ID]
+			if !ok {
+				idx = len(result.Parts)
+				partIndex[part.ID] = idx
+				result.Parts = append(result.Parts, Part{ID: part.ID})
+			}
+
+			m := part.Measure
+			m.RawNumber = measure.Number
+			m.Number = parseMeasureNumber(measure.Number)
+			m.Implicit = measure.Implicit == BoolYes
+			result.Parts[idx].Measures = append(result.Parts[idx].Measures, m)
+		}
+	}
+
+	// the part declared on the part-list without any measure
+	parts := []Part{}
+	for _, part := range result.Parts {
+		if len(part.Measures) > 0 {
+			parts = append(parts, part)
+		}
+	}
+	result.Parts = parts
+
+	return result
+}
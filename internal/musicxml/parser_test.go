@@ -0,0 +1,137 @@
+package musicxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	partwiseDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<score-partwise version="3.1">
+	<part-list><score-part id="P1"><part-name>Soprano</part-name></score-part></part-list>
+	<part id="P1"><measure number="1"><note><pitch><step>C</step><octave>4</octave></pitch><type>quarter</type></note></measure></part>
+</score-partwise>`
+
+	timewiseDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<score-timewise version="3.1">
+	<work><work-title>Timewise</work-title></work>
+	<part-list>
+		<score-part id="P1"><part-name>Soprano</part-name></score-part>
+		<score-part id="P2"><part-name>Alto</part-name></score-part>
+	</part-list>
+	<measure number="1">
+		<part id="P1"><note><pitch><step>E</step><octave>4</octave></pitch><type>quarter</type></note></part>
+		<part id="P2"><note><pitch><step>C</step><octave>4</octave></pitch><type>quarter</type></note></part>
+	</measure>
+	<measure number="2">
+		<part id="P1"><barline location="right"><bar-style>light-heavy</bar-style></barline></part>
+		<part id="P2"></part>
+	</measure>
+</score-timewise>`
+
+	containerDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<container><rootfiles><rootfile full-path="score/hymn.xml" media-type="application/vnd.recordare.musicxml+xml"/></rootfiles></container>`
+)
+
+func compress(t *testing.T, files map[string]string) []byte {
+	buff := &bytes.Buffer{}
+	w := zip.NewWriter(buff)
+	for name, content := range files {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	return buff.Bytes()
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       func(t *testing.T) []byte
+		wantParts     []string
+		wantMeasures  []int
+		wantWorkTitle string
+		wantBarlines  int
+		wantErr       error
+	}{
+		{
+			name:         "partwise",
+			content:      func(t *testing.T) []byte { return []byte(partwiseDoc) },
+			wantParts:    []string{"P1"},
+			wantMeasures: []int{1},
+		},
+		{
+			name:          "timewise converted to partwise",
+			content:       func(t *testing.T) []byte { return []byte(timewiseDoc) },
+			wantParts:     []string{"P1", "P2"},
+			wantMeasures:  []int{2, 2},
+			wantWorkTitle: "Timewise",
+			wantBarlines:  1,
+		},
+		{
+			name: "compressed with container",
+			content: func(t *testing.T) []byte {
+				return compress(t, map[string]string{
+					MXL_CONTAINER_PATH: containerDoc,
+					"score/hymn.xml":   timewiseDoc,
+					"score/other.xml":  partwiseDoc,
+				})
+			},
+			wantParts:     []string{"P1", "P2"},
+			wantMeasures:  []int{2, 2},
+			wantWorkTitle: "Timewise",
+		},
+		{
+			name: "compressed without container",
+			content: func(t *testing.T) []byte {
+				return compress(t, map[string]string{"hymn.musicxml": partwiseDoc})
+			},
+			wantParts:    []string{"P1"},
+			wantMeasures: []int{1},
+		},
+		{
+			name: "compressed without score",
+			content: func(t *testing.T) []byte {
+				return compress(t, map[string]string{"readme.txt": "nope"})
+			},
+			wantErr: ErrEmptyArchive,
+		},
+		{
+			name:    "unknown root",
+			content: func(t *testing.T) []byte { return []byte(`<opus></opus>`) },
+			wantErr: ErrUnknownRoot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.content(t))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWorkTitle, got.Work.Title)
+			assert.Len(t, got.Parts, len(tt.wantParts))
+			for i, part := range got.Parts {
+				assert.Equal(t, tt.wantParts[i], part.ID)
+				assert.Len(t, part.Measures, tt.wantMeasures[i])
+				for mi, measure := range part.Measures {
+					assert.Equal(t, mi+1, measure.Number)
+				}
+			}
+
+			if tt.wantBarlines > 0 {
+				last := got.MainPart().Measures[len(got.MainPart().Measures)-1]
+				assert.Len(t, last.Barline, tt.wantBarlines)
+				assert.NoError(t, last.Build())
+			}
+		})
+	}
+}
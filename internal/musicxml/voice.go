@@ -0,0 +1,134 @@
+package musicxml
+
+const (
+	DEFAULT_VOICE = "1"
+
+	// ordered from the lowest
+	PITCH_STEPS = "CDEFGAB"
+)
+
+// VoiceLayer is a row of numbered notes taken from a part.
+// a part with more than one voice or with chords is rendered as several rows, stacked from the upper voice.
+type VoiceLayer struct {
+	Voice string
+	// the position of the note in the chord, 0 is the highest
+	Chord int
+	// not the first row of the part, only the numbered notes are rendered
+	Secondary bool
+}
+
+// SplitVoices splits the part into the rows of voices and chords, from the upper voice to the lower one.
+// the part with a single voice and without chord is returned as it is.
+func (p Part) SplitVoices() []Part {
+	voices := []string{}
+	chordSize := map[string]int{}
+
+	for _, measure := range p.Measures {
+		current := map[string]int{}
+		for _, elmnt := range measure.Appendix {
+			if !elmnt.IsNote() {
+				continue
+			}
+
+			note, err := elmnt.ParseAsNote()
+			if err != nil {
+				continue
+			}
+
+			voice := note.GetVoice()
+			if _, ok := chordSize[voice]; !ok {
+				voices = append(voices, voice)
+				chordSize[voice] = 1
+			}
+
+			if note.IsChord() {
+				current[voice]++
+			} else {
+				current[voice] = 1
+			}
+
+			if current[voice] > chordSize[voice] {
+				chordSize[voice] = current[voice]
+			}
+		}
+	}
+
+	if len(voices) < 2 && (len(voices) == 0 || chordSize[voices[0]] < 2) {
+		return []Part{p}
+	}
+
+	result := []Part{}
+	for _, voice := range voices {
+		for chord := 0; chord < chordSize[voice]; chord++ {
+			layer := &VoiceLayer{
+				Voice:     voice,
+				Chord:     chord,
+				Secondary: len(result) > 0,
+			}
+
+			row := p
+			row.Layer = layer
+			row.Measures = make([]Measure, len(p.Measures))
+			for i, measure := range p.Measures {
+				measure.Layer = layer
+				row.Measures[i] = measure
+			}
+
+			result = append(result, row)
+		}
+	}
+
+	return result
+}
+
+// selectVoice picks the voice that should be built on the measure.
+// when the voice is not written on the measure, the voices are in unison and the first voice is taken
+func (vl *VoiceLayer) selectVoice(voices []string) string {
+	if len(voices) == 0 {
+		return DEFAULT_VOICE
+	}
+
+	if vl != nil {
+		for _, v := range voices {
+			if v == vl.Voice {
+				return v
+			}
+		}
+	}
+
+	return voices[0]
+}
+
+// applyChord replaces the pitch of the notes with the pitch on the chord position of the layer, the note takes the element of that pitch
+func (vl *VoiceLayer) applyChord(notes []Note) {
+	for i, note := range notes {
+		if len(note.ChordNotes) == 0 {
+			continue
+		}
+
+		tones := append([]Note{note}, note.ChordNotes...)
+		highest := make([]Note, 0, len(tones))
+		for _, tone := range tones {
+			pos := len(highest)
+			for j, h := range highest {
+				if tone.IsHigherThan(h) {
+					pos = j
+					break
+				}
+			}
+			highest = append(highest[:pos], append([]Note{tone}, highest[pos:]...)...)
+		}
+
+		chord := 0
+		if vl != nil {
+			chord = vl.Chord
+		}
+		if chord >= len(highest) {
+			chord = len(highest) - 1
+		}
+
+		notes[i].Pitch = highest[chord].Pitch
+		notes[i].Accidental = highest[chord].Accidental
+		notes[i].Element = highest[chord].Element
+	}
+}
@@ -0,0 +1,105 @@
+package musicxml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	upperNote = `<pitch><step>E</step><octave>5</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type>
+	<lyric number="1"><syllabic>single</syllabic><text>do</text></lyric>`
+	chordNote = `<chord/><pitch><step>G</step><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type>`
+	lowerNote = `<pitch><step>C</step><octave>4</octave></pitch><duration>2</duration><voice>2</voice><type>quarter</type>`
+	backup    = `<duration>2</duration>`
+)
+
+func TestPart_SplitVoices(t *testing.T) {
+	tests := []struct {
+		name       string
+		appendix   []Element
+		wantLayers []*VoiceLayer
+	}{
+		{
+			name:       "single voice",
+			appendix:   []Element{NewElement(ElementNote, upperNote)},
+			wantLayers: []*VoiceLayer{nil},
+		},
+		{
+			name:     "chord",
+			appendix: []Element{NewElement(ElementNote, upperNote), NewElement(ElementNote, chordNote)},
+			wantLayers: []*VoiceLayer{
+				{Voice: "1", Chord: 0},
+				{Voice: "1", Chord: 1, Secondary: true},
+			},
+		},
+		{
+			name:     "chord and second voice",
+			appendix: []Element{NewElement(ElementNote, upperNote), NewElement(ElementNote, chordNote), NewElement(ElementBackup, backup), NewElement(ElementNote, lowerNote)},
+			wantLayers: []*VoiceLayer{
+				{Voice: "1", Chord: 0},
+				{Voice: "1", Chord: 1, Secondary: true},
+				{Voice: "2", Chord: 0, Secondary: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			part := Part{ID: "P1", Measures: []Measure{{Number: 1, Appendix: tt.appendix}}}
+
+			got := part.SplitVoices()
+			assert.Len(t, got, len(tt.wantLayers))
+			for i, row := range got {
+				assert.Equal(t, tt.wantLayers[i], row.Layer)
+				assert.Equal(t, tt.wantLayers[i], row.Measures[0].Layer)
+			}
+		})
+	}
+}
+
+func TestMeasure_Build_Voices(t *testing.T) {
+	appendix := []Element{NewElement(ElementNote, upperNote), NewElement(ElementNote, chordNote), NewElement(ElementBackup, backup), NewElement(ElementNote, lowerNote)}
+
+	type want struct {
+		step     string
+		octave   int
+		hasLyric bool
+	}
+	tests := []struct {
+		name  string
+		layer *VoiceLayer
+		want  want
+	}{
+		{
+			name:  "first voice by default",
+			layer: nil,
+			want:  want{step: "E", octave: 5, hasLyric: true},
+		},
+		{
+			name:  "lower note of the chord",
+			layer: &VoiceLayer{Voice: "1", Chord: 1, Secondary: true},
+			want:  want{step: "G", octave: 4},
+		},
+		{
+			name:  "second voice",
+			layer: &VoiceLayer{Voice: "2", Secondary: true},
+			want:  want{step: "C", octave: 4},
+		},
+		{
+			name:  "voice not written on the measure",
+			layer: &VoiceLayer{Voice: "3", Secondary: true},
+			want:  want{step: "E", octave: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Measure{Appendix: appendix, Layer: tt.layer}
+			assert.NoError(t, m.Build())
+
+			assert.Len(t, m.Notes, 1)
+			assert.Equal(t, tt.want.step, m.Notes[0].Pitch.Step)
+			assert.Equal(t, tt.want.octave, m.Notes[0].Pitch.Octave)
+			assert.Equal(t, tt.want.hasLyric, len(m.Notes[0].Lyric) > 0)
+		})
+	}
+}
@@ -11,3 +11,30 @@ const (
 	REHERSHAL_TEXT_X_OFFSET = 1
 	REHERSHAL_TEXT_Y_OFFSET = 25
 )
+
+const (
+	GRACE_NOTE_Y_OFFSET = 7
+	GRACE_NOTE_FONT     = `style="font-size:9.6px"`
+)
+
+const (
+	CHORD_SYMBOL_Y_OFFSET = 16
+	CHORD_SYMBOL_STYLE    = `style="font-size:9.6px;font-weight:bold"`
+)
+
+const (
+	KEY_CHANGE_STYLE = `style="font-size:9.6px;font-style:italic"`
+)
+
+// the texts above the note, the chord symbol and the key change, are placed above the highest mark of the note
+const (
+	// the space between the highest mark and the baseline of the text
+	TEXT_ABOVE_MARGIN = 3
+	// the key change goes above the chord symbol of the same note
+	TEXT_ABOVE_LINE_HEIGHT = 12
+
+	// the top of the marks, measured up from the baseline of the note
+	OCTAVE_DOT_TOP = 16
+	FERMATA_TOP    = 20
+	BEAM_TOP       = 22
+)
@@ -0,0 +1,37 @@
+package numbered
+
+import (
+	"fmt"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+// renderGraceNotes renders the grace notes as small superscript numbers before the main note
+func renderGraceNotes(canv canvas.Canvas, note *entity.NoteRenderer, y int) {
+	if len(note.GraceNotes) == 0 {
+		return
+	}
+
+	canv.Group(`class="grace-notes"`)
+	xPos := note.PositionX - len(note.GraceNotes)*constant.GRACE_NOTE_WIDTH
+	yPos := y - GRACE_NOTE_Y_OFFSET
+	for _, grace := range note.GraceNotes {
+		canv.Text(xPos, yPos, fmt.Sprintf("%d", grace.Note), GRACE_NOTE_FONT)
+
+		switch grace.Octave {
+		case 1:
+			canv.Circle(xPos+3, yPos-9, 1, "fill:#000000;fill-opacity:1;stroke:#000000;stroke-width:0.5")
+		case -1:
+			canv.Circle(xPos+3, yPos+3, 1, "fill:#000000;fill-opacity:1;stroke:#000000;stroke-width:0.5")
+		}
+
+		if grace.Strikethrough {
+			canv.Line(xPos+6, yPos-9, xPos, yPos+2, "fill:none;stroke:#000000;stroke-linecap:round;stroke-width:0.9")
+		}
+
+		xPos += constant.GRACE_NOTE_WIDTH
+	}
+	canv.Gend()
+}
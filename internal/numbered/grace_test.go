@@ -0,0 +1,57 @@
+package numbered
+
+import (
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+func Test_renderGraceNotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name string
+		canv func(*gomock.Controller) *canvas.MockCanvas
+		note *entity.NoteRenderer
+		y    int
+	}{
+		{
+			name: "no grace notes",
+			canv: func(c *gomock.Controller) *canvas.MockCanvas { return canvas.NewMockCanvas(c) },
+			note: &entity.NoteRenderer{PositionX: 100, Note: 1},
+			y:    100,
+		},
+		{
+			name: "two grace notes with octave and strikethrough",
+			canv: func(c *gomock.Controller) *canvas.MockCanvas {
+				canvMock := canvas.NewMockCanvas(c)
+				gomock.InOrder(
+					canvMock.EXPECT().Group(`class="grace-notes"`),
+					canvMock.EXPECT().Text(84, 93, "2", GRACE_NOTE_FONT),
+					canvMock.EXPECT().Circle(87, 84, 1, "fill:#000000;fill-opacity:1;stroke:#000000;stroke-width:0.5"),
+					canvMock.EXPECT().Text(92, 93, "4", GRACE_NOTE_FONT),
+					canvMock.EXPECT().Line(98, 84, 92, 95, "fill:none;stroke:#000000;stroke-linecap:round;stroke-width:0.9"),
+					canvMock.EXPECT().Gend(),
+				)
+				return canvMock
+			},
+			note: &entity.NoteRenderer{
+				PositionX: 100,
+				Note:      3,
+				GraceNotes: []entity.GraceNote{
+					{Note: 2, Octave: 1, Slash: true},
+					{Note: 4, Strikethrough: true},
+				},
+			},
+			y: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderGraceNotes(tt.canv(ctrl), tt.note, tt.y)
+		})
+	}
+}
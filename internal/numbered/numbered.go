@@ -196,8 +196,21 @@ func (ni *numberedInteractor) RenderNote(ctx context.Context, canv canvas.Canvas
 				canv.Circle(n.PositionX+REHERSHAL_CIRCLE_X_OFFSET, n.PositionY-REHERSHAL_CIRCLE_Y_OFFSET, REHERSHAL_CIRCLE_RADIUS, `stroke="black"`, `fill="none"`, `stroke-width="1.3"`)
 				canv.Text(n.PositionX+REHERSHAL_TEXT_X_OFFSET, n.PositionY-REHERSHAL_TEXT_Y_OFFSET, n.LeadingHeader, `font-weight="600"`, `style="font-size:9.6px"`)
 			}
+			offset := textAboveOffset(n)
+			if n.ChordSymbol != "" {
+				canv.Text(n.PositionX, y-offset, n.ChordSymbol, CHORD_SYMBOL_STYLE)
+				offset += TEXT_ABOVE_LINE_HEIGHT
+			}
+			if n.KeyChange != "" {
+				canv.Text(n.PositionX, y-offset, n.KeyChange, KEY_CHANGE_STYLE)
+			}
+			renderGraceNotes(canv, n, y)
 			noteStr := fmt.Sprintf("%d", n.Note)
-			canv.Text(n.PositionX, y, noteStr)
+			if n.NoteID != "" {
+				canv.Text(n.PositionX, y, noteStr, fmt.Sprintf(`id="%s"`, n.NoteID))
+			} else {
+				canv.Text(n.PositionX, y, noteStr)
+			}
 
 			coordinate := entity.Coordinate{X: float64(n.PositionX), Y: float64(y)}
 			ni.RenderStrikethrough(ctx, canv, n.Strikethrough, coordinate)
@@ -209,6 +222,32 @@ func (ni *numberedInteractor) RenderNote(ctx context.Context, canv canvas.Canvas
 
 }
 
+// textAboveOffset returns the offset of the first text above the note,
+// clear of the upper octave dot, the beams, the fermata and the rehearsal mark
+func textAboveOffset(n *entity.NoteRenderer) int {
+	top := 0
+	if n.Octave > 0 {
+		top = OCTAVE_DOT_TOP
+	}
+	for number := range n.Beam {
+		// the first beam is the highest one, see rhythm.RenderBeam
+		if beamTop := BEAM_TOP - number*3; beamTop > top {
+			top = beamTop
+		}
+	}
+	if n.Fermata != nil && FERMATA_TOP > top {
+		top = FERMATA_TOP
+	}
+	if len(n.LeadingHeader) == 1 && unicode.IsNumber(rune(n.LeadingHeader[0])) {
+		top = REHERSHAL_CIRCLE_Y_OFFSET + REHERSHAL_CIRCLE_RADIUS
+	}
+
+	if offset := top + TEXT_ABOVE_MARGIN; top > 0 && offset > CHORD_SYMBOL_Y_OFFSET {
+		return offset
+	}
+	return CHORD_SYMBOL_Y_OFFSET
+}
+
 func New(l lyric.Lyric, b barline.Barline) Numbered {
 	return &numberedInteractor{
 		Barline: b,
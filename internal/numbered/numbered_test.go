@@ -361,6 +361,118 @@ func Test_numberedInteractor_RenderNote(t *testing.T) {
 		y                int
 		rightAlignOffset int
 	}{
+		{
+			name: "with chord symbol",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Text(55, 84, "D/F#", []string{CHORD_SYMBOL_STYLE})
+				canv.EXPECT().Text(55, 100, "5")
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX:   55,
+					Note:        5,
+					ChordSymbol: "D/F#",
+				},
+			},
+			y: 100,
+		},
+		{
+			name: "with the key change",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Text(55, 84, "do = f", []string{KEY_CHANGE_STYLE})
+				canv.EXPECT().Text(55, 100, "1")
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX: 55,
+					Note:      1,
+					KeyChange: "do = f",
+				},
+			},
+			y: 100,
+		},
+		{
+			name: "with the key change and the chord symbol",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Text(55, 84, "F", []string{CHORD_SYMBOL_STYLE})
+				canv.EXPECT().Text(55, 72, "do = f", []string{KEY_CHANGE_STYLE})
+				canv.EXPECT().Text(55, 100, "1")
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX:   55,
+					Note:        1,
+					ChordSymbol: "F",
+					KeyChange:   "do = f",
+				},
+			},
+			y: 100,
+		},
+		{
+			name: "with the chord symbol above the upper octave and the beam",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Text(55, 78, "G", []string{CHORD_SYMBOL_STYLE})
+				canv.EXPECT().Text(55, 100, "2")
+				canv.EXPECT().Circle(60, 85, 1, []string{"fill:#000000;fill-opacity:1;stroke:#000000;stroke-width:0.75"})
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX:   55,
+					Note:        2,
+					Octave:      1,
+					Beam:        map[int]entity.Beam{1: {Number: 1, Type: musicxml.NoteBeamTypeBegin}},
+					ChordSymbol: "G",
+				},
+			},
+			y: 100,
+		},
+		{
+			name: "with the key change above the rehearsal mark",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Circle(59, 72, 6, []string{`stroke="black"`, `fill="none"`, `stroke-width="1.3"`})
+				canv.EXPECT().Text(56, 75, "2", []string{`font-weight="600"`, `style="font-size:9.6px"`})
+				canv.EXPECT().Text(55, 63, "F", []string{CHORD_SYMBOL_STYLE})
+				canv.EXPECT().Text(55, 51, "do = f", []string{KEY_CHANGE_STYLE})
+				canv.EXPECT().Text(55, 100, "1")
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX:     55,
+					PositionY:     100,
+					Note:          1,
+					LeadingHeader: "2",
+					ChordSymbol:   "F",
+					KeyChange:     "do = f",
+				},
+			},
+			y: 100,
+		},
+		{
+			name: "with the note id",
+			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
+				canv := canvas.NewMockCanvasTestify(t)
+				canv.EXPECT().Text(55, 100, "3", []string{`id="note-1-P1-2-4"`})
+				return canv
+			},
+			measure: []*entity.NoteRenderer{
+				{
+					PositionX: 55,
+					Note:      3,
+					NoteID:    "note-1-P1-2-4",
+				},
+			},
+			y: 100,
+		},
 		{
 			name: "everything went fine",
 			canv: func(c *gomock.Controller) *canvas.MockCanvasTestify {
@@ -0,0 +1,291 @@
+package plaintext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/breathpause"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/utils"
+)
+
+// Line formats the line of the staff as the monospace numbered notation.
+// the notes are on their own row, the marks (chord, fermata, ending, measure text) above them,
+// the beams are underscores beneath the notes and each verse of the lyric is on its own row below the beams.
+// the column follows the position on the svg, so the rows of the parts in the same system stay aligned
+func Line(measures [][]*entity.NoteRenderer) string {
+	notes := []*entity.NoteRenderer{}
+	for _, measure := range measures {
+		notes = append(notes, measure...)
+	}
+
+	above, main := &row{}, &row{}
+	beams := map[int]*row{}
+	lyrics := map[int]*row{}
+
+	cols := make([]int, len(notes))
+	tokens := make([]string, len(notes))
+	cursor := 0
+	for i, n := range notes {
+		tokens[i] = Note(n)
+		verses := lyricTexts(n)
+		mark := marks(n)
+		if tokens[i] == "" && len(verses) == 0 && mark == "" {
+			cols[i] = cursor
+			continue
+		}
+
+		width := utf8.RuneCountInString(tokens[i])
+		for _, text := range verses {
+			if w := utf8.RuneCountInString(text); w > width {
+				width = w
+			}
+		}
+
+		col := (n.PositionX - constant.LAYOUT_INDENT_LENGTH) / constant.AVERAGE_CHARACTER_WIDTH
+		if col < cursor {
+			col = cursor
+		}
+		cols[i] = col
+		if width > 0 {
+			cursor = col + width + 1
+		}
+
+		main.put(col, tokens[i])
+		if mark != "" {
+			above.put(max(col, above.next()), mark)
+		}
+		for verse, text := range verses {
+			if _, ok := lyrics[verse]; !ok {
+				lyrics[verse] = &row{}
+			}
+			lyrics[verse].put(col, text)
+		}
+	}
+
+	for i, n := range notes {
+		for level, beam := range n.Beam {
+			if beam.Type == "" || tokens[i] == "" {
+				continue
+			}
+			if _, ok := beams[level]; !ok {
+				beams[level] = &row{}
+			}
+
+			end := cols[i] + utf8.RuneCountInString(tokens[i])
+			// the beam continues to the next note
+			if (beam.Type == musicxml.NoteBeamTypeBegin || beam.Type == musicxml.NoteBeamTypeContinue) && i+1 < len(notes) {
+				if next, ok := notes[i+1].Beam[level]; ok && next.Type != "" {
+					end = cols[i+1]
+				}
+			}
+			beams[level].put(cols[i], strings.Repeat("_", end-cols[i]))
+		}
+	}
+
+	result := []string{}
+	if above.String() != "" {
+		result = append(result, above.String())
+	}
+	result = append(result, main.String())
+	for _, level := range utils.GetMapSortedKeys(beams) {
+		result = append(result, beams[level].String())
+	}
+	for _, verse := range utils.GetMapSortedKeys(lyrics) {
+		result = append(result, lyrics[verse].String())
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// Dedent removes the indentation shared by all of the non empty lines
+func Dedent(text string) string {
+	lines := strings.Split(text, "\n")
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent <= 0 {
+		return text
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Verses formats the verses of the metadata printed below the score, one line of the verse per line
+func Verses(metadata *entity.HymnMetaData) string {
+	if metadata == nil || metadata.HymnMetadata == nil {
+		return ""
+	}
+
+	result := []string{}
+	for _, i := range utils.GetMapSortedKeys(metadata.ParsedVerse) {
+		prefix := fmt.Sprintf("%d. ", i)
+		indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+
+		for iLine, line := range metadata.ParsedVerse[i] {
+			words := []string{}
+			for _, word := range line {
+				if word.ScoreOnly {
+					continue
+				}
+				words = append(words, word.Word)
+			}
+
+			if iLine == 0 {
+				result = append(result, prefix+strings.Join(words, " "))
+				continue
+			}
+			result = append(result, indent+strings.Join(words, " "))
+		}
+		result = append(result, "")
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// Note formats the note as the digit, followed by the slash when it is altered and the octave marks.
+// the grace notes are in the braces before the note
+func Note(n *entity.NoteRenderer) string {
+	switch {
+	case n.IsDotted:
+		return "."
+	case breathpause.IsBreathMark(n):
+		return ","
+	case n.Barline != nil:
+		return Barline(*n.Barline)
+	}
+
+	result := ""
+	if len(n.GraceNotes) > 0 {
+		graces := []string{}
+		for _, grace := range n.GraceNotes {
+			graces = append(graces, digit(grace.Note, grace.Octave, grace.Strikethrough))
+		}
+		result = "{" + strings.Join(graces, " ") + "}"
+	}
+
+	return result + digit(n.Note, n.Octave, n.Strikethrough)
+}
+
+// Barline formats the barline the same way as the abc notation, the barline without the style is empty
+func Barline(b musicxml.Barline) string {
+	if b.Repeat != nil {
+		if b.Repeat.Direction == musicxml.BarLineRepeatDirectionForward {
+			return "|:"
+		}
+		return ":|"
+	}
+
+	switch b.BarStyle {
+	case musicxml.BarLineStyleRegular:
+		return "|"
+	case musicxml.BarLineStyleLightLight, musicxml.BarLineStyleHeavyHeavy:
+		return "||"
+	case musicxml.BarLineStyleLightHeavy:
+		return "|]"
+	case musicxml.BarLineStyleHeavyLight:
+		return "[|"
+	}
+	// e.g. the left barline which only starts the ending
+	return ""
+}
+
+func digit(note, octave int, strikethrough bool) string {
+	result := strconv.Itoa(note)
+	if strikethrough {
+		result += "/"
+	}
+	if octave > 0 {
+		result += strings.Repeat("'", octave)
+	} else if octave < 0 {
+		result += strings.Repeat(",", -octave)
+	}
+	return result
+}
+
+// marks is the text above the note
+func marks(n *entity.NoteRenderer) string {
+	result := []string{}
+	if n.Barline != nil && n.Barline.Ending != nil && n.Barline.Ending.Type == musicxml.BarlineEndingTypeStart {
+		result = append(result, n.Barline.Ending.Number+".")
+	}
+	for _, text := range n.MeasureText {
+		result = append(result, text.Text)
+	}
+	if n.KeyChange != "" {
+		result = append(result, n.KeyChange)
+	}
+	if n.ChordSymbol != "" {
+		result = append(result, n.ChordSymbol)
+	}
+	if n.Fermata != nil {
+		result = append(result, "^")
+	}
+	return strings.Join(result, " ")
+}
+
+// lyricTexts returns the syllable of each lyric row, followed by the hyphen when the word continues
+func lyricTexts(n *entity.NoteRenderer) map[int]string {
+	result := map[int]string{}
+	for i, l := range n.Lyric {
+		text := entity.LyricVal(l.Text).String()
+		if text == "" {
+			continue
+		}
+		if l.Syllabic == musicxml.LyricSyllabicTypeBegin || l.Syllabic == musicxml.LyricSyllabicTypeMiddle {
+			text += "-"
+		}
+		result[i] = text
+	}
+	return result
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// row is the monospace line, the text is put on the column
+type row []rune
+
+func (r *row) put(col int, text string) {
+	for len(*r) < col {
+		*r = append(*r, ' ')
+	}
+	for i, c := range []rune(text) {
+		if col+i < len(*r) {
+			(*r)[col+i] = c
+			continue
+		}
+		*r = append(*r, c)
+	}
+}
+
+// next is the column after the last text, with a space
+func (r *row) next() int {
+	if len(*r) == 0 {
+		return 0
+	}
+	return len(*r) + 1
+}
+
+func (r *row) String() string {
+	return strings.TrimRight(string(*r), " ")
+}
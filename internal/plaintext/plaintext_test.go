@@ -0,0 +1,274 @@
+package plaintext
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		measures [][]*entity.NoteRenderer
+		want     string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name: "notes, beam, lyric and marks",
+			measures: [][]*entity.NoteRenderer{
+				{
+					&entity.NoteRenderer{
+						Note:      1,
+						PositionX: 50,
+						Beam: map[int]entity.Beam{
+							1: {Number: 1, Type: musicxml.NoteBeamTypeBegin},
+						},
+						Lyric: []entity.Lyric{
+							{Text: []entity.Text{{Value: "Ha"}}, Syllabic: musicxml.LyricSyllabicTypeBegin},
+						},
+					},
+					&entity.NoteRenderer{
+						Note:      2,
+						PositionX: 66,
+						Beam: map[int]entity.Beam{
+							1: {Number: 1, Type: musicxml.NoteBeamTypeEnd},
+						},
+						Lyric: []entity.Lyric{
+							{Text: []entity.Text{{Value: "le"}}, Syllabic: musicxml.LyricSyllabicTypeEnd},
+						},
+					},
+					&entity.NoteRenderer{
+						PositionX: 82,
+						Barline:   &musicxml.Barline{BarStyle: musicxml.BarLineStyleRegular},
+					},
+				},
+				{
+					&entity.NoteRenderer{
+						Note:      5,
+						Octave:    1,
+						PositionX: 98,
+						Fermata:   &musicxml.Femata{},
+					},
+				},
+			},
+			want: "" +
+				"         ^\n" +
+				"1   2  | 5'\n" +
+				"_____\n" +
+				"Ha- le",
+		},
+		{
+			name: "the second lyric row",
+			measures: [][]*entity.NoteRenderer{
+				{
+					&entity.NoteRenderer{
+						Note:      3,
+						PositionX: 50,
+						Lyric: []entity.Lyric{
+							{Text: []entity.Text{{Value: "a"}}, Verse: 1},
+							{Text: []entity.Text{{Value: "b"}}, Verse: 1},
+						},
+					},
+				},
+			},
+			want: "3\na\nb",
+		},
+		{
+			name: "the key change",
+			measures: [][]*entity.NoteRenderer{
+				{
+					&entity.NoteRenderer{
+						Note:        1,
+						PositionX:   50,
+						ChordSymbol: "F",
+						KeyChange:   "do = f",
+					},
+				},
+			},
+			want: "do = f F\n1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Line(tt.measures))
+		})
+	}
+}
+
+func TestNote(t *testing.T) {
+	breath := entity.ArticulationTypes("comma")
+	tests := []struct {
+		name string
+		n    *entity.NoteRenderer
+		want string
+	}{
+		{
+			name: "plain note",
+			n:    &entity.NoteRenderer{Note: 5},
+			want: "5",
+		},
+		{
+			name: "altered note with the lower octave",
+			n:    &entity.NoteRenderer{Note: 4, Strikethrough: true, Octave: -2},
+			want: "4/,,",
+		},
+		{
+			name: "rest",
+			n:    &entity.NoteRenderer{Note: 0, IsRest: true},
+			want: "0",
+		},
+		{
+			name: "dotted",
+			n:    &entity.NoteRenderer{IsDotted: true},
+			want: ".",
+		},
+		{
+			name: "breath mark",
+			n:    &entity.NoteRenderer{Articulation: &entity.Articulation{BreathMark: &breath}},
+			want: ",",
+		},
+		{
+			name: "barline",
+			n:    &entity.NoteRenderer{Barline: &musicxml.Barline{BarStyle: musicxml.BarLineStyleLightHeavy}},
+			want: "|]",
+		},
+		{
+			name: "grace notes",
+			n: &entity.NoteRenderer{
+				Note: 1,
+				GraceNotes: []entity.GraceNote{
+					{Note: 2, Octave: 1},
+					{Note: 7},
+				},
+			},
+			want: "{2' 7}1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Note(tt.n))
+		})
+	}
+}
+
+func TestBarline(t *testing.T) {
+	tests := []struct {
+		name string
+		b    musicxml.Barline
+		want string
+	}{
+		{
+			name: "regular",
+			b:    musicxml.Barline{BarStyle: musicxml.BarLineStyleRegular},
+			want: "|",
+		},
+		{
+			name: "double",
+			b:    musicxml.Barline{BarStyle: musicxml.BarLineStyleLightLight},
+			want: "||",
+		},
+		{
+			name: "start",
+			b:    musicxml.Barline{BarStyle: musicxml.BarLineStyleHeavyLight},
+			want: "[|",
+		},
+		{
+			name: "repeat forward",
+			b: musicxml.Barline{
+				BarStyle: musicxml.BarLineStyleHeavyLight,
+				Repeat:   &musicxml.BarLineRepeat{Direction: musicxml.BarLineRepeatDirectionForward},
+			},
+			want: "|:",
+		},
+		{
+			name: "repeat backward",
+			b: musicxml.Barline{
+				BarStyle: musicxml.BarLineStyleLightHeavy,
+				Repeat:   &musicxml.BarLineRepeat{Direction: musicxml.BarLineRepeatDirectionBackward},
+			},
+			want: ":|",
+		},
+		{
+			name: "none",
+			b:    musicxml.Barline{BarStyle: musicxml.BarLineStyleNone},
+			want: "",
+		},
+		{
+			name: "ending only",
+			b: musicxml.Barline{
+				Location: musicxml.BarlineLocationLeft,
+				Ending:   &musicxml.BarlineEnding{Number: "2", Type: musicxml.BarlineEndingTypeStart},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Barline(tt.b))
+		})
+	}
+}
+
+func TestDedent(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "no indentation",
+			text: "1 2\n3",
+			want: "1 2\n3",
+		},
+		{
+			name: "shared indentation",
+			text: "    1 2\n\n      3\n",
+			want: "1 2\n\n  3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Dedent(tt.text))
+		})
+	}
+}
+
+func TestVerses(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *entity.HymnMetaData
+		want     string
+	}{
+		{
+			name: "no metadata",
+			want: "",
+		},
+		{
+			name: "verses",
+			metadata: &entity.HymnMetaData{
+				HymnMetadata: &repository.HymnMetadata{},
+				ParsedVerse: map[int][][]entity.LyricWordVerse{
+					2: {
+						{{Word: "Puji"}, {Word: "Tuhan"}},
+						{{Word: "-", ScoreOnly: true}, {Word: "Haleluya"}},
+					},
+					3: {
+						{{Word: "Amin"}},
+					},
+				},
+			},
+			want: "2. Puji Tuhan\n   Haleluya\n\n3. Amin\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Verses(tt.metadata))
+		})
+	}
+}
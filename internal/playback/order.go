@@ -0,0 +1,172 @@
+package playback
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+)
+
+// Order returns the index of the measures in the playing order.
+// it follows the same rules as usecase.ProcessRepeats: the backward repeat goes back to the forward repeat (or the beginning),
+// the endings are played on their own pass and the D.C. / D.S. goes back once, to the Fine or the To Coda.
+// no repeat is taken after the D.C. / D.S., only the last ending is played
+func Order(measures []musicxml.Measure) []int {
+	endings := endingNumbers(measures)
+	navigations := make([]map[musicxml.NavigationType]bool, len(measures))
+	for i, measure := range measures {
+		navigations[i] = measure.GetNavigation()
+	}
+
+	result := []int{}
+	// guard from the malformed repeats
+	limit := len(measures) * 8
+
+	start, pass, lastRepeat, segno := 0, 1, -1, 0
+	jumped := false
+	for i := 0; i < len(measures) && len(result) < limit; i++ {
+		// leaving the repeat, the next one starts here
+		if pass > 1 && i > lastRepeat && len(endings[i]) == 0 {
+			start, pass = i, 1
+		}
+		if hasRepeat(measures[i], musicxml.BarLineRepeatDirectionForward) && i != start {
+			start, pass = i, 1
+		}
+		if navigations[i][musicxml.NavigationSegno] && !jumped {
+			segno = i
+		}
+
+		if len(endings[i]) > 0 {
+			current := pass
+			if jumped {
+				current = lastEnding(endings, i)
+			}
+			if !containsEnding(endings[i], current) {
+				continue
+			}
+		}
+
+		result = append(result, i)
+
+		if !jumped {
+			if hasRepeat(measures[i], musicxml.BarLineRepeatDirectionBackward) && pass < lastEnding(endings, i) {
+				pass++
+				lastRepeat = i
+				i = start - 1
+				continue
+			}
+
+			target := -1
+			switch {
+			case navigations[i][musicxml.NavigationDalSegno]:
+				target = segno
+			case navigations[i][musicxml.NavigationDaCapo]:
+				target = 0
+			}
+			if target >= 0 {
+				jumped = true
+				i = target - 1
+			}
+			continue
+		}
+
+		if navigations[i][musicxml.NavigationFine] {
+			break
+		}
+		if navigations[i][musicxml.NavigationToCoda] {
+			for j := i + 1; j < len(measures); j++ {
+				if navigations[j][musicxml.NavigationCoda] {
+					i = j - 1
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func hasRepeat(measure musicxml.Measure, direction musicxml.BarLineRepeatDirection) bool {
+	for _, b := range measure.Barline {
+		if b.Repeat != nil && b.Repeat.Direction == direction {
+			return true
+		}
+	}
+	return false
+}
+
+// endingNumbers returns the numbers of the ending of every measure, the ending lasts from its start until its stop
+func endingNumbers(measures []musicxml.Measure) [][]int {
+	result := make([][]int, len(measures))
+
+	var current []int
+	for i, measure := range measures {
+		stop := false
+		for _, b := range measure.Barline {
+			if b.Ending == nil {
+				continue
+			}
+			switch b.Ending.Type {
+			case musicxml.BarlineEndingTypeStart:
+				current = parseEndingNumber(b.Ending.Number)
+			case musicxml.BarlineEndingTypeStop, musicxml.BarlineEndingTypeDiscontinue:
+				if current == nil {
+					current = parseEndingNumber(b.Ending.Number)
+				}
+				stop = true
+			}
+		}
+
+		result[i] = current
+		if stop {
+			current = nil
+		}
+	}
+	return result
+}
+
+// parseEndingNumber parses the number of the ending, e.g. "1, 2"
+func parseEndingNumber(raw string) []int {
+	result := []int{}
+	for _, v := range strings.Split(raw, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func containsEnding(numbers []int, pass int) bool {
+	for _, n := range numbers {
+		if n == pass {
+			return true
+		}
+	}
+	return false
+}
+
+// lastEnding returns the highest number of the endings around the measure, it is the number of the passes of the repeat.
+// the repeat is played twice at least
+func lastEnding(endings [][]int, i int) int {
+	result := 2
+	if len(endings[i]) == 0 {
+		return result
+	}
+
+	from, to := i, i
+	for from > 0 && len(endings[from-1]) > 0 {
+		from--
+	}
+	for to < len(endings)-1 && len(endings[to+1]) > 0 {
+		to++
+	}
+
+	for _, numbers := range endings[from : to+1] {
+		for _, n := range numbers {
+			if n > result {
+				result = n
+			}
+		}
+	}
+	return result
+}
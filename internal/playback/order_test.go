@@ -0,0 +1,126 @@
+package playback
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	forwardRepeat = musicxml.Barline{
+		Location: musicxml.BarlineLocationLeft,
+		BarStyle: musicxml.BarLineStyleHeavyLight,
+		Repeat:   &musicxml.BarLineRepeat{Direction: musicxml.BarLineRepeatDirectionForward},
+	}
+	backwardRepeat = musicxml.Barline{
+		Location: musicxml.BarlineLocationRight,
+		BarStyle: musicxml.BarLineStyleLightHeavy,
+		Repeat:   &musicxml.BarLineRepeat{Direction: musicxml.BarLineRepeatDirectionBackward},
+	}
+)
+
+func ending(number string, endingType musicxml.BarlineEndingType, repeat *musicxml.BarLineRepeat) musicxml.Barline {
+	var location musicxml.BarlineLocation = musicxml.BarlineLocationLeft
+	if endingType != musicxml.BarlineEndingTypeStart {
+		location = musicxml.BarlineLocationRight
+	}
+	return musicxml.Barline{
+		Location: location,
+		Ending:   &musicxml.BarlineEnding{Number: number, Type: endingType},
+		Repeat:   repeat,
+	}
+}
+
+func sound(attr string) musicxml.Element {
+	return musicxml.NewElement(musicxml.ElementSound, "", xml.Attr{Name: xml.Name{Local: attr}, Value: "yes"})
+}
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		measures []musicxml.Measure
+		want     []int
+	}{
+		{
+			name: "no repeat",
+			measures: []musicxml.Measure{
+				{Number: 1}, {Number: 2}, {Number: 3},
+			},
+			want: []int{0, 1, 2},
+		},
+		{
+			name: "repeat from the beginning",
+			measures: []musicxml.Measure{
+				{Number: 1}, {Number: 2, Barline: []musicxml.Barline{backwardRepeat}}, {Number: 3},
+			},
+			want: []int{0, 1, 0, 1, 2},
+		},
+		{
+			name: "repeat from the forward repeat",
+			measures: []musicxml.Measure{
+				{Number: 1},
+				{Number: 2, Barline: []musicxml.Barline{forwardRepeat}},
+				{Number: 3, Barline: []musicxml.Barline{backwardRepeat}},
+				{Number: 4},
+			},
+			want: []int{0, 1, 2, 1, 2, 3},
+		},
+		{
+			name: "first and second ending",
+			measures: []musicxml.Measure{
+				{Number: 1, Barline: []musicxml.Barline{forwardRepeat}},
+				{Number: 2, Barline: []musicxml.Barline{ending("1", musicxml.BarlineEndingTypeStart, nil)}},
+				{Number: 3, Barline: []musicxml.Barline{ending("1", musicxml.BarlineEndingTypeStop, backwardRepeat.Repeat)}},
+				{Number: 4, Barline: []musicxml.Barline{
+					ending("2", musicxml.BarlineEndingTypeStart, nil),
+					ending("2", musicxml.BarlineEndingTypeDiscontinue, nil),
+				}},
+				{Number: 5},
+			},
+			want: []int{0, 1, 2, 0, 3, 4},
+		},
+		{
+			name: "three passes",
+			measures: []musicxml.Measure{
+				{Number: 1},
+				{Number: 2, Barline: []musicxml.Barline{
+					ending("1, 2", musicxml.BarlineEndingTypeStart, nil),
+					ending("1, 2", musicxml.BarlineEndingTypeStop, backwardRepeat.Repeat),
+				}},
+				{Number: 3, Barline: []musicxml.Barline{
+					ending("3", musicxml.BarlineEndingTypeStart, nil),
+					ending("3", musicxml.BarlineEndingTypeStop, nil),
+				}},
+			},
+			want: []int{0, 1, 0, 1, 0, 2},
+		},
+		{
+			name: "da capo al fine",
+			measures: []musicxml.Measure{
+				{Number: 1},
+				{Number: 2, Appendix: []musicxml.Element{sound("fine")}},
+				{Number: 3},
+				{Number: 4, Appendix: []musicxml.Element{sound("dacapo")}},
+			},
+			want: []int{0, 1, 2, 3, 0, 1},
+		},
+		{
+			name: "dal segno al coda, the repeat is not taken again",
+			measures: []musicxml.Measure{
+				{Number: 1, Barline: []musicxml.Barline{backwardRepeat}},
+				{Number: 2, Appendix: []musicxml.Element{sound("segno")}},
+				{Number: 3, Appendix: []musicxml.Element{sound("tocoda")}},
+				{Number: 4, Appendix: []musicxml.Element{sound("dalsegno")}},
+				{Number: 5, Appendix: []musicxml.Element{sound("coda")}},
+			},
+			want: []int{0, 0, 1, 2, 3, 1, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Order(tt.measures))
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package playback
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+)
+
+const (
+	// TICKS_PER_QUARTER is the resolution of the time of the playback
+	TICKS_PER_QUARTER = 480
+
+	// the tempo when the score has neither the sound tempo nor the metronome, in quarter notes per minute
+	DEFAULT_TEMPO = 100
+)
+
+var stepSemitones = map[string]int{
+	"C": 0,
+	"D": 2,
+	"E": 4,
+	"F": 5,
+	"G": 7,
+	"A": 9,
+	"B": 11,
+}
+
+// Note is the sounding note, the time is in ticks
+type Note struct {
+	// the midi key, the middle C is 60
+	Key      int
+	Start    int
+	Duration int
+
+	// where the note is written, the measure number and the position of the note element in the measure
+	Measure  int
+	Position int
+
+	// the note continues the previous note of the same key
+	tied bool
+}
+
+// Track is the notes of a part
+type Track struct {
+	Name string
+	// the id of the part on the musicxml
+	Part  string
+	Notes []Note
+}
+
+// Tempo is the change of the tempo, in quarter notes per minute
+type Tempo struct {
+	Start int
+	BPM   float64
+}
+
+// TimeChange is the time signature starting at the tick
+type TimeChange struct {
+	Start    int
+	Beat     int
+	BeatType int
+}
+
+// Score is the whole score expanded in the playing order
+type Score struct {
+	Tracks []Track
+	Tempos []Tempo
+	Times  []TimeChange
+	// the end of the last measure
+	Length int
+}
+
+// the element of the measure, decoded only for the playback
+type (
+	pitchAlter struct {
+		Alter float64 `xml:"pitch>alter"`
+	}
+	moveDuration struct {
+		Duration int `xml:"duration"`
+	}
+)
+
+// New expands the repeats of the score and times every note of every part.
+// the duration follows the divisions of the score, the time signature is used when the divisions is not written
+func New(ctx context.Context, music musicxml.MusicXML, ts timesig.TimeSignature) Score {
+	result := Score{}
+	if len(music.Parts) == 0 {
+		return result
+	}
+
+	parts := music.NamedParts()
+	mainPart := parts[0]
+	order := Order(mainPart.Measures)
+
+	for pi, part := range parts {
+		track := Track{Name: part.Name, Part: part.ID}
+		if track.Name == "" {
+			track.Name = part.ID
+		}
+
+		divisions := divisionsOnMeasures(part.Measures)
+		tick := 0
+		for _, i := range order {
+			if i >= len(part.Measures) {
+				continue
+			}
+			measure := part.Measures[i]
+			time := ts.GetTimesignatureOnMeasure(ctx, measure.Number)
+
+			notes, tempos, length := timeMeasure(ctx, measure, divisions[i], time, tick)
+			track.Notes = appendTied(track.Notes, notes)
+			if pi == 0 {
+				result.Tempos = append(result.Tempos, tempos...)
+				if last := len(result.Times) - 1; last < 0 || result.Times[last].Beat != time.Beat || result.Times[last].BeatType != time.BeatType {
+					result.Times = append(result.Times, TimeChange{Start: tick, Beat: time.Beat, BeatType: time.BeatType})
+				}
+			}
+			tick += length
+		}
+
+		if tick > result.Length {
+			result.Length = tick
+		}
+		result.Tracks = append(result.Tracks, track)
+	}
+
+	if len(result.Tempos) == 0 || result.Tempos[0].Start > 0 {
+		result.Tempos = append([]Tempo{{Start: 0, BPM: initialTempo(mainPart)}}, result.Tempos...)
+	}
+
+	return result
+}
+
+// Seconds returns the time of the tick following the tempo changes, the tempos are sorted by their start
+func (s Score) Seconds(tick int) float64 {
+	result := 0.0
+	bpm := float64(DEFAULT_TEMPO)
+	last := 0
+	for _, t := range s.Tempos {
+		if t.Start >= tick {
+			break
+		}
+		if t.BPM <= 0 {
+			continue
+		}
+		result += float64(t.Start-last) / TICKS_PER_QUARTER * 60 / bpm
+		bpm, last = t.BPM, t.Start
+	}
+	return result + float64(tick-last)/TICKS_PER_QUARTER*60/bpm
+}
+
+// VerseSeconds is the length of a verse, the verses are separated by a quarter rest
+func (s Score) VerseSeconds() float64 {
+	return s.Seconds(s.Length + TICKS_PER_QUARTER)
+}
+
+// initialTempo is the tempo of the metronome marking when the score has no sound tempo at the beginning
+func initialTempo(part musicxml.Part) float64 {
+	metronome := part.GetTempo()
+	if metronome == nil {
+		return DEFAULT_TEMPO
+	}
+
+	perMinute, err := strconv.ParseFloat(strings.TrimSpace(metronome.PerMinute), 64)
+	if err != nil || perMinute <= 0 {
+		return DEFAULT_TEMPO
+	}
+
+	quarters := map[musicxml.NoteLength]float64{
+		musicxml.NoteLengthWhole:   4,
+		musicxml.NoteLengthHalf:    2,
+		musicxml.NoteLengthQuarter: 1,
+		musicxml.NoteLengthEighth:  0.5,
+		musicxml.NoteLength16th:    0.25,
+	}
+	unit, ok := quarters[metronome.BeatUnit]
+	if !ok {
+		unit = 1
+	}
+	if len(metronome.BeatUnitDot) > 0 {
+		unit *= 1.5
+	}
+	return perMinute * unit
+}
+
+// divisionsOnMeasures returns the divisions of every measure, it is written once and kept until it changes
+func divisionsOnMeasures(measures []musicxml.Measure) []int {
+	result := make([]int, len(measures))
+	current := 0
+	for i, measure := range measures {
+		if measure.Attribute != nil && measure.Attribute.Divisions > 0 {
+			current = measure.Attribute.Divisions
+		}
+		result[i] = current
+
+		// the divisions changed in the middle of the measure is kept on the next measures
+		for _, change := range measure.AttributeChanges {
+			if change.Attribute.Divisions > 0 {
+				current = change.Attribute.Divisions
+			}
+		}
+	}
+	return result
+}
+
+// timeMeasure times the notes of the measure, the voices are kept apart by the backup and the forward.
+// it returns the length of the measure, the pickup measure is shorter than its time signature
+func timeMeasure(ctx context.Context, measure musicxml.Measure, divisions int, time timesig.Time, start int) ([]Note, []Tempo, int) {
+	notes := []Note{}
+	tempos := []Tempo{}
+	if time.BeatType <= 0 {
+		time.BeatType = 4
+	}
+
+	toTicks := func(duration int) int {
+		return duration * TICKS_PER_QUARTER / divisions
+	}
+
+	cursor, end, lastStart := 0, 0, 0
+	for i, elmnt := range measure.Appendix {
+		switch elmnt.Name() {
+		case musicxml.ElementNote:
+			n, err := elmnt.ParseAsNote()
+			if err != nil || n.IsGrace() {
+				continue
+			}
+			// the other voices need the divisions to go back to the beginning of the measure
+			if divisions <= 0 && n.GetVoice() != musicxml.DEFAULT_VOICE {
+				continue
+			}
+
+			var duration int
+			if divisions > 0 {
+				duration = toTicks(n.Duration)
+			} else {
+				duration = int(time.GetNoteLength(ctx, n) * TICKS_PER_QUARTER * 4 / float64(time.BeatType))
+			}
+
+			noteStart := cursor
+			if n.IsChord() {
+				noteStart = lastStart
+			} else {
+				cursor += duration
+			}
+			lastStart = noteStart
+
+			if n.Rest == nil {
+				alter := pitchAlter{}
+				elmnt.Decode(&alter)
+
+				notes = append(notes, Note{
+					Key:      Key(n, alter.Alter),
+					Start:    start + noteStart,
+					Duration: duration,
+					Measure:  measure.Number,
+					Position: i,
+					tied:     isTiedStop(n),
+				})
+			}
+
+		case musicxml.ElementAttributes:
+			attr := musicxml.Attribute{}
+			if err := elmnt.Decode(&attr); err == nil && attr.Divisions > 0 {
+				divisions = attr.Divisions
+			}
+
+		case musicxml.ElementBackup, musicxml.ElementForward:
+			if divisions <= 0 {
+				continue
+			}
+			move := moveDuration{}
+			if err := elmnt.Decode(&move); err != nil {
+				continue
+			}
+			if elmnt.Name() == musicxml.ElementBackup {
+				cursor -= toTicks(move.Duration)
+			} else {
+				cursor += toTicks(move.Duration)
+			}
+
+		case musicxml.ElementDirection:
+			d, err := elmnt.ParseAsDirection()
+			if err == nil && d.Sound != nil && d.Sound.Tempo > 0 {
+				tempos = append(tempos, Tempo{Start: start + cursor, BPM: d.Sound.Tempo})
+			}
+
+		case musicxml.ElementSound:
+			sound := musicxml.Sound{}
+			if err := elmnt.Decode(&sound); err == nil && sound.Tempo > 0 {
+				tempos = append(tempos, Tempo{Start: start + cursor, BPM: sound.Tempo})
+			}
+		}
+
+		if cursor > end {
+			end = cursor
+		}
+	}
+
+	if end == 0 && time.BeatType > 0 {
+		// the empty measure
+		end = time.Beat * TICKS_PER_QUARTER * 4 / time.BeatType
+	}
+
+	return notes, tempos, end
+}
+
+// Key returns the midi key of the pitch of the note
+func Key(n musicxml.Note, alter float64) int {
+	step, octave := n.Pitch.Step, n.Pitch.Octave
+	if n.Unpitched != nil {
+		step, octave = n.Unpitched.DisplayStep, n.Unpitched.DisplayOctave
+	}
+	return (octave+1)*12 + stepSemitones[strings.ToUpper(step)] + int(alter)
+}
+
+func isTiedStop(n musicxml.Note) bool {
+	return n.Notations != nil && n.Notations.Tied != nil && n.Notations.Tied.Type == musicxml.NoteSlurTypeStop
+}
+
+// appendTied appends the notes to the track, the note tied to the previous note lengthens it
+func appendTied(track []Note, notes []Note) []Note {
+	for _, n := range notes {
+		if !n.tied {
+			track = append(track, n)
+			continue
+		}
+
+		tied := false
+		for i := len(track) - 1; i >= 0; i-- {
+			if track[i].Key == n.Key && track[i].Start+track[i].Duration == n.Start {
+				track[i].Duration += n.Duration
+				tied = true
+				break
+			}
+		}
+		if !tied {
+			// the tie across the jump, it is played as the new note
+			track = append(track, n)
+		}
+	}
+	return track
+}
@@ -0,0 +1,128 @@
+package playback
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Score
+	}{
+		{
+			name: "voices, chord, tie and the sound tempo",
+			content: `<score-partwise>
+				<part-list><score-part id="P1"><part-name>Soprano</part-name></score-part></part-list>
+				<part id="P1">
+				<measure number="1">
+					<attributes><divisions>2</divisions><time><beats>2</beats><beat-type>4</beat-type></time></attributes>
+					<direction><direction-type><words>Andante</words></direction-type><sound tempo="72"/></direction>
+					<note><pitch><step>C</step><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type></note>
+					<note><chord/><pitch><step>E</step><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type></note>
+					<note><pitch><step>F</step><alter>1</alter><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type><notations><tied type="start"/></notations></note>
+					<backup><duration>4</duration></backup>
+					<note><pitch><step>A</step><octave>3</octave></pitch><duration>4</duration><voice>2</voice><type>half</type></note>
+				</measure>
+				<measure number="2">
+					<note><pitch><step>F</step><alter>1</alter><octave>4</octave></pitch><duration>1</duration><voice>1</voice><type>eighth</type><notations><tied type="stop"/></notations></note>
+					<note><rest/><duration>1</duration><voice>1</voice><type>eighth</type></note>
+					<note><grace/><pitch><step>D</step><octave>4</octave></pitch><voice>1</voice><type>eighth</type></note>
+					<note><pitch><step>B</step><alter>-1</alter><octave>4</octave></pitch><duration>2</duration><voice>1</voice><type>quarter</type></note>
+				</measure>
+				</part>
+			</score-partwise>`,
+			want: Score{
+				Tracks: []Track{
+					{
+						Name: "Soprano",
+						Part: "P1",
+						Notes: []Note{
+							{Key: 60, Start: 0, Duration: 480, Measure: 1, Position: 2},
+							{Key: 64, Start: 0, Duration: 480, Measure: 1, Position: 3},
+							{Key: 66, Start: 480, Duration: 720, Measure: 1, Position: 4},
+							{Key: 57, Start: 0, Duration: 960, Measure: 1, Position: 6},
+							{Key: 70, Start: 1440, Duration: 480, Measure: 2, Position: 3},
+						},
+					},
+				},
+				Tempos: []Tempo{{Start: 0, BPM: 72}},
+				Times:  []TimeChange{{Start: 0, Beat: 2, BeatType: 4}},
+				Length: 1920,
+			},
+		},
+		{
+			name: "without the divisions, the metronome tempo",
+			content: `<score-partwise>
+				<part id="P1">
+				<measure number="1">
+					<attributes><time><beats>6</beats><beat-type>8</beat-type></time></attributes>
+					<direction><direction-type><metronome><beat-unit>quarter</beat-unit><beat-unit-dot/><per-minute>60</per-minute></metronome></direction-type></direction>
+					<note><pitch><step>G</step><octave>4</octave></pitch><type>quarter</type><dot/></note>
+					<note><pitch><step>A</step><octave>4</octave></pitch><type>eighth</type></note>
+				</measure>
+				<measure number="2"></measure>
+				</part>
+			</score-partwise>`,
+			want: Score{
+				Tracks: []Track{
+					{
+						Name: "P1",
+						Part: "P1",
+						Notes: []Note{
+							{Key: 67, Start: 0, Duration: 720, Measure: 1, Position: 2},
+							{Key: 69, Start: 720, Duration: 240, Measure: 1, Position: 3},
+						},
+					},
+				},
+				Tempos: []Tempo{{Start: 0, BPM: 90}},
+				Times:  []TimeChange{{Start: 0, Beat: 6, BeatType: 8}},
+				Length: 2400,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			music, err := musicxml.Parse([]byte(tt.content))
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := context.Background()
+			ts := timesig.NewTimeSignatures(ctx, music.MainPart().Measures)
+
+			assert.Equal(t, tt.want, New(ctx, music, ts))
+		})
+	}
+}
+
+func TestScore_Seconds(t *testing.T) {
+	score := Score{
+		Tempos: []Tempo{
+			{Start: 0, BPM: 60},
+			{Start: 2 * TICKS_PER_QUARTER, BPM: 120},
+		},
+	}
+
+	tests := []struct {
+		name string
+		tick int
+		want float64
+	}{
+		{name: "beginning", tick: 0, want: 0},
+		{name: "first tempo", tick: TICKS_PER_QUARTER, want: 1},
+		{name: "on the change", tick: 2 * TICKS_PER_QUARTER, want: 2},
+		{name: "after the change", tick: 4 * TICKS_PER_QUARTER, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, score.Seconds(tt.tick), 1e-9)
+		})
+	}
+
+	assert.InDelta(t, 60.0/DEFAULT_TEMPO, Score{}.Seconds(TICKS_PER_QUARTER), 1e-9)
+}
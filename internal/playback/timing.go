@@ -0,0 +1,56 @@
+package playback
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+)
+
+// Timing is when the note is played, in milliseconds from the beginning of the first verse
+type Timing struct {
+	NoteID     string `json:"noteId"`
+	Verse      int    `json:"verse"`
+	StartMs    int    `json:"startMs"`
+	DurationMs int    `json:"durationMs"`
+}
+
+// Timings returns the time of every note on every verse, the verses are played one after another.
+// the note id is the same as the id of the note on the rendered hymn
+func (s Score) Timings(hymn string, verses int) []Timing {
+	if verses < 1 {
+		verses = 1
+	}
+
+	// the timing of a verse is the same on every verse, only the offset differs
+	verse := []Timing{}
+	for _, track := range s.Tracks {
+		for _, n := range track.Notes {
+			start := s.Seconds(n.Start)
+			verse = append(verse, Timing{
+				NoteID:     entity.NoteID(hymn, track.Part, n.Measure, n.Position),
+				StartMs:    milliseconds(start),
+				DurationMs: milliseconds(s.Seconds(n.Start+n.Duration) - start),
+			})
+		}
+	}
+	sort.SliceStable(verse, func(i, j int) bool {
+		return verse[i].StartMs < verse[j].StartMs
+	})
+
+	result := make([]Timing, 0, len(verse)*verses)
+	verseLength := s.VerseSeconds()
+	for v := 1; v <= verses; v++ {
+		offset := milliseconds(float64(v-1) * verseLength)
+		for _, t := range verse {
+			t.Verse = v
+			t.StartMs += offset
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func milliseconds(seconds float64) int {
+	return int(math.Round(seconds * 1000))
+}
@@ -0,0 +1,66 @@
+package playback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore_Timings(t *testing.T) {
+	score := Score{
+		Tracks: []Track{
+			{
+				Part: "P1",
+				Notes: []Note{
+					{Key: 60, Start: 0, Duration: 480, Measure: 1, Position: 2},
+					{Key: 62, Start: 480, Duration: 240, Measure: 1, Position: 3},
+				},
+			},
+			{
+				Part: "P2",
+				Notes: []Note{
+					{Key: 48, Start: 0, Duration: 960, Measure: 1, Position: 1},
+				},
+			},
+		},
+		Tempos: []Tempo{{Start: 0, BPM: 120}},
+		Length: 960,
+	}
+
+	tests := []struct {
+		name   string
+		hymn   string
+		verses int
+		want   []Timing
+	}{
+		{
+			name:   "single verse, sorted by the start",
+			hymn:   "3a",
+			verses: 1,
+			want: []Timing{
+				{NoteID: "note-3a-P1-1-2", Verse: 1, StartMs: 0, DurationMs: 500},
+				{NoteID: "note-3a-P2-1-1", Verse: 1, StartMs: 0, DurationMs: 1000},
+				{NoteID: "note-3a-P1-1-3", Verse: 1, StartMs: 500, DurationMs: 250},
+			},
+		},
+		{
+			name:   "every verse after the quarter rest",
+			verses: 2,
+			want: []Timing{
+				{NoteID: "note-P1-1-2", Verse: 1, StartMs: 0, DurationMs: 500},
+				{NoteID: "note-P2-1-1", Verse: 1, StartMs: 0, DurationMs: 1000},
+				{NoteID: "note-P1-1-3", Verse: 1, StartMs: 500, DurationMs: 250},
+				{NoteID: "note-P1-1-2", Verse: 2, StartMs: 1500, DurationMs: 500},
+				{NoteID: "note-P2-1-1", Verse: 2, StartMs: 1500, DurationMs: 1000},
+				{NoteID: "note-P1-1-3", Verse: 2, StartMs: 2000, DurationMs: 250},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, score.Timings(tt.hymn, tt.verses))
+		})
+	}
+
+	assert.Equal(t, []Timing{}, Score{}.Timings("1", 0))
+}
@@ -0,0 +1,106 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/fonts"
+)
+
+type fontFace struct {
+	Family string
+	File   string
+}
+
+var fontFaces = []fontFace{
+	{Family: "Caladea", File: "caladea.ttf"},
+	{Family: "Figtree", File: "figtree.ttf"},
+	{Family: "Noto Music", File: "noto-music.ttf"},
+	{Family: "Old Standard TT", File: "old-standard-tt.ttf"},
+	{Family: "mozart11", File: "mozart11.ttf"},
+}
+
+func fontFaceRule(w io.Writer, family, src string) {
+	fmt.Fprintf(w, `@font-face {
+         font-family: '%s';
+         font-style: normal;
+         font-weight: 400;
+         src: %s format('truetype');
+       }
+`, family, src)
+}
+
+// embeddedFont inlines the fonts as the data uri, only the glyphs of the text are kept
+func embeddedFont(fontPath string, text []rune) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, face := range fontFaces {
+		raw, err := os.ReadFile(filepath.Join(fontPath, face.File))
+		if err != nil {
+			return nil, err
+		}
+
+		subset, err := fonts.Subset(raw, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", face.File, err)
+		}
+
+		fontFaceRule(&buf, face.Family, "url(data:font/ttf;base64,"+base64.StdEncoding.EncodeToString(subset)+")")
+	}
+	return buf.Bytes(), nil
+}
+
+// glyphRecorder records the characters of the text written on the canvas, the fonts are subset to them
+type glyphRecorder struct {
+	canvas.Canvas
+	used map[rune]bool
+}
+
+func newGlyphRecorder(canv canvas.Canvas) *glyphRecorder {
+	return &glyphRecorder{
+		Canvas: canv,
+		used:   map[rune]bool{},
+	}
+}
+
+func (gr *glyphRecorder) Text(x int, y int, t string, s ...string) {
+	gr.record(t)
+	gr.Canvas.Text(x, y, t, s...)
+}
+
+func (gr *glyphRecorder) TextUnescaped(x float64, y float64, t string, s ...string) {
+	// the unescaped text might have the markup (e.g. tspan) and the character reference
+	decoder := xml.NewDecoder(strings.NewReader("<t>" + t + "</t>"))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if data, ok := token.(xml.CharData); ok {
+			gr.record(string(data))
+		}
+	}
+	gr.Canvas.TextUnescaped(x, y, t, s...)
+}
+
+func (gr *glyphRecorder) record(t string) {
+	for _, r := range t {
+		gr.used[r] = true
+	}
+}
+
+func (gr *glyphRecorder) Runes() []rune {
+	result := make([]rune, 0, len(gr.used))
+	for r := range gr.used {
+		result = append(result, r)
+	}
+	return result
+}
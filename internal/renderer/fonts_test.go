@@ -0,0 +1,86 @@
+package renderer
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_glyphRecorder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name   string
+		record func(gr *glyphRecorder)
+		mock   func(canv *canvas.MockCanvas)
+		want   string
+	}{
+		{
+			name: "text",
+			record: func(gr *glyphRecorder) {
+				gr.Text(1, 2, "suci", "class='lyric'")
+			},
+			mock: func(canv *canvas.MockCanvas) {
+				canv.EXPECT().Text(1, 2, "suci", "class='lyric'")
+			},
+			want: "cisu",
+		},
+		{
+			name: "unescaped text with the markup and the character reference",
+			record: func(gr *glyphRecorder) {
+				gr.TextUnescaped(1, 2, `<tspan style="font-family:mozart11">&#xF026;</tspan>1&amp;`)
+			},
+			mock: func(canv *canvas.MockCanvas) {
+				canv.EXPECT().TextUnescaped(float64(1), float64(2), `<tspan style="font-family:mozart11">&#xF026;</tspan>1&amp;`)
+			},
+			want: "&1\uf026",
+		},
+		{
+			name: "the other drawing is passed through",
+			record: func(gr *glyphRecorder) {
+				gr.Line(0, 0, 10, 0)
+			},
+			mock: func(canv *canvas.MockCanvas) {
+				canv.EXPECT().Line(0, 0, 10, 0)
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canv := canvas.NewMockCanvas(ctrl)
+			tt.mock(canv)
+
+			gr := newGlyphRecorder(canv)
+			tt.record(gr)
+
+			got := gr.Runes()
+			sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_embeddedFont(t *testing.T) {
+	got, err := embeddedFont("../../files/var/www/fonts", []rune("Suci 1234567"))
+	assert.NoError(t, err)
+	assert.Equal(t, len(fontFaces), strings.Count(string(got), "@font-face"))
+	assert.Equal(t, len(fontFaces), strings.Count(string(got), "url(data:font/ttf;base64,"))
+	assert.NotContains(t, string(got), "/assets/fonts/")
+
+	_, err = embeddedFont("/nonexistent", []rune("Suci"))
+	assert.Error(t, err)
+}
+
+func Test_googlefont(t *testing.T) {
+	got := string(googlefont())
+	for _, face := range fontFaces {
+		assert.Contains(t, got, "font-family: '"+face.Family+"';")
+		assert.Contains(t, got, "url(/assets/fonts/"+face.File+")")
+	}
+}
@@ -0,0 +1,29 @@
+package renderer
+
+import (
+	"context"
+	"log"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/midi"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+type midiRendererInteractor struct{}
+
+// NewMIDIRenderer creates the renderer of the playback as the standard midi file, it is written into the canvas writer
+func NewMIDIRenderer() Renderer {
+	return &midiRendererInteractor{}
+}
+
+func (mr *midiRendererInteractor) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *entity.HymnMetaData) {
+	timeSignature := timesig.NewTimeSignatures(ctx, music.MainPart().Measures)
+
+	err := midi.Write(canv.Writer(), playback.New(ctx, music, timeSignature))
+	if err != nil {
+		log.Printf("[Render] failed to write the midi: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_midiRendererInteractor_Render(t *testing.T) {
+	music, err := musicxml.Parse([]byte(`<score-partwise>
+		<part-list><score-part id="P1"><part-name>Melody</part-name></score-part></part-list>
+		<part id="P1"><measure number="1">
+			<attributes><divisions>1</divisions></attributes>
+			<note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		</measure></part>
+	</score-partwise>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	NewMIDIRenderer().Render(context.Background(), music, canvas.NewTextCanvas(&buf, nil), nil)
+
+	result := buf.Bytes()
+	assert.Equal(t, "MThd", string(result[:4]))
+	// the conductor track and the melody
+	assert.Equal(t, 2, bytes.Count(result, []byte("MTrk")))
+	assert.Contains(t, string(result), "Melody")
+}
@@ -1,8 +1,10 @@
 package renderer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log"
 
 	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/credits"
@@ -31,6 +33,9 @@ type rendererInteractor struct {
 	Footnote footnote.Footnote
 	Verse    verse.Verse
 	Header   header.Header
+
+	// renders the score on the format requested by the render param instead of the svg
+	Formats map[params.Format]Renderer
 }
 
 func NewRenderer() Renderer {
@@ -43,6 +48,13 @@ func NewRenderer() Renderer {
 		Footnote: f,
 		Verse:    verse.New(f, l),
 		Header:   header.NewHeader(l),
+
+		Formats: map[params.Format]Renderer{
+			params.FormatText:   NewTextRenderer(),
+			params.FormatMIDI:   NewMIDIRenderer(),
+			params.FormatWAV:    NewWAVRenderer(),
+			params.FormatTiming: NewTimingRenderer(),
+		},
 	}
 }
 
@@ -50,22 +62,40 @@ func (ir *rendererInteractor) Render(ctx context.Context, music musicxml.MusicXM
   canvHeight := 3000
 	ns := []string{}
 	param, _ := params.GetParamFromContext(ctx)
+	if param.Render != nil {
+		if formatRenderer, ok := ir.Formats[param.Render.Format]; ok {
+			formatRenderer.Render(ctx, music, canv, metadata)
+			return
+		}
+	}
 	if param.Render != nil && param.Render.WhiteBackground {
 		ns = append(ns, "background-color='#FFFFFF'")
 	}
 
-	canv.Def()
-	fmt.Fprintf(canv.Writer(), fontfmt, string(googlefont()))
-	canv.DefEnd()
+	// the fonts are embedded at the end, after all of the text is written
+	var recorder *glyphRecorder
+	if param.Render != nil && param.Render.EmbedFonts {
+		recorder = newGlyphRecorder(canv)
+		canv = recorder
+	} else {
+		canv.Def()
+		fmt.Fprintf(canv.Writer(), fontfmt, string(googlefont()))
+		canv.DefEnd()
+	}
 
-	keySignature := keysig.NewKeySignature(ctx, music.Part.Measures)
-	timeSignature := timesig.NewTimeSignatures(ctx, music.Part.Measures)
+	mainPart := music.MainPart()
+	keySignature := keysig.NewKeySignature(ctx, mainPart.Measures)
+	timeSignature := timesig.NewTimeSignatures(ctx, mainPart.Measures)
 	canv.Group("class='header'", "style='font-family:Caladea;font-size:16px'")
 	ir.Header.RenderSheetHeader(ctx, canv, music.Credit, metadata)
-	ir.Header.RenderKeyandTimeSignatures(ctx, canv, keySignature, timeSignature)
+	if tempo := mainPart.GetTempo(); tempo != nil {
+		ir.Header.RenderKeyandTimeSignatures(ctx, canv, keySignature, timeSignature, *tempo)
+	} else {
+		ir.Header.RenderKeyandTimeSignatures(ctx, canv, keySignature, timeSignature)
+	}
 	canv.Gend()
 
-	relativeY := ir.Staff.Render(ctx, canv, music.Part, keySignature, timeSignature, metadata)
+	relativeY := ir.Staff.Render(ctx, canv, music.NamedParts(), keySignature, timeSignature, metadata)
 	if metadata != nil {
 		prm, _ := params.GetParamFromContext(ctx)
 		if prm.Verse > 2 || (prm.Verse > 1 && prm.SingleVerseMode) {
@@ -90,40 +120,26 @@ func (ir *rendererInteractor) Render(ctx context.Context, music musicxml.MusicXM
 		canvHeight = relativeY + 50
 		ir.Footnote.RenderTitleFootnotes(canv, relativeY, metadata.HymnData)
 	}
+	if recorder != nil {
+		fontStyle, err := embeddedFont(param.Render.FontPath, recorder.Runes())
+		if err != nil {
+			log.Printf("[Render] failed to embed the fonts, fallback to the font url: %v", err)
+			fontStyle = googlefont()
+		}
+		canv.Def()
+		fmt.Fprintf(canv.Writer(), fontfmt, string(fontStyle))
+		canv.DefEnd()
+	}
+
 	canv.Start(constant.LAYOUT_WIDTH, canvHeight)
 	canv.End()
 
 }
 
 func googlefont() []byte {
-	return []byte(`@font-face {
-         font-family: 'Caladea';
-         font-style: normal;
-         font-weight: 400;
-         src: url(/assets/fonts/caladea.ttf) format('truetype');
-       }
-       @font-face {
-         font-family: 'Figtree';
-         font-style: normal;
-         font-weight: 400;
-         src: url(/assets/fonts/figtree.ttf) format('truetype');
-        }
-       @font-face {
-         font-family: 'Noto Music';
-         font-style: normal;
-         font-weight: 400;
-         src: url(/assets/fonts/noto-music.ttf) format('truetype');
-       }
-       @font-face {
-         font-family: 'Old Standard TT';
-         font-style: normal;
-         font-weight: 400;
-         src: url(/assets/fonts/old-standard-tt.ttf) format('truetype');
-       }
-	   @font-face {
-         font-family: 'mozart11';
-         font-style: normal;
-         font-weight: 400;
-         src: url(/assets/fonts/mozart11.ttf) format('truetype');
-       }`)
+	var buf bytes.Buffer
+	for _, face := range fontFaces {
+		fontFaceRule(&buf, face.Family, "url(/assets/fonts/"+face.File+")")
+	}
+	return buf.Bytes()
 }
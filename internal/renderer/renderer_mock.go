@@ -9,8 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	entity "github.com/jodi-ivan/numbered-notation-xml/internal/entity"
 	musicxml "github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
-	repository "github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	canvas "github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 )
 
@@ -38,7 +38,7 @@ func (m *MockRenderer) EXPECT() *MockRendererMockRecorder {
 }
 
 // Render mocks base method.
-func (m *MockRenderer) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *repository.HymnMetadata) {
+func (m *MockRenderer) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *entity.HymnMetaData) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Render", ctx, music, canv, metadata)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/jodi-ivan/numbered-notation-xml/internal/verse"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -101,8 +102,8 @@ func Test_rendererInteractor_Render(t *testing.T) {
 			name: "empty metadata",
 			args: args{
 				music: musicxml.MusicXML{
-					Part: musicxml.Part{
-						Measures: measures,
+					Parts: []musicxml.Part{
+						{Measures: measures},
 					},
 					Credit: creditsData,
 				},
@@ -131,7 +132,7 @@ func Test_rendererInteractor_Render(t *testing.T) {
 			},
 			staffMock: func(c *gomock.Controller) *staff.MockStaff {
 				mockStaff := staff.NewMockStaff(c)
-				mockStaff.EXPECT().Render(gomock.Any(), gomock.Any(), musicxml.Part{Measures: measures}, keySignature, timeSignature, nil)
+				mockStaff.EXPECT().Render(gomock.Any(), gomock.Any(), []musicxml.Part{{Measures: measures}}, keySignature, timeSignature, nil)
 				return mockStaff
 			},
 		},
@@ -148,8 +149,8 @@ func Test_rendererInteractor_Render(t *testing.T) {
 					},
 				},
 				music: musicxml.MusicXML{
-					Part: musicxml.Part{
-						Measures: measures,
+					Parts: []musicxml.Part{
+						{Measures: measures},
 					},
 					Credit: creditsData,
 				},
@@ -177,7 +178,7 @@ func Test_rendererInteractor_Render(t *testing.T) {
 			},
 			staffMock: func(c *gomock.Controller) *staff.MockStaff {
 				mockStaff := staff.NewMockStaff(c)
-				mockStaff.EXPECT().Render(gomock.Any(), gomock.Any(), musicxml.Part{Measures: measures}, keySignature, timeSignature, metadata).Return(100)
+				mockStaff.EXPECT().Render(gomock.Any(), gomock.Any(), []musicxml.Part{{Measures: measures}}, keySignature, timeSignature, metadata).Return(100)
 				return mockStaff
 			},
 
@@ -229,6 +230,28 @@ func Test_rendererInteractor_Render(t *testing.T) {
 	}
 }
 
+func Test_rendererInteractor_RenderFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	music := musicxml.MusicXML{}
+	midi := NewMockRenderer(ctrl)
+	wav := NewMockRenderer(ctrl)
+
+	ctx := params.NewParamContext(context.Background(), &params.Param{
+		Render: &params.RenderParam{Format: params.FormatMIDI},
+	})
+	midi.EXPECT().Render(ctx, music, nil, nil)
+
+	ir := rendererInteractor{
+		Formats: map[params.Format]Renderer{
+			params.FormatMIDI: midi,
+			params.FormatWAV:  wav,
+		},
+	}
+	ir.Render(ctx, music, nil, nil)
+}
+
 func TestNewRenderer(t *testing.T) {
 	tests := []struct {
 		name string
@@ -249,7 +272,7 @@ func TestNewRenderer(t *testing.T) {
 				assert.NotNil(t, cast.Footnote)
 				assert.NotNil(t, cast.Verse)
 				assert.NotNil(t, cast.Header)
-
+				assert.Len(t, cast.Formats, 4)
 			}
 
 		})
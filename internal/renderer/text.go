@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/header"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/plaintext"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/staff"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+type textRendererInteractor struct {
+	Staff staff.Staff
+}
+
+// NewTextRenderer creates the renderer of the plain text numbered notation, it is written into the canvas writer
+func NewTextRenderer() Renderer {
+	return &textRendererInteractor{
+		Staff: staff.NewTextStaff(),
+	}
+}
+
+func (tr *textRendererInteractor) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *entity.HymnMetaData) {
+	mainPart := music.MainPart()
+	keySignature := keysig.NewKeySignature(ctx, mainPart.Measures)
+	timeSignature := timesig.NewTimeSignatures(ctx, mainPart.Measures)
+
+	w := canv.Writer()
+	if title := header.WorkTitle(music.Credit, metadata); title != "" {
+		fmt.Fprintf(w, "%s\n", title)
+	}
+
+	signatures := []string{header.HumanizedKey(ctx, keySignature)}
+	if !timeSignature.IsEmpty() {
+		signatures = append(signatures, timeSignature.GetHumanized())
+	}
+	if tempo := mainPart.GetTempo(); tempo != nil {
+		signatures = append(signatures, tempo.String())
+	}
+	fmt.Fprintf(w, "%s\n\n", strings.Join(signatures, "   "))
+
+	// the staff is indented on the svg, the indentation is removed from the text
+	var staffText bytes.Buffer
+	tr.Staff.Render(ctx, canvas.NewTextCanvas(&staffText, canv.Delegator()), music.NamedParts(), keySignature, timeSignature, metadata)
+	io.WriteString(w, plaintext.Dedent(staffText.String()))
+
+	if verses := plaintext.Verses(metadata); verses != "" {
+		fmt.Fprintf(w, "%s\n", verses)
+	}
+}
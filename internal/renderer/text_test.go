@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/staff"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_textRendererInteractor_Render(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	music := musicxml.MusicXML{
+		Credit: []musicxml.Credit{
+			{Type: musicxml.CreditTypeTitle, Words: "Unit Test"},
+		},
+		Parts: []musicxml.Part{
+			{
+				Measures: []musicxml.Measure{
+					{
+						Number: 1,
+						Attribute: &musicxml.Attribute{
+							Key: &musicxml.KeySignature{
+								Fifth: 2, // D major
+							},
+							Time: &struct {
+								Beats    int `xml:"beats"`
+								BeatType int `xml:"beat-type"`
+							}{
+								Beats:    4,
+								BeatType: 4,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	metadata := &entity.HymnMetaData{
+		HymnMetadata: &repository.HymnMetadata{
+			HymnData: repository.HymnData{
+				HymnIndicator: repository.HymnIndicator{Number: 1},
+				Title:         "Unit Test",
+			},
+		},
+		ParsedVerse: map[int][][]entity.LyricWordVerse{
+			2: {
+				{{Word: "Puji"}, {Word: "Tuhan"}},
+			},
+		},
+	}
+
+	staffMock := staff.NewMockStaff(ctrl)
+	staffMock.EXPECT().Render(gomock.Any(), gomock.Any(), music.NamedParts(), gomock.Any(), gomock.Any(), metadata).
+		DoAndReturn(func(ctx context.Context, canv canvas.Canvas, parts []musicxml.Part, ks keysig.KeySignature, ts timesig.TimeSignature, metadata *entity.HymnMetaData) int {
+			io.WriteString(canv.Writer(), "    1  2  3  4  |]\n    do re mi fa\n\n")
+			return 0
+		})
+
+	tr := &textRendererInteractor{
+		Staff: staffMock,
+	}
+
+	var buf bytes.Buffer
+	tr.Render(context.Background(), music, canvas.NewTextCanvas(&buf, nil), metadata)
+
+	assert.Equal(t, ""+
+		"1. UNIT TEST\n"+
+		"do = d   4 ketuk\n"+
+		"\n"+
+		"1  2  3  4  |]\n"+
+		"do re mi fa\n"+
+		"\n"+
+		"2. Puji Tuhan\n"+
+		"\n", buf.String())
+}
@@ -0,0 +1,31 @@
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+type timingRendererInteractor struct{}
+
+// NewTimingRenderer creates the renderer of the time of every note on every verse as the json, it is written into the canvas writer.
+// the time follows the wav, the note id follows the note on the svg
+func NewTimingRenderer() Renderer {
+	return &timingRendererInteractor{}
+}
+
+func (tr *timingRendererInteractor) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *entity.HymnMetaData) {
+	timeSignature := timesig.NewTimeSignatures(ctx, music.MainPart().Measures)
+	score := playback.New(ctx, music, timeSignature)
+
+	err := json.NewEncoder(canv.Writer()).Encode(score.Timings(metadata.HymnKey(), metadata.TotalVerses()))
+	if err != nil {
+		log.Printf("[Render] failed to write the timing: %v", err)
+	}
+}
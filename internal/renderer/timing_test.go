@@ -0,0 +1,50 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_timingRendererInteractor_Render(t *testing.T) {
+	music, err := musicxml.Parse([]byte(`<score-partwise>
+		<part id="P1"><measure number="1">
+			<attributes><divisions>1</divisions></attributes>
+			<sound tempo="60"/>
+			<note><pitch><step>A</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+			<note><rest/><duration>1</duration><type>quarter</type></note>
+		</measure></part>
+	</score-partwise>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	metadata := &entity.HymnMetaData{
+		HymnMetadata: &repository.HymnMetadata{
+			HymnData: repository.HymnData{
+				HymnIndicator: repository.HymnIndicator{Number: 5, Variant: sql.NullString{String: "a", Valid: true}},
+			},
+			Verse: map[int]repository.HymnVerse{2: {}},
+		},
+	}
+	ctx := params.NewParamContext(context.Background(), &params.Param{
+		Render: &params.RenderParam{Format: params.FormatTiming},
+	})
+
+	var buf bytes.Buffer
+	tr := &timingRendererInteractor{}
+	tr.Render(ctx, music, canvas.NewTextCanvas(&buf, nil), metadata)
+
+	assert.JSONEq(t, `[
+		{"noteId": "note-5a-P1-1-2", "verse": 1, "startMs": 0, "durationMs": 1000},
+		{"noteId": "note-5a-P1-1-2", "verse": 2, "startMs": 3000, "durationMs": 1000}
+	]`, buf.String())
+}
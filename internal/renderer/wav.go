@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"context"
+	"log"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/synth"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
+)
+
+type wavRendererInteractor struct {
+	SampleRate int
+}
+
+// NewWAVRenderer creates the renderer of the synthesized playback as the wav, it is written into the canvas writer
+func NewWAVRenderer() Renderer {
+	return &wavRendererInteractor{
+		SampleRate: synth.DEFAULT_SAMPLE_RATE,
+	}
+}
+
+func (wr *wavRendererInteractor) Render(ctx context.Context, music musicxml.MusicXML, canv canvas.Canvas, metadata *entity.HymnMetaData) {
+	timeSignature := timesig.NewTimeSignatures(ctx, music.MainPart().Measures)
+	score := playback.New(ctx, music, timeSignature)
+
+	voice := synth.VoiceOrgan
+	if param, _ := params.GetParamFromContext(ctx); param.Render != nil && param.Render.Instrument != "" {
+		voice = synth.Voice(param.Render.Instrument)
+	}
+
+	samples := synth.Render(score, metadata.TotalVerses(), voice, wr.SampleRate)
+	if err := synth.WriteWAV(canv.Writer(), samples, wr.SampleRate); err != nil {
+		log.Printf("[Render] failed to write the wav: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wavRendererInteractor_Render(t *testing.T) {
+	music, err := musicxml.Parse([]byte(`<score-partwise>
+		<part id="P1"><measure number="1">
+			<attributes><divisions>1</divisions></attributes>
+			<sound tempo="60"/>
+			<note><pitch><step>A</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		</measure></part>
+	</score-partwise>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	metadata := &entity.HymnMetaData{
+		HymnMetadata: &repository.HymnMetadata{
+			Verse: map[int]repository.HymnVerse{2: {}},
+		},
+	}
+	ctx := params.NewParamContext(context.Background(), &params.Param{
+		Render: &params.RenderParam{Format: params.FormatWAV, Instrument: "piano"},
+	})
+
+	var buf bytes.Buffer
+	wr := &wavRendererInteractor{SampleRate: 1000}
+	wr.Render(ctx, music, canvas.NewTextCanvas(&buf, nil), metadata)
+
+	// two verses of the quarter and the quarter rest, with the release of the piano
+	assert.Equal(t, "RIFF", string(buf.Bytes()[:4]))
+	assert.Equal(t, 44+(2000*2+150+1)*2, buf.Len())
+}
@@ -47,6 +47,8 @@ func (ri *rhythmInteractor) AdjustMultiDottedRenderer(notes []*entity.NoteRender
 		if n.AbsoluteAccidental != "" {
 			x += 4
 		}
+		// the grace notes sit on the left of the main note
+		x += len(n.GraceNotes) * constant.GRACE_NOTE_WIDTH
 		n.PositionX = x
 		n.PositionY = y
 		if n.IsLengthTakenFromLyric {
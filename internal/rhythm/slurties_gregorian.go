@@ -79,7 +79,8 @@ func noteYPosDirection(note *entity.NoteRenderer, staffLine lines.LineStaff) (fl
 		return 0, 0
 	}
 	r := rune(note.AbsoluteNote[0])
-	return staffLine.GetYPos(r, note.AbsoluteOctave), staffLine.GetStemDirection(r, note.AbsoluteOctave)
+	yPos := staffLine.GetYPosWithClef(r, note.AbsoluteOctave, note.Clef)
+	return yPos, staffLine.GetStemDirectionCompare(yPos)
 }
 
 func processTieNote(
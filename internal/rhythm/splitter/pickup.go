@@ -0,0 +1,101 @@
+package splitter
+
+import (
+	"context"
+	"math"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+)
+
+// splitPickup splits the beam of the pickup on its first beat.
+// the pickup ends on the barline, its first note starts on the measure length minus the pickup length.
+// the notes before the first beat are beamed together, the rest is split as the complete measure
+func splitPickup(ctx context.Context, notes []*entity.NoteRenderer, ts timesig.TimeSignature, segments []BeamSplitMarker, beamNo int) []BeamSplitMarker {
+	currentTimesig := ts.GetTimesignatureOnMeasure(ctx, notes[0].MeasureNumber)
+	beatLength := float64(1)
+	if currentTimesig.IsCompoundTime() {
+		// beamed by the dotted quarter
+		beatLength = 3
+	}
+
+	onsets, length := noteOnsets(ctx, notes, ts)
+	offset := float64(currentTimesig.Beat) - length
+
+	firstBeat := -1
+	for i, onset := range onsets {
+		if math.Mod(offset+onset, beatLength) == 0 {
+			firstBeat = i
+			break
+		}
+	}
+
+	if firstBeat <= 0 {
+		return segments
+	}
+
+	result := []BeamSplitMarker{}
+	for _, segment := range segments {
+		if segment.EndIndex < firstBeat {
+			// before the first beat, it is shorter than a beat
+			lockSegment(notes, segment, beamNo)
+			continue
+		}
+
+		if segment.StartIndex < firstBeat {
+			lockSegment(notes, BeamSplitMarker{StartIndex: segment.StartIndex, EndIndex: firstBeat - 1}, beamNo)
+			segment.StartIndex = firstBeat
+			if segment.StartIndex < segment.EndIndex {
+				notes[segment.StartIndex].UpdateBeamWithLock(beamNo, musicxml.NoteBeamTypeBegin)
+			}
+		}
+		result = append(result, segment)
+	}
+
+	return result
+}
+
+// lockSegment beams the segment as one group, it is not split further
+func lockSegment(notes []*entity.NoteRenderer, segment BeamSplitMarker, beamNo int) {
+	if segment.StartIndex == segment.EndIndex {
+		notes[segment.StartIndex].UpdateBeamWithLock(beamNo, musicxml.NoteBeamTypeEnd)
+		return
+	}
+
+	notes[segment.StartIndex].UpdateBeamWithLock(beamNo, musicxml.NoteBeamTypeBegin)
+	for i := segment.StartIndex + 1; i < segment.EndIndex; i++ {
+		notes[i].UpdateBeamWithLock(beamNo, musicxml.NoteBeamTypeContinue)
+	}
+	notes[segment.EndIndex].UpdateBeamWithLock(beamNo, musicxml.NoteBeamTypeEnd)
+}
+
+// noteOnsets returns the position of every note in beat, counted from the first note, and the length of the notes.
+// the dots of the numbered note takes the latest part of the note length
+func noteOnsets(ctx context.Context, notes []*entity.NoteRenderer, ts timesig.TimeSignature) ([]float64, float64) {
+	onsets := make([]float64, len(notes))
+	cursor := float64(0)
+
+	for i := 0; i < len(notes); i++ {
+		onsets[i] = cursor
+
+		additionalValue := float64(0)
+		values := []float64{}
+		for j := i + 1; j < len(notes) && notes[j].IsAdditional; j++ {
+			value := ts.GetNoteLength(ctx, notes[j].MeasureNumber, musicxml.Note{Type: notes[j].NoteLength})
+			values = append(values, value)
+			additionalValue += value
+		}
+
+		onset := cursor + math.Max(0, notes[i].NoteValue-additionalValue)
+		for k, value := range values {
+			onsets[i+1+k] = onset
+			onset += value
+		}
+
+		cursor += notes[i].NoteValue
+		i += len(values)
+	}
+
+	return onsets, cursor
+}
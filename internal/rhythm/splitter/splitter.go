@@ -33,6 +33,12 @@ func (bs *beamSplitter) Split(ctx context.Context, notes []*entity.NoteRenderer,
 	beamSegments[1] = CleanBeamByNumber(ctx, notes, 1)
 	beamSegments[2] = CleanBeamByNumber(ctx, notes, 2)
 
+	// the pickup does not start on the downbeat
+	if notes[0].IsPickupMeasure {
+		beamSegments[1] = splitPickup(ctx, notes, ts, beamSegments[1], 1)
+		beamSegments[2] = splitPickup(ctx, notes, ts, beamSegments[2], 2)
+	}
+
 	currentTimesig := ts.GetTimesignatureOnMeasure(ctx, measureNumber)
 	beamSegments[1] = splitTuplet(notes, beamSegments[1])
 	switch currentTimesig.BeatType {
@@ -0,0 +1,87 @@
+package splitter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/rhythm/splitter"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/stretchr/testify/assert"
+)
+
+// the eighth note of the pickup, the value is the length in beat of the time signature
+func eighthNote(value float64) *entity.NoteRenderer {
+	return &entity.NoteRenderer{
+		NoteLength:      musicxml.NoteLengthEighth,
+		NoteValue:       value,
+		IsPickupMeasure: true,
+		Beam: map[int]entity.Beam{
+			1: {Number: 1, Type: musicxml.NoteBeamTypeContinue},
+		},
+	}
+}
+
+func TestBeamSplitter_SplitPickup(t *testing.T) {
+	common := timesig.TimeSignature{Signatures: []timesig.Time{{Measure: 0, Beat: 4, BeatType: 4}}}
+	compound := timesig.TimeSignature{Signatures: []timesig.Time{{Measure: 0, Beat: 6, BeatType: 8}}}
+
+	tests := []struct {
+		name  string
+		ts    timesig.TimeSignature
+		notes []*entity.NoteRenderer
+		want  []musicxml.NoteBeamType
+	}{
+		{
+			name:  "the pickup starts on the beat",
+			ts:    common,
+			notes: []*entity.NoteRenderer{eighthNote(0.5), eighthNote(0.5)},
+			want:  []musicxml.NoteBeamType{musicxml.NoteBeamTypeBegin, musicxml.NoteBeamTypeEnd},
+		},
+		{
+			name:  "the pickup starts after the beat",
+			ts:    common,
+			notes: []*entity.NoteRenderer{eighthNote(0.5), eighthNote(0.5), eighthNote(0.5)},
+			want: []musicxml.NoteBeamType{
+				musicxml.NoteBeamTypeEnd,
+				musicxml.NoteBeamTypeBegin, musicxml.NoteBeamTypeEnd,
+			},
+		},
+		{
+			name: "the pickup is split on every beat",
+			ts:   common,
+			notes: []*entity.NoteRenderer{
+				eighthNote(0.5), eighthNote(0.5), eighthNote(0.5),
+				eighthNote(0.5), eighthNote(0.5),
+			},
+			want: []musicxml.NoteBeamType{
+				musicxml.NoteBeamTypeEnd,
+				musicxml.NoteBeamTypeBegin, musicxml.NoteBeamTypeEnd,
+				musicxml.NoteBeamTypeBegin, musicxml.NoteBeamTypeEnd,
+			},
+		},
+		{
+			name: "the pickup on the compound time",
+			ts:   compound,
+			notes: []*entity.NoteRenderer{
+				eighthNote(1), eighthNote(1), eighthNote(1), eighthNote(1),
+			},
+			want: []musicxml.NoteBeamType{
+				musicxml.NoteBeamTypeEnd,
+				musicxml.NoteBeamTypeBegin, musicxml.NoteBeamTypeContinue, musicxml.NoteBeamTypeEnd,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitter.New().Split(context.Background(), tt.notes, tt.ts, nil)
+
+			got := []musicxml.NoteBeamType{}
+			for _, n := range tt.notes {
+				got = append(got, n.Beam[1].Type)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/jodi-ivan/numbered-notation-xml/internal/staff/toping"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
 )
 
 type RenderStaffWithAlign interface {
@@ -163,14 +164,26 @@ func (rsa *renderStaffAlign) RenderWithAlign(ctx context.Context, canv canvas.Ca
 		}
 	}
 
-	canv.Group(`class="gregorian"`, "style='font-family:mozart11'")
-	margin := rsa.Gregorian.RenderStaffLine(ctx, staffPos, y, canv, flatten, ks, ts)
-	rsa.Toping.RenderRepeatMeasure(ctx, y+10, canv, flatten, true) // for the gregorian
-	canv.Gend()
+	yPos := y + additionalMarginBottom
+	marginBottom := 0
+	if prm, _ := params.GetParamFromContext(ctx); !prm.DisableGregorian {
+		canv.Group(`class="gregorian"`, "style='font-family:mozart11'")
+		margin := rsa.Gregorian.RenderStaffLine(ctx, staffPos, y, canv, flatten, ks, ts)
+		rsa.Toping.RenderRepeatMeasure(ctx, y+10, canv, flatten, true) // for the gregorian
+		canv.Gend()
 
-	stafflines := lines.NewLineStaffWithLines(ts, ks, y)
+		marginBottom = int(margin.Bottom.Y) - margin.DefaultBottom
+		yPos += gregorian.STAFF_OFFSET + marginBottom
+	}
 
-	yPos := y + gregorian.STAFF_OFFSET + (int(margin.Bottom.Y) - margin.DefaultBottom) + additionalMarginBottom
+	// the dynamics and the hairpins have their own space between the numbered notes and the lyric
+	lyricY := yPos
+	if text.HasExpression(flatten) {
+		lyricY += text.EXPRESSION_MARGIN
+		marginBottom += text.EXPRESSION_MARGIN
+	}
+
+	stafflines := lines.NewLineStaffWithLines(ts, ks, y)
 
 	canv.Group(`class="numbered"`)
 	offsetLyric := 0
@@ -195,7 +208,7 @@ func (rsa *renderStaffAlign) RenderWithAlign(ctx context.Context, canv canvas.Ca
 
 			}
 		}
-		newOffsetLyric := rsa.Lyric.RenderLyrics(ctx, yPos+offsetLyric, canv, measure, prev...)
+		newOffsetLyric := rsa.Lyric.RenderLyrics(ctx, lyricY+offsetLyric, canv, measure, prev...)
 		if newOffsetLyric > 0 && offsetLyric == 0 {
 			offsetLyric = newOffsetLyric
 		}
@@ -217,7 +230,8 @@ func (rsa *renderStaffAlign) RenderWithAlign(ctx context.Context, canv canvas.Ca
 
 	}
 
-	rsa.Lyric.RenderHypen(ctx, yPos, offsetLyric, canv, flatten)
+	rsa.Text.RenderExpression(ctx, yPos, canv, flatten)
+	rsa.Lyric.RenderHypen(ctx, lyricY, offsetLyric, canv, flatten)
 	rsa.Rhythm.RenderSlurTies(ctx, yPos, canv, slurTiesNote, float64(lastPos))
 	rsa.Toping.RenderRepeatMeasure(ctx, yPos, canv, flatten) // for the numbered
 	canv.Gend()
@@ -226,6 +240,6 @@ func (rsa *renderStaffAlign) RenderWithAlign(ctx context.Context, canv canvas.Ca
 	// canv.Circle(int(margin.Top.X), int(margin.Top.Y), 2, "stroke-width:1;fill:none;stroke:#FF0000")
 	// canv.Circle(int(margin.Bottom.X), int(margin.Bottom.Y), 2, "stroke-width:1;fill:none;stroke:#FF0000")
 
-	return int(margin.Bottom.Y) - margin.DefaultBottom
+	return marginBottom
 
 }
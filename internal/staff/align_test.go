@@ -291,6 +291,7 @@ func Test_renderStaffAlign_RenderWithAlign(t *testing.T) {
 
 			mtMock := text.NewMockText(t)
 			mtMock.EXPECT().RenderMeasureText(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+			mtMock.EXPECT().RenderExpression(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 			rsa.Text = mtMock
 			rsa.RenderWithAlign(context.Background(), canv, 0, tt.y, tt.ts, ks, tt.noteRenderer)
 			canv.AssertExpectations(t)
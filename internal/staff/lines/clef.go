@@ -0,0 +1,71 @@
+package lines
+
+import (
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+// the line (counted from the bottom line) where the clef is drawn on the font
+var defaultClefLine = map[musicxml.ClefSign]int{
+	musicxml.ClefSignG: 2,
+	musicxml.ClefSignF: 4,
+	musicxml.ClefSignC: 3,
+}
+
+// the diatonic index (octave * 7 + step) of the pitch written on the clef line
+var clefPitchIndex = map[musicxml.ClefSign]int{
+	musicxml.ClefSignG: 4*7 + 4, // G4
+	musicxml.ClefSignF: 3*7 + 3, // F3
+	musicxml.ClefSignC: 4 * 7,   // C4
+}
+
+func getClef(clef *musicxml.Clef) musicxml.Clef {
+	if clef == nil {
+		return musicxml.TrebleClef
+	}
+
+	result := *clef
+	if _, ok := clefPitchIndex[result.Sign]; !ok {
+		// percussion, TAB and none are rendered on the treble position
+		result.Sign = musicxml.ClefSignG
+		result.Line = 0
+	}
+	if result.Line == 0 {
+		result.Line = defaultClefLine[result.Sign]
+	}
+	return result
+}
+
+// topLineIndex returns the diatonic index of the top line of the staff on the clef, F5 on the treble clef
+func topLineIndex(clef *musicxml.Clef) int {
+	c := getClef(clef)
+	return clefPitchIndex[c.Sign] + (5-c.Line)*2 + (c.OctaveChange * 7)
+}
+
+// keySigShift returns how many half spaces the key signature is moved down from the treble position
+func keySigShift(clef *musicxml.Clef) int {
+	diff := topLineIndex(&musicxml.TrebleClef) - topLineIndex(clef)
+	return ((7 - diff%7) % 7)
+}
+
+// RenderClef draws the clef with its line and the octave change. small is the clef changes in the middle of the staff
+func RenderClef(canv canvas.Canvas, x float64, topLine int, clef *musicxml.Clef, small bool) {
+	c := getClef(clef)
+
+	// the glyph is drawn on the default line, move it when the clef is on another line
+	y := float64(topLine+CLEF_Y_OFFSET) - float64((c.Line-defaultClefLine[c.Sign])*STAFF_SPACE_WIDTH)
+
+	style := CLEF_FONT_SIZE
+	if small {
+		style = CLEF_CHANGE_FONT_SIZE
+	}
+
+	canv.Group(`class="clef"`, style)
+	canv.TextUnescaped(x, y, clefHex[c.Sign])
+	if c.OctaveChange < 0 {
+		canv.Text(int(x)+CLEF_OCTAVE_X_OFFSET, topLine+(4*STAFF_SPACE_WIDTH)+CLEF_OCTAVE_BELOW_Y_OFFSET, "8", CLEF_OCTAVE_STYLE)
+	} else if c.OctaveChange > 0 {
+		canv.Text(int(x)+CLEF_OCTAVE_X_OFFSET, topLine-CLEF_OCTAVE_ABOVE_Y_OFFSET, "8", CLEF_OCTAVE_STYLE)
+	}
+	canv.Gend()
+}
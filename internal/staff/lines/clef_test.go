@@ -0,0 +1,77 @@
+package lines
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineStaff_GetYPosWithClef(t *testing.T) {
+	ls := NewLineStaffWithLines(timesig.TimeSignature{}, keysig.KeySignature{}, 100)
+
+	tests := []struct {
+		name   string
+		clef   *musicxml.Clef
+		pitch  rune
+		octave int
+		want   float64
+	}{
+		{
+			name:  "treble top line",
+			pitch: 'F', octave: 5,
+			want: 100,
+		},
+		{
+			name:  "bass top line",
+			clef:  &musicxml.Clef{Sign: musicxml.ClefSignF, Line: 4},
+			pitch: 'A', octave: 3,
+			want: 100,
+		},
+		{
+			name:  "bass clef line without line",
+			clef:  &musicxml.Clef{Sign: musicxml.ClefSignF},
+			pitch: 'F', octave: 3,
+			want: 108,
+		},
+		{
+			name:  "treble 8vb middle line",
+			clef:  &musicxml.Clef{Sign: musicxml.ClefSignG, Line: 2, OctaveChange: -1},
+			pitch: 'B', octave: 3,
+			want: 116,
+		},
+		{
+			name:  "alto middle C",
+			clef:  &musicxml.Clef{Sign: musicxml.ClefSignC, Line: 3},
+			pitch: 'C', octave: 4,
+			want: 116,
+		},
+		{
+			name:  "percussion on the treble position",
+			clef:  &musicxml.Clef{Sign: musicxml.ClefSignPercussion},
+			pitch: 'F', octave: 5,
+			want: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ls.GetYPosWithClef(tt.pitch, tt.octave, tt.clef))
+		})
+	}
+}
+
+func TestLineStaff_GetYPosKeySig(t *testing.T) {
+	treble := NewLineStaffWithLines(timesig.TimeSignature{}, keysig.KeySignature{}, 100)
+	bass := NewLineStaffWithLines(timesig.TimeSignature{}, keysig.KeySignature{}, 100)
+	bass.Clef = &musicxml.Clef{Sign: musicxml.ClefSignF, Line: 4}
+
+	// F# on the top line of the treble, on the fourth line of the bass
+	assert.Equal(t, float64(100), treble.GetYPosKeySig("F", false))
+	assert.Equal(t, float64(108), bass.GetYPosKeySig("F", false))
+
+	// Bb on the middle line of the treble, on the second line of the bass
+	assert.Equal(t, float64(116), treble.GetYPosKeySig("B", true))
+	assert.Equal(t, float64(124), bass.GetYPosKeySig("B", true))
+}
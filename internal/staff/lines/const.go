@@ -14,8 +14,26 @@ const (
 
 const (
 	TREBLE_CLEF_HEX = `&#xF026;`
+	BASS_CLEF_HEX   = `&#xF025;`
+	ALTO_CLEF_HEX   = `&#xF024;`
+
+	CLEF_Y_OFFSET         = 15
+	CLEF_FONT_SIZE        = `style="font-size:28px"`
+	CLEF_CHANGE_FONT_SIZE = `style="font-size:20px"`
+	CLEF_CHANGE_X_OFFSET  = 14
+
+	CLEF_OCTAVE_X_OFFSET       = 6
+	CLEF_OCTAVE_BELOW_Y_OFFSET = 14
+	CLEF_OCTAVE_ABOVE_Y_OFFSET = 16
+	CLEF_OCTAVE_STYLE          = `style="font-family:Caladea;font-size:9.6px"`
 )
 
+var clefHex = map[musicxml.ClefSign]string{
+	musicxml.ClefSignG: TREBLE_CLEF_HEX,
+	musicxml.ClefSignF: BASS_CLEF_HEX,
+	musicxml.ClefSignC: ALTO_CLEF_HEX,
+}
+
 var accidentalHex = map[musicxml.NoteAccidental]string{
 	musicxml.NoteAccidentalNatural:     "&#xF02E;",
 	musicxml.NoteAccidentalSharp:       "&#xF02B;",
@@ -19,6 +19,8 @@ type LineStaff struct {
 	MarginRight  int
 	LeftIndent   int
 	MeasureStart int
+	// the clef at the beginning of the line, nil is the treble clef
+	Clef *musicxml.Clef
 }
 
 func NewMiddleNonFirstLineStaff(ks keysig.KeySignature) LineStaff {
@@ -87,6 +89,11 @@ func (ls *LineStaff) GetStemDirectionCompare(yPos float64) int {
 }
 
 func (ls *LineStaff) GetYPosKeySig(pitch string, isFlat bool) float64 {
+	shift := float64(keySigShift(ls.Clef)) * (float64(STAFF_SPACE_WIDTH) / 2)
+	return ls.getYPosTrebleKeySig(pitch, isFlat) + shift
+}
+
+func (ls *LineStaff) getYPosTrebleKeySig(pitch string, isFlat bool) float64 {
 	if isFlat {
 		// Flat order: B E A D G C F
 		pos := map[string]float64{
@@ -131,9 +138,7 @@ func (ls *LineStaff) Render(canv canvas.Canvas, y int, measureNo int, inclTimesi
 	key := ls.Keysig.GetKeyOnMeasure(context.Background(), measureNo)
 	accidentalSet := key.GetAccidentals()
 
-	canv.Group(`class="clef"`, `style="font-size:28px"`)
-	canv.TextUnescaped(constant.LAYOUT_INDENT_LENGTH+5, float64(initialY+15), TREBLE_CLEF_HEX)
-	canv.Gend()
+	RenderClef(canv, constant.LAYOUT_INDENT_LENGTH+5, initialY, ls.Clef, false)
 
 	canv.Group(`class="keysig"`, `style="font-size:28px"`)
 	offset := 0
@@ -147,20 +152,18 @@ func (ls *LineStaff) Render(canv canvas.Canvas, y int, measureNo int, inclTimesi
 			width := ACCIDENTAL_KEY_SIGNATURE_WIDTH
 
 			canv.TextUnescaped(float64(constant.LAYOUT_INDENT_LENGTH+CLEF_WIDTH)+float64(width*x),
-				ls.GetYPosKeySig(acc, key.Prev.Fifth < 0),
+				ls.GetYPosKeySig(acc, isFlatOnKey(*key.Prev, acc)),
 				accidental)
 		}
 
 		offset = (len(naturalSet) * ACCIDENTAL_KEY_SIGNATURE_WIDTH) + PADDING_WIDTH
 	}
 	for x, acc := range accidentalSet {
-		accidental := accidentalHex[musicxml.NoteAccidentalSharp]
+		// the non-traditional key might mix the sharps and the flats
+		accidental := accidentalHex[key.GetKeyAccidental(acc)]
 		width := ACCIDENTAL_KEY_SIGNATURE_WIDTH
-		if key.Fifth < 0 {
-			accidental = accidentalHex[musicxml.NoteAccidentalFlat]
-		}
 		canv.TextUnescaped(float64(constant.LAYOUT_INDENT_LENGTH+CLEF_WIDTH+offset)+float64(width*x),
-			ls.GetYPosKeySig(acc, key.Fifth < 0),
+			ls.GetYPosKeySig(acc, isFlatOnKey(key, acc)),
 			accidental)
 	}
 	canv.Gend()
@@ -174,7 +177,17 @@ func (ls *LineStaff) Render(canv canvas.Canvas, y int, measureNo int, inclTimesi
 	canv.Gend()
 }
 
+func isFlatOnKey(key keysig.Key, letter string) bool {
+	accidental := key.GetKeyAccidental(letter)
+	return accidental == musicxml.NoteAccidentalFlat || accidental == musicxml.NoteAccidentalDoubleFlat
+}
+
 func (ls *LineStaff) GetYPos(pitch rune, octave int) float64 {
+	return ls.GetYPosWithClef(pitch, octave, ls.Clef)
+}
+
+// GetYPosWithClef returns the y position of the note on the clef, the clef might change in the middle of the line
+func (ls *LineStaff) GetYPosWithClef(pitch rune, octave int, clef *musicxml.Clef) float64 {
 	noteOrder := []rune{'C', 'D', 'E', 'F', 'G', 'A', 'B'}
 
 	diatonicIndex := func(p rune, oct int) int {
@@ -186,7 +199,7 @@ func (ls *LineStaff) GetYPos(pitch rune, octave int) float64 {
 		return -1
 	}
 
-	refIndex := diatonicIndex('F', 5) // lines[0] = F5
+	refIndex := topLineIndex(clef) // lines[0] = F5 on the treble clef
 	noteIndex := diatonicIndex(pitch, octave)
 
 	stepsBelow := refIndex - noteIndex
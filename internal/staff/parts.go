@@ -0,0 +1,353 @@
+package staff
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/barline"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/breathpause"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/header"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/staff/lines"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/params"
+)
+
+const (
+	anchorLeading = -1
+	anchorClosing = math.MaxInt32
+
+	// resolution of the beat position, enough for 32nd triplets
+	ticksPerBeat = 96
+)
+
+// partAnchor is a beat position of a part in the system, with all the renderer sits on it
+type partAnchor struct {
+	key      [2]int // measure index on the system, tick
+	x        int
+	elements []*entity.NoteRenderer
+}
+
+// SplitPartsLines splits every part following the lines of the leading part.
+// the result is indexed by the line, then by the part.
+func SplitPartsLines(leadLines [][]musicxml.Measure, parts []musicxml.Part) [][][]musicxml.Measure {
+	result := make([][][]musicxml.Measure, len(leadLines))
+
+	measureIndex := make([]map[int]int, len(parts))
+	for p, part := range parts {
+		measureIndex[p] = map[int]int{}
+		for i, m := range part.Measures {
+			measureIndex[p][m.Number] = i
+		}
+	}
+
+	for i, line := range leadLines {
+		result[i] = make([][]musicxml.Measure, len(parts))
+		result[i][0] = line
+
+		for p := 1; p < len(parts); p++ {
+			result[i][p] = []musicxml.Measure{}
+			if len(line) == 0 {
+				continue
+			}
+
+			start, okStart := measureIndex[p][line[0].Number]
+			end, okEnd := measureIndex[p][line[len(line)-1].Number]
+			if !okStart || !okEnd || end < start {
+				continue
+			}
+
+			result[i][p] = parts[p].Measures[start : end+1]
+		}
+	}
+
+	return result
+}
+
+func beatToTick(beat float64) int {
+	return int(math.Round(beat * ticksPerBeat))
+}
+
+// collectAnchors groups the renderer of a part by their beat position in the measure
+func collectAnchors(ctx context.Context, ts timesig.TimeSignature, measures [][]*entity.NoteRenderer) []partAnchor {
+	result := []partAnchor{}
+
+	for mi, measure := range measures {
+		cursor := 0.0
+		hasTimedNote := false
+		additionalOnset := map[*entity.NoteRenderer]float64{}
+
+		for i, note := range measure {
+			tick := anchorLeading
+
+			switch {
+			case note.Barline != nil:
+				if hasTimedNote {
+					tick = anchorClosing
+				}
+
+			case breathpause.IsBreathMark(note) && len(result) > 0:
+				last := &result[len(result)-1]
+				last.elements = append(last.elements, note)
+				continue
+
+			case note.IsAdditional:
+				onset, ok := additionalOnset[note]
+				if !ok && len(result) > 0 {
+					last := &result[len(result)-1]
+					last.elements = append(last.elements, note)
+					continue
+				}
+				tick = beatToTick(onset)
+
+			default:
+				// the numbered note followed by its dots, the dots takes the latest part of the note length
+				additionalValue := 0.0
+				segments := []float64{}
+				for j := i + 1; j < len(measure) && measure[j].IsAdditional; j++ {
+					value := ts.GetNoteLength(ctx, measure[j].MeasureNumber, musicxml.Note{Type: measure[j].NoteLength})
+					segments = append(segments, value)
+					additionalValue += value
+				}
+
+				onset := cursor + math.Max(0, note.NoteValue-additionalValue)
+				for k, value := range segments {
+					additionalOnset[measure[i+1+k]] = onset
+					onset += value
+				}
+
+				tick = beatToTick(cursor)
+				cursor += note.NoteValue
+				hasTimedNote = true
+			}
+
+			key := [2]int{mi, tick}
+			if len(result) > 0 && result[len(result)-1].key == key {
+				last := &result[len(result)-1]
+				last.elements = append(last.elements, note)
+				continue
+			}
+
+			result = append(result, partAnchor{
+				key:      key,
+				x:        note.PositionX,
+				elements: []*entity.NoteRenderer{note},
+			})
+		}
+	}
+
+	return result
+}
+
+func lessAnchorKey(one, two [2]int) bool {
+	if one[0] != two[0] {
+		return one[0] < two[0]
+	}
+	return one[1] < two[1]
+}
+
+// AlignParts aligns the renderer of the parts on the same line vertically by their beat position.
+// every part keeps the minimum space it needs between its own notes,
+// then the line is stretched to the right margin so the justify does not move the notes anymore.
+func AlignParts(ctx context.Context, ts timesig.TimeSignature, aligns [][][]*entity.NoteRenderer) {
+	if len(aligns) < 2 || len(aligns[0]) == 0 {
+		return
+	}
+
+	for _, part := range aligns[1:] {
+		if len(part) != len(aligns[0]) {
+			return
+		}
+	}
+
+	anchors := make([][]partAnchor, len(aligns))
+	positions := make([]map[[2]int]int, len(aligns))
+	keySet := map[[2]int]bool{}
+	for p, measures := range aligns {
+		anchors[p] = collectAnchors(ctx, ts, measures)
+		positions[p] = map[[2]int]int{}
+
+		for i, anchor := range anchors[p] {
+			positions[p][anchor.key] = i
+			keySet[anchor.key] = true
+		}
+	}
+
+	keys := make([][2]int, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lessAnchorKey(keys[i], keys[j])
+	})
+
+	// the anchors are always moving forward, so the longest distance can be done in one pass
+	grid := map[[2]int]float64{}
+	for _, k := range keys {
+		pos := math.Inf(-1)
+		for p := range anchors {
+			i, ok := positions[p][k]
+			if !ok {
+				continue
+			}
+
+			anchor := anchors[p][i]
+			if i == 0 {
+				pos = math.Max(pos, float64(anchor.x))
+				continue
+			}
+
+			prev := anchors[p][i-1]
+			pos = math.Max(pos, grid[prev.key]+float64(anchor.x-prev.x))
+		}
+		grid[k] = pos
+	}
+
+	start, end := grid[keys[0]], grid[keys[len(keys)-1]]
+	ratio := 1.0
+
+	lastMeasure := aligns[0][len(aligns[0])-1]
+	if lastNote := lastMeasure[len(lastMeasure)-1]; lastNote.Barline != nil && end > start {
+		target := float64(constant.LAYOUT_WIDTH-constant.LAYOUT_INDENT_LENGTH) - barline.GetBarlineWidth(lastNote.Barline.BarStyle)
+		ratio = (target - start) / (end - start)
+	}
+
+	for p := range anchors {
+		for _, anchor := range anchors[p] {
+			anchorX := int(math.Round(start + (grid[anchor.key]-start)*ratio))
+			for _, note := range anchor.elements {
+				note.PositionX = anchorX + (note.PositionX - anchor.x)
+			}
+		}
+	}
+}
+
+func partAttributes(part musicxml.Part) []string {
+	attrs := []string{"class='part'", fmt.Sprintf("id='%s'", part.ID)}
+	if part.Layer != nil {
+		attrs = append(attrs, fmt.Sprintf("voice='%s'", part.Layer.Voice), fmt.Sprintf("chord='%d'", part.Layer.Chord))
+	}
+	return attrs
+}
+
+// partLabel returns the full name of the part on the first line, and the abbreviation on the rest
+func partLabel(part musicxml.Part, firstLine bool) string {
+	if part.Layer != nil && part.Layer.Secondary {
+		return ""
+	}
+
+	if firstLine || part.Abbreviation == "" {
+		return part.Name
+	}
+	return part.Abbreviation
+}
+
+// renderParts renders the score with more than one part (e.g. SATB).
+// every line (system) contains one row of each part, the notes in the same beat are aligned vertically.
+// the layout break inside the measure (__layout=br) is only supported on the single part.
+func (si *staffInteractor) renderParts(ctx context.Context, canv canvas.Canvas, parts []musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
+	relativeY := constant.TITLE_Y_POS + header.HEADER_OFFSET
+
+	systems := SplitPartsLines(si.SplitLines(ctx, parts[0]), parts)
+	staffLines := lines.NewLineStaff(timeSignature, keySignature)
+
+	infos := make([]StaffInfo, len(parts))
+	for p := range infos {
+		infos[p].SyllableOffset = map[int]int{}
+	}
+
+	x := staffLines.GetLeftIndentWithTimeSignature()
+	for i, system := range systems {
+		if len(system[0]) == 0 {
+			continue
+		}
+
+		aligns := make([][][]*entity.NoteRenderer, len(parts))
+		offsets := make([]int, len(parts))
+		for p, measures := range system {
+			if len(measures) == 0 {
+				continue
+			}
+
+			// only the leading part carries the other verses
+			partMetadata := metadata
+			if p > 0 {
+				partMetadata = nil
+			}
+
+			data := StaffData{
+				TimeSig:       timeSignature,
+				KeySig:        keySignature,
+				SyllableCount: infos[p].SyllableCount,
+				IndexStart:    infos[p].EndIndex,
+				ReffAtStart:   infos[p].StartRenderOtherNotes,
+				RepeatInfo:    infos[p].RepeatInfo,
+
+				SyllableOffset: infos[p].SyllableOffset,
+				OpenWedges:     infos[p].OpenWedges,
+				Clef:           infos[p].Clef,
+
+				Hymn:   metadata.HymnKey(),
+				PartID: parts[p].ID,
+			}
+
+			var info StaffInfo
+			aligns[p], offsets[p], info = si.prepareStaff(ctx, x, relativeY, i, partMetadata, measures, data)
+			info.RepeatInfo = append(data.RepeatInfo, info.RepeatInfo...)
+			infos[p] = info
+		}
+
+		AlignParts(ctx, timeSignature, aligns)
+
+		canv.Group("class='system'", fmt.Sprintf("number='%d'", i+1))
+		for p := range system {
+			if len(aligns[p]) == 0 {
+				continue
+			}
+
+			rowCtx := ctx
+			secondary := parts[p].Layer != nil && parts[p].Layer.Secondary
+			if secondary {
+				// the lower voice is stacked below the upper voice, without its own gregorian staff
+				prm, _ := params.GetParamFromContext(ctx)
+				rowParam := *prm
+				rowParam.DisableGregorian = true
+				rowCtx = params.NewParamContext(ctx, &rowParam)
+				relativeY -= VOICE_ROW_OFFSET
+			}
+
+			canv.Group(partAttributes(parts[p])...)
+			staffY := relativeY + offsets[p]
+			infos[p].MarginBottom += si.RenderAlign.RenderWithAlign(rowCtx, canv, i, staffY, timeSignature, keySignature, aligns[p])
+			if label := partLabel(parts[p], i == 0); label != "" {
+				// centered on the gregorian staff
+				canv.Text(constant.LAYOUT_INDENT_LENGTH-PART_LABEL_MARGIN, staffY+(2*lines.STAFF_SPACE_WIDTH)+PART_LABEL_BASELINE, label, `text-anchor="end"`, `style="font-size:9.6px;font-weight:600"`)
+			}
+			canv.Gend()
+
+			if secondary {
+				relativeY += VOICE_ROW_OFFSET + VOICE_ROW_DISTANCE + infos[p].MarginBottom
+				continue
+			}
+
+			relativeY += STAFF_LINE_DISTANCE + STAFF_LINE_SPACING + infos[p].MarginBottom
+		}
+		canv.Gend()
+
+		relativeY += PART_SYSTEM_DISTANCE
+
+		nextMeasureNumber := system[0][0].Number + len(system[0])
+		if i+1 < len(systems) && len(systems[i+1][0]) > 0 {
+			nextMeasureNumber = systems[i+1][0][0].Number
+		}
+		x = staffLines.GetLeftIndent(nextMeasureNumber)
+	}
+
+	return relativeY
+}
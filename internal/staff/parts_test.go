@@ -0,0 +1,123 @@
+package staff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPartsLines(t *testing.T) {
+	lead := musicxml.Part{
+		ID:       "P1",
+		Measures: []musicxml.Measure{{Number: 1}, {Number: 2}, {Number: 3}},
+	}
+	alto := musicxml.Part{
+		ID:       "P2",
+		Measures: []musicxml.Measure{{Number: 1}, {Number: 2}, {Number: 3}},
+	}
+
+	type args struct {
+		leadLines [][]musicxml.Measure
+		parts     []musicxml.Part
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][][]musicxml.Measure
+	}{
+		{
+			name: "follow the lines of the leading part",
+			args: args{
+				leadLines: [][]musicxml.Measure{lead.Measures[:2], lead.Measures[2:]},
+				parts:     []musicxml.Part{lead, alto},
+			},
+			want: [][][]musicxml.Measure{
+				{lead.Measures[:2], alto.Measures[:2]},
+				{lead.Measures[2:], alto.Measures[2:]},
+			},
+		},
+		{
+			name: "the measure is missing on the other part",
+			args: args{
+				leadLines: [][]musicxml.Measure{lead.Measures},
+				parts:     []musicxml.Part{lead, {ID: "P2", Measures: alto.Measures[:2]}},
+			},
+			want: [][][]musicxml.Measure{
+				{lead.Measures, {}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitPartsLines(tt.args.leadLines, tt.args.parts)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAlignParts(t *testing.T) {
+	ctx := context.Background()
+	ts := timesig.TimeSignature{}
+
+	notes := func(xs []int, values []float64) []*entity.NoteRenderer {
+		result := make([]*entity.NoteRenderer, len(xs))
+		for i := range xs {
+			result[i] = &entity.NoteRenderer{PositionX: xs[i], NoteValue: values[i], MeasureNumber: 1}
+		}
+		return result
+	}
+
+	positions := func(aligns [][][]*entity.NoteRenderer) [][]int {
+		result := [][]int{}
+		for _, part := range aligns {
+			xs := []int{}
+			for _, measure := range part {
+				for _, n := range measure {
+					xs = append(xs, n.PositionX)
+				}
+			}
+			result = append(result, xs)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		aligns [][][]*entity.NoteRenderer
+		want   [][]int
+	}{
+		{
+			name: "single part untouched",
+			aligns: [][][]*entity.NoteRenderer{
+				{notes([]int{100, 130}, []float64{1, 1})},
+			},
+			want: [][]int{{100, 130}},
+		},
+		{
+			name: "notes on the same beat are aligned",
+			aligns: [][][]*entity.NoteRenderer{
+				{notes([]int{100, 130}, []float64{1, 1})},
+				{notes([]int{100, 110, 120}, []float64{0.5, 0.5, 1})},
+			},
+			want: [][]int{{100, 130}, {100, 110, 130}},
+		},
+		{
+			name: "different measure count is untouched",
+			aligns: [][][]*entity.NoteRenderer{
+				{notes([]int{100, 130}, []float64{1, 1})},
+				{notes([]int{100}, []float64{1}), notes([]int{120}, []float64{1})},
+			},
+			want: [][]int{{100, 130}, {100, 120}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AlignParts(ctx, ts, tt.aligns)
+			assert.Equal(t, tt.want, positions(tt.aligns))
+		})
+	}
+}
@@ -14,7 +14,26 @@ import (
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 )
 
-func (si *staffInteractor) Render(ctx context.Context, canv canvas.Canvas, part musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
+func (si *staffInteractor) Render(ctx context.Context, canv canvas.Canvas, parts []musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
+	// every voice and chord position is rendered as its own row
+	rows := []musicxml.Part{}
+	for _, part := range parts {
+		rows = append(rows, part.SplitVoices()...)
+	}
+
+	if len(rows) > 1 {
+		return si.renderParts(ctx, canv, rows, keySignature, timeSignature, metadata)
+	}
+
+	part := musicxml.Part{}
+	if len(rows) == 1 {
+		part = rows[0]
+	}
+
+	return si.renderPart(ctx, canv, part, keySignature, timeSignature, metadata)
+}
+
+func (si *staffInteractor) renderPart(ctx context.Context, canv canvas.Canvas, part musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
 
 	relativeY := constant.TITLE_Y_POS + header.HEADER_OFFSET
 
@@ -40,10 +59,15 @@ func (si *staffInteractor) Render(ctx context.Context, canv canvas.Canvas, part
 			RepeatInfo:    info.RepeatInfo,
 
 			SyllableOffset: info.SyllableOffset,
+			OpenWedges:     info.OpenWedges,
+			Clef:           info.Clef,
+
+			Hymn:   metadata.HymnKey(),
+			PartID: part.ID,
 		}
 		info = si.RenderStaff(ctx, canv, x, relativeY, i, metadata, st, data)
 		info.RepeatInfo = append(data.RepeatInfo, info.RepeatInfo...)
-		relativeY = relativeY + STAFF_LINE_DISTANCE + 70 + info.MarginBottom
+		relativeY = relativeY + STAFF_LINE_DISTANCE + STAFF_LINE_SPACING + info.MarginBottom
 
 		nextMeasureNumber := 1 + len(st)
 		if len(st) > 0 {
@@ -77,6 +101,11 @@ func (si *staffInteractor) Render(ctx context.Context, canv canvas.Canvas, part
 			RepeatInfo:    info.RepeatInfo,
 
 			SyllableOffset: info.SyllableOffset,
+			OpenWedges:     info.OpenWedges,
+			Clef:           info.Clef,
+
+			Hymn:   metadata.HymnKey(),
+			PartID: part.ID,
 		}
 		x = staffLines.GetLeftIndent(info.NextLineRenderer[0].MeasureNumber)
 		idx := len(staffes) - 1
@@ -84,7 +113,7 @@ func (si *staffInteractor) Render(ctx context.Context, canv canvas.Canvas, part
 			idx = 1
 		}
 		info = si.RenderStaff(ctx, canv, x, relativeY, idx, metadata, nil, data)
-		relativeY += info.MarginBottom + STAFF_LINE_DISTANCE + 70
+		relativeY += info.MarginBottom + STAFF_LINE_DISTANCE + STAFF_LINE_SPACING
 	}
 
 	return relativeY
@@ -30,7 +30,7 @@ import (
 type Staff interface {
 	RenderStaff(ctx context.Context, canv canvas.Canvas, x, y, staffPos int, metadata *entity.HymnMetaData, measures []musicxml.Measure, data StaffData) StaffInfo
 	SplitLines(ctx context.Context, part musicxml.Part) [][]musicxml.Measure
-	Render(ctx context.Context, canv canvas.Canvas, part musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int
+	Render(ctx context.Context, canv canvas.Canvas, parts []musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int
 }
 
 type staffInteractor struct {
@@ -61,15 +61,25 @@ func NewStaff() Staff {
 	}
 }
 
-func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas, x, y, staffPos int, metadata *entity.HymnMetaData, measures []musicxml.Measure, data StaffData) (staffInfo StaffInfo) {
+func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas, x, y, staffPos int, metadata *entity.HymnMetaData, measures []musicxml.Measure, data StaffData) StaffInfo {
+	align, yOffset, staffInfo := si.prepareStaff(ctx, x, y, staffPos, metadata, measures, data)
+
+	staffInfo.MarginBottom += si.RenderAlign.RenderWithAlign(ctx, canv, staffPos, y+yOffset, data.TimeSig, data.KeySig, align)
+	return staffInfo
+}
+
+// prepareStaff builds the note renderers of the line grouped by measure, without rendering them yet.
+// it returns the y offset needed by the measure texts on top of the line.
+func (si *staffInteractor) prepareStaff(ctx context.Context, x, y, staffPos int, metadata *entity.HymnMetaData, measures []musicxml.Measure, data StaffData) (align [][]*entity.NoteRenderer, yOffsetTotal int, staffInfo StaffInfo) {
 
 	staffInfo.NextLineRenderer = []*entity.NoteRenderer{}
 	linestaff := lines.NewLineStaff(data.TimeSig, data.KeySig)
+	initialY := y
 
 	var lastRightBarlinePosition *barline.CoordinateWithBarline
 	yOffsetRepeat, yOffset := false, false
 	refreinStartNote := false
-	align := [][]*entity.NoteRenderer{}
+	align = [][]*entity.NoteRenderer{}
 
 	pos := 0
 	startSyllable := data.SyllableCount
@@ -77,6 +87,9 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 		align, staffInfo = ProcessPreviousLines(data.PrevNotes, data.KeySig, y)
 		pos = data.PrevNotes[len(data.PrevNotes)-1].IndexPosition + 1
 	}
+	staffInfo.OpenWedges = data.OpenWedges
+	staffInfo.Clef = data.Clef
+
 	for mi, measure := range measures {
 
 		mSyllcount := 0
@@ -86,6 +99,14 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 
 		currTimesig := data.TimeSig.GetTimesignatureOnMeasure(ctx, measure.Number)
 		currKeySig := data.KeySig.GetKeyOnMeasure(ctx, measure.Number)
+		// the key change is marked once, on the first note of the measure
+		keyChange := ""
+		if currKeySig.IsChanged() {
+			keyChange = currKeySig.String()
+		}
+		if clef := measure.ClefAt(0); clef != nil {
+			staffInfo.Clef = clef
+		}
 
 		alignMeasures := []*entity.NoteRenderer{}
 
@@ -110,6 +131,14 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 				continue
 			}
 
+			// the key or the clef changed in the middle of the measure is marked on the first note after it
+			if noteKey := data.KeySig.GetKeyAt(ctx, measure.Number, note.Element); noteKey.Measure != currKeySig.Measure || noteKey.Element != currKeySig.Element {
+				currKeySig, keyChange = noteKey, noteKey.String()
+			}
+			if clef := measure.ClefAt(note.Element); clef != nil {
+				staffInfo.Clef = clef
+			}
+
 			n, octave, strikethrough := moveabledo.GetNumberedNotation(currKeySig, note)
 			noteLength := data.TimeSig.GetNoteLength(ctx, measure.Number, note)
 
@@ -134,26 +163,66 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 			}
 			renderer := &entity.NoteRenderer{
 				UUID:      uuid.New().String(),
+				NoteID:    entity.NoteID(data.Hymn, data.PartID, measure.Number, note.Element),
 				PositionX: x, PositionY: int(y),
 				Note: n, NoteLength: note.Type, Octave: octave,
 				Strikethrough: strikethrough, NoteValue: noteLength,
 				IsRest: (note.Rest != nil),
 
-				Beam:          map[int]entity.Beam{},
-				IsNewLine:     measure.NewLineIndex[notePos],
-				MeasureNumber: measure.Number,
+				Beam:            map[int]entity.Beam{},
+				IsNewLine:       measure.NewLineIndex[notePos],
+				MeasureNumber:   measure.Number,
+				IsPickupMeasure: measure.IsPickup(),
 
 				AbsoluteNote: note.Pitch.Step, AbsoluteOctave: note.Pitch.Octave,
 				AbsoluteAccidental: note.Accidental,
 				TimeModifications:  note.TimeModification,
 
+				Dynamics: note.Dynamics, Wedges: note.Wedges,
+				Clef: staffInfo.Clef,
+
 				LeadingHeader: measure.PrefixHeader[notePos],
 				IndexPosition: pos + note.IndexPosition + data.IndexStart,
 			}
 
+			if keyChange != "" {
+				renderer.KeyChange, keyChange = keyChange, ""
+			}
+
+			if note.Harmony != nil {
+				renderer.ChordSymbol = note.Harmony.String()
+				if p, ok := params.GetParamFromContext(ctx); ok && p.NumberedChord {
+					renderer.ChordSymbol = moveabledo.GetNumberedChord(currKeySig, *note.Harmony)
+				}
+			}
+
+			for wi, wedge := range renderer.Wedges {
+				switch wedge.Type {
+				case musicxml.WedgeTypeCrescendo, musicxml.WedgeTypeDiminuendo:
+					if staffInfo.OpenWedges == nil {
+						staffInfo.OpenWedges = map[int]musicxml.WedgeType{}
+					}
+					staffInfo.OpenWedges[wedge.Number] = wedge.Type
+				case musicxml.WedgeTypeStop:
+					renderer.Wedges[wi].Opening = staffInfo.OpenWedges[wedge.Number]
+					delete(staffInfo.OpenWedges, wedge.Number)
+				}
+			}
+
 			// log.Println(measure.Number, pos+note.IndexPosition+data.IndexStart, data.IndexStart, pos, note.IndexPosition)
 			staffInfo.EndIndex = pos + note.IndexPosition + data.IndexStart
 
+			for _, grace := range note.GraceNotes {
+				gn, gOctave, gStrikethrough := moveabledo.GetNumberedNotation(currKeySig, grace)
+				renderer.GraceNotes = append(renderer.GraceNotes, entity.GraceNote{
+					Note: gn, Octave: gOctave, Strikethrough: gStrikethrough,
+					Slash: grace.Grace.Slash == "yes",
+
+					AbsoluteNote: grace.Pitch.Step, AbsoluteOctave: grace.Pitch.Octave,
+					AbsoluteAccidental: grace.Accidental,
+				})
+			}
+
 			if note.Notations != nil && note.Notations.Fermata != nil {
 				renderer.Fermata = note.Notations.Fermata
 			}
@@ -168,7 +237,7 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 
 				isRefrein := si.StaffText.NoteHasText(renderer.MeasureText, text.DEFAULT_TEXT_REFREIN)
 
-				if hasMeasureText && isRefrein && renderer.MeasureNumber == 1 && notePos == 0 {
+				if hasMeasureText && isRefrein && renderer.MeasureNumber <= 1 && notePos == 0 {
 					staffInfo.StartRenderOtherNotes = false
 				}
 
@@ -222,7 +291,7 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 		}
 
 		// this is happens on the measure note render level.
-		if (data.ReffAtStart || staffInfo.StartRenderOtherNotes) || (measures[0].Number == 1 && !refreinStartNote) {
+		if (data.ReffAtStart || staffInfo.StartRenderOtherNotes) || (measures[0].Number <= 1 && !refreinStartNote) {
 			start := startSyllable
 
 			repeatInfo := data.RepeatInfo
@@ -235,14 +304,17 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 				repeatInfo = staffInfo.RepeatInfo
 			}
 
-			matcher := si.SyllableMatch
-			if p, ok := params.GetParamFromContext(ctx); ok && p.Diagnostic != nil {
-				matcher = diagnostics.GetVerseDiagnostic(matcher)
-			}
-			var marginBottom int
-			staffInfo.SyllableOffset, marginBottom = matcher.LoadOtherVerse(ctx, notes, metadata, start, data.SyllableOffset, repeatInfo)
-			if staffInfo.MarginBottom < marginBottom {
-				staffInfo.MarginBottom = marginBottom
+			// the other verses only follow the leading part, the other parts are rendered without metadata
+			if metadata != nil {
+				matcher := si.SyllableMatch
+				if p, ok := params.GetParamFromContext(ctx); ok && p.Diagnostic != nil {
+					matcher = diagnostics.GetVerseDiagnostic(matcher)
+				}
+				var marginBottom int
+				staffInfo.SyllableOffset, marginBottom = matcher.LoadOtherVerse(ctx, notes, metadata, start, data.SyllableOffset, repeatInfo)
+				if staffInfo.MarginBottom < marginBottom {
+					staffInfo.MarginBottom = marginBottom
+				}
 			}
 			startSyllable += mSyllcount
 			staffInfo.StartRenderOtherNotes = true
@@ -336,7 +408,7 @@ func (si *staffInteractor) RenderStaff(ctx context.Context, canv canvas.Canvas,
 		}
 	}
 
-	staffInfo.MarginBottom += si.RenderAlign.RenderWithAlign(ctx, canv, staffPos, y, data.TimeSig, data.KeySig, align)
+	yOffsetTotal = y - initialY
 	staffInfo.SyllableCount += startSyllable
 
 	return
@@ -40,17 +40,17 @@ func (m *MockStaff) EXPECT() *MockStaffMockRecorder {
 }
 
 // Render mocks base method.
-func (m *MockStaff) Render(ctx context.Context, canv canvas.Canvas, part musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
+func (m *MockStaff) Render(ctx context.Context, canv canvas.Canvas, parts []musicxml.Part, keySignature keysig.KeySignature, timeSignature timesig.TimeSignature, metadata *entity.HymnMetaData) int {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Render", ctx, canv, part, keySignature, timeSignature, metadata)
+	ret := m.ctrl.Call(m, "Render", ctx, canv, parts, keySignature, timeSignature, metadata)
 	ret0, _ := ret[0].(int)
 	return ret0
 }
 
 // Render indicates an expected call of Render.
-func (mr *MockStaffMockRecorder) Render(ctx, canv, part, keySignature, timeSignature, metadata interface{}) *gomock.Call {
+func (mr *MockStaffMockRecorder) Render(ctx, canv, parts, keySignature, timeSignature, metadata interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockStaff)(nil).Render), ctx, canv, part, keySignature, timeSignature, metadata)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockStaff)(nil).Render), ctx, canv, parts, keySignature, timeSignature, metadata)
 }
 
 // RenderStaff mocks base method.
@@ -67,20 +67,6 @@ func (mr *MockStaffMockRecorder) RenderStaff(ctx, canv, x, y, staffPos, metadata
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderStaff", reflect.TypeOf((*MockStaff)(nil).RenderStaff), ctx, canv, x, y, staffPos, metadata, measures, data)
 }
 
-// SetMeasureTextRenderer mocks base method.
-func (m *MockStaff) SetMeasureTextRenderer(noteRenderer *entity.NoteRenderer, note musicxml.Note, directionDashses map[int]musicxml.DirectionDashesType, isLastNote bool) bool {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetMeasureTextRenderer", noteRenderer, note, directionDashses, isLastNote)
-	ret0, _ := ret[0].(bool)
-	return ret0
-}
-
-// SetMeasureTextRenderer indicates an expected call of SetMeasureTextRenderer.
-func (mr *MockStaffMockRecorder) SetMeasureTextRenderer(noteRenderer, note, directionDashses, isLastNote interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMeasureTextRenderer", reflect.TypeOf((*MockStaff)(nil).SetMeasureTextRenderer), noteRenderer, note, directionDashses, isLastNote)
-}
-
 // SplitLines mocks base method.
 func (m *MockStaff) SplitLines(ctx context.Context, part musicxml.Part) [][]musicxml.Measure {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package staff
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
 	reflect "reflect"
 	"testing"
@@ -75,9 +76,11 @@ func Test_staffInteractor_RenderStaff(t *testing.T) {
 			},
 			Appendix: []musicxml.Element{
 				{
+					XMLName: xml.Name{Local: musicxml.ElementDirection},
 					Content: `<direction-type><words>Refrein</words></direction-type>`,
 				},
 				{
+					XMLName: xml.Name{Local: musicxml.ElementNote},
 					Content: `<pitch><step>A</step><octave>4</octave></pitch><duration>2</duration><type>quarter</type><lyric number="1"><syllabic>begin</syllabic><text>Ha</text></lyric>`,
 				},
 			},
@@ -102,9 +105,11 @@ func Test_staffInteractor_RenderStaff(t *testing.T) {
 			},
 			Appendix: []musicxml.Element{
 				{
+					XMLName: xml.Name{Local: musicxml.ElementNote},
 					Content: `<pitch><step>F</step><octave>5</octave></pitch><duration>4</duration><tie type="start"/><type>half</type><notations><tied type="start"/><slur type="start" number="1"/></notations><lyric number="1"><syllabic>begin</syllabic><text>Da</text></lyric>`,
 				},
 				{
+					XMLName: xml.Name{Local: musicxml.ElementNote},
 					Content: `<pitch><step>F</step><octave>5</octave></pitch><duration>1</duration><tie type="stop"/><type>eighth</type><notations><tied type="stop"/></notations>`,
 				},
 			},
@@ -127,6 +132,7 @@ func Test_staffInteractor_RenderStaff(t *testing.T) {
 	getNote := func(appx []musicxml.Element, i int) musicxml.Note {
 		n, _ := appx[i].ParseAsNote()
 		n.IndexPosition = i
+		n.Element = i
 		if i > 0 {
 			d, err := appx[i-1].ParseAsDirection()
 			if err == nil && len(d.DirectionType) > 0 {
@@ -22,3 +22,26 @@ const (
 	DEFAULT_TEXT_REFREIN = "Refrein"
 	DEFAULT_TEXT_FINE    = "Fine"
 )
+
+const (
+	// the space between the numbered notes and the lyric reserved for the dynamics and the hairpins,
+	// below the lower octave dots
+	EXPRESSION_MARGIN = 14
+
+	DYNAMICS_Y_OFFSET   = 18
+	DYNAMICS_CHAR_WIDTH = 6
+	DYNAMICS_STYLE      = `style="font-size:9.6px;font-style:italic;font-weight:bold;font-family:serif"`
+
+	WEDGE_Y_OFFSET     = 14
+	WEDGE_HALF_OPENING = 3
+	WEDGE_STYLE        = "fill:none;stroke:#000000;stroke-linecap:round;stroke-width:0.8"
+)
+
+const (
+	SEGNO_HEX = `&#xF08A;`
+	CODA_HEX  = `&#xF089;`
+
+	NAVIGATION_SYMBOL_WIDTH    = 12
+	NAVIGATION_SYMBOL_Y_OFFSET = 6
+	NAVIGATION_SYMBOL_STYLE    = `style="font-family:mozart11;font-size:24px"`
+)
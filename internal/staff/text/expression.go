@@ -0,0 +1,99 @@
+package text
+
+import (
+	"context"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/constant"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+type wedgeSpan struct {
+	wedgeType musicxml.WedgeType
+	start     int
+	end       int
+}
+
+// HasExpression tells whether the line has any dynamics or hairpin, the lyric is moved down to make room for them
+func HasExpression(notes []*entity.NoteRenderer) bool {
+	for _, note := range notes {
+		if note.Dynamics != "" || len(note.Wedges) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderExpression renders the dynamics and the hairpins of the line below the numbered notes.
+// the hairpin that is not closed on the line is extended to the last note.
+func (ti *textInteractor) RenderExpression(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer) {
+	spans := []wedgeSpan{}
+	open := map[int]int{}
+
+	lineStart, lineEnd := -1, 0
+	for _, note := range notes {
+		if note.Barline != nil || note.IsDotted {
+			continue
+		}
+		if lineStart < 0 {
+			lineStart = note.PositionX
+		}
+		lineEnd = note.PositionX + constant.LOWERCASE_LENGTH
+	}
+
+	for _, note := range notes {
+		if note.Dynamics != "" {
+			canv.Text(note.PositionX, y+DYNAMICS_Y_OFFSET, note.Dynamics, DYNAMICS_STYLE)
+		}
+
+		for _, wedge := range note.Wedges {
+			switch wedge.Type {
+			case musicxml.WedgeTypeCrescendo, musicxml.WedgeTypeDiminuendo:
+				start := note.PositionX
+				if note.Dynamics != "" {
+					start += len(note.Dynamics)*DYNAMICS_CHAR_WIDTH + 2
+				}
+				open[wedge.Number] = len(spans)
+				spans = append(spans, wedgeSpan{wedgeType: wedge.Type, start: start, end: lineEnd})
+
+			case musicxml.WedgeTypeStop:
+				end := note.PositionX - 4
+				if wedge.Trailing {
+					end = note.PositionX + constant.LOWERCASE_LENGTH
+				}
+
+				idx, ok := open[wedge.Number]
+				if !ok {
+					// started on the previous line
+					spans = append(spans, wedgeSpan{wedgeType: wedge.Opening, start: lineStart, end: end})
+					continue
+				}
+				spans[idx].end = end
+				delete(open, wedge.Number)
+			}
+		}
+	}
+
+	if len(spans) == 0 {
+		return
+	}
+
+	canv.Group(`class="hairpins"`)
+	center := y + WEDGE_Y_OFFSET
+	for _, span := range spans {
+		if span.end <= span.start {
+			continue
+		}
+
+		switch span.wedgeType {
+		case musicxml.WedgeTypeCrescendo:
+			canv.Line(span.start, center, span.end, center-WEDGE_HALF_OPENING, WEDGE_STYLE)
+			canv.Line(span.start, center, span.end, center+WEDGE_HALF_OPENING, WEDGE_STYLE)
+		case musicxml.WedgeTypeDiminuendo:
+			canv.Line(span.start, center-WEDGE_HALF_OPENING, span.end, center, WEDGE_STYLE)
+			canv.Line(span.start, center+WEDGE_HALF_OPENING, span.end, center, WEDGE_STYLE)
+		}
+	}
+	canv.Gend()
+}
@@ -0,0 +1,98 @@
+package text
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_textInteractor_RenderExpression(t *testing.T) {
+	tests := []struct {
+		name  string
+		y     int
+		canv  func() *canvas.MockCanvasTestify
+		notes []*entity.NoteRenderer
+	}{
+		{
+			name:  "no expression",
+			y:     100,
+			notes: []*entity.NoteRenderer{{PositionX: 100}},
+			canv: func() *canvas.MockCanvasTestify {
+				return canvas.NewMockCanvasTestify(t)
+			},
+		},
+		{
+			name: "dynamics and crescendo",
+			y:    100,
+			notes: []*entity.NoteRenderer{
+				{
+					PositionX: 100,
+					Dynamics:  "p",
+					Wedges:    []musicxml.Wedge{{Type: musicxml.WedgeTypeCrescendo, Number: 1}},
+				},
+				{PositionX: 130},
+				{
+					PositionX: 160,
+					Wedges:    []musicxml.Wedge{{Type: musicxml.WedgeTypeStop, Number: 1}},
+				},
+			},
+			canv: func() *canvas.MockCanvasTestify {
+				res := canvas.NewMockCanvasTestify(t)
+				res.EXPECT().Text(100, 118, "p", []string{DYNAMICS_STYLE})
+				res.EXPECT().Group([]string{`class="hairpins"`})
+				res.EXPECT().Line(108, 114, 156, 111, []string{WEDGE_STYLE})
+				res.EXPECT().Line(108, 114, 156, 117, []string{WEDGE_STYLE})
+				res.EXPECT().Gend()
+				return res
+			},
+		},
+		{
+			name: "diminuendo from the previous line",
+			y:    100,
+			notes: []*entity.NoteRenderer{
+				{PositionX: 100},
+				{
+					PositionX: 130,
+					Wedges:    []musicxml.Wedge{{Type: musicxml.WedgeTypeStop, Number: 1, Trailing: true, Opening: musicxml.WedgeTypeDiminuendo}},
+				},
+			},
+			canv: func() *canvas.MockCanvasTestify {
+				res := canvas.NewMockCanvasTestify(t)
+				res.EXPECT().Group([]string{`class="hairpins"`})
+				res.EXPECT().Line(100, 111, 145, 114, []string{WEDGE_STYLE})
+				res.EXPECT().Line(100, 117, 145, 114, []string{WEDGE_STYLE})
+				res.EXPECT().Gend()
+				return res
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ti textInteractor
+			canv := tt.canv()
+			ti.RenderExpression(context.Background(), tt.y, canv, tt.notes)
+			canv.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHasExpression(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes []*entity.NoteRenderer
+		want  bool
+	}{
+		{name: "no expression", notes: []*entity.NoteRenderer{{}, {Note: 1}}},
+		{name: "dynamics", notes: []*entity.NoteRenderer{{}, {Dynamics: "mf"}}, want: true},
+		{name: "hairpin stop", notes: []*entity.NoteRenderer{{Wedges: []musicxml.Wedge{{Type: musicxml.WedgeTypeStop}}}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasExpression(tt.notes))
+		})
+	}
+}
@@ -28,7 +28,9 @@ func GetTextMarginBottom(stafflines lines.LineStaff, notes []*entity.NoteRendere
 		}
 		textWidth := 0
 		def, ok := defaultTextWidth[t.Text]
-		if ok {
+		if t.Symbol != "" {
+			textWidth = NAVIGATION_SYMBOL_WIDTH
+		} else if ok {
 			textWidth = def
 		} else {
 			for _, c := range t.Text {
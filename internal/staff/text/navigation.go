@@ -0,0 +1,24 @@
+package text
+
+import (
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+var navigationHex = map[musicxml.NavigationType]string{
+	musicxml.NavigationSegno: SEGNO_HEX,
+	musicxml.NavigationCoda:  CODA_HEX,
+}
+
+// RenderNavigationSymbol draws the segno or the coda glyph, the position is the bottom left of the sign
+func RenderNavigationSymbol(canv canvas.Canvas, x, y int, symbol musicxml.NavigationType) {
+	glyph, ok := navigationHex[symbol]
+	if !ok {
+		return
+	}
+
+	canv.Group(`class="`+string(symbol)+`"`, NAVIGATION_SYMBOL_STYLE)
+	// the glyph is centered on its baseline
+	canv.TextUnescaped(float64(x), float64(y-NAVIGATION_SYMBOL_Y_OFFSET), glyph)
+	canv.Gend()
+}
@@ -21,6 +21,7 @@ type Text interface {
 	MeasureHasText(measure musicxml.Measure, t string) bool
 	SetMeasureTextRenderer(ctx context.Context, noteRenderer *entity.NoteRenderer, note musicxml.Note, isLastNote bool) bool
 	RenderMeasureText(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer, linestaff ...lines.LineStaff)
+	RenderExpression(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer)
 }
 
 func NewText(l lyric.Lyric) Text {
@@ -82,6 +83,7 @@ func (ti *textInteractor) SetMeasureTextRenderer(ctx context.Context, noteRender
 		noteRenderer.MeasureText = append(noteRenderer.MeasureText, musicxml.MeasureText{
 			Text:      mt.Text,
 			RelativeY: mt.RelativeY, TextAlignment: alignment,
+			Symbol: mt.Symbol,
 		})
 	}
 
@@ -116,6 +118,17 @@ func (ti *textInteractor) RenderMeasureText(ctx context.Context, y int, canv can
 
 			for i, t := range note.MeasureText {
 
+				origPos := (len(note.MeasureText) - 1) * TEXT_BASELINE_DISTANCE
+				if t.Symbol != "" {
+					xPos := note.PositionX
+					if t.TextAlignment == musicxml.TextAlignmentRight {
+						xPos = constant.LAYOUT_WIDTH - constant.LAYOUT_INDENT_LENGTH - NAVIGATION_SYMBOL_WIDTH
+					}
+					yPos := (y - origPos) - offset - TEXT_TO_STAFF_DISTANCE - (i * -TEXT_BASELINE_DISTANCE)
+					RenderNavigationSymbol(canv, xPos, yPos, t.Symbol)
+					continue
+				}
+
 				style := []string{`font-style:italic`}
 				if t.Text != DEFAULT_TEXT_REFREIN && t.Text != DEFAULT_TEXT_FINE {
 					style = append(style, `font-size:10.4px`, `font-weight:bold`)
@@ -126,7 +139,6 @@ func (ti *textInteractor) RenderMeasureText(ctx context.Context, y int, canv can
 					xPos = constant.LAYOUT_WIDTH - constant.LAYOUT_INDENT_LENGTH - int(textLength)
 				}
 
-				origPos := (len(note.MeasureText) - 1) * TEXT_BASELINE_DISTANCE
 				yPos := (y - origPos) - offset - TEXT_TO_STAFF_DISTANCE - (i * -TEXT_BASELINE_DISTANCE)
 				if t.RelativeY < 0 {
 					yPos = y + (i * TEXT_BASELINE_DISTANCE) + (len(note.Lyric) * TEXT_TO_STAFF_DISTANCE) + 20
@@ -164,6 +164,64 @@ func (_c *MockText_NoteHasText_Call) RunAndReturn(run func(measureText []musicxm
 	return _c
 }
 
+// RenderExpression provides a mock function for the type MockText
+func (_mock *MockText) RenderExpression(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer) {
+	_mock.Called(ctx, y, canv, notes)
+	return
+}
+
+// MockText_RenderExpression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenderExpression'
+type MockText_RenderExpression_Call struct {
+	*mock.Call
+}
+
+// RenderExpression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - y int
+//   - canv canvas.Canvas
+//   - notes []*entity.NoteRenderer
+func (_e *MockText_Expecter) RenderExpression(ctx interface{}, y interface{}, canv interface{}, notes interface{}) *MockText_RenderExpression_Call {
+	return &MockText_RenderExpression_Call{Call: _e.mock.On("RenderExpression", ctx, y, canv, notes)}
+}
+
+func (_c *MockText_RenderExpression_Call) Run(run func(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer)) *MockText_RenderExpression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 canvas.Canvas
+		if args[2] != nil {
+			arg2 = args[2].(canvas.Canvas)
+		}
+		var arg3 []*entity.NoteRenderer
+		if args[3] != nil {
+			arg3 = args[3].([]*entity.NoteRenderer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockText_RenderExpression_Call) Return() *MockText_RenderExpression_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockText_RenderExpression_Call) RunAndReturn(run func(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer)) *MockText_RenderExpression_Call {
+	_c.Run(run)
+	return _c
+}
+
 // RenderMeasureText provides a mock function for the type MockText
 func (_mock *MockText) RenderMeasureText(ctx context.Context, y int, canv canvas.Canvas, notes []*entity.NoteRenderer, linestaff ...lines.LineStaff) {
 	if len(linestaff) > 0 {
@@ -187,6 +187,25 @@ func Test_textInteractor_RenderMeasureText(t *testing.T) {
 				return res
 			},
 		},
+		{
+			name: "coda sign",
+			notes: []*entity.NoteRenderer{
+				{
+					MeasureText: []musicxml.MeasureText{
+						{Symbol: musicxml.NavigationCoda},
+					},
+					PositionX: 100,
+				},
+			},
+			y: 100,
+			canv: func() *canvas.MockCanvasTestify {
+				res := canvas.NewMockCanvasTestify(t)
+				res.EXPECT().Group([]string{`class="coda"`, NAVIGATION_SYMBOL_STYLE})
+				res.EXPECT().TextUnescaped(float64(100), float64(69), CODA_HEX)
+				res.EXPECT().Gend()
+				return res
+			},
+		},
 		// with barline ending not default text and right alignment
 		{
 			name: "with barline ending not default text and right alignment",
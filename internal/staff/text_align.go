@@ -0,0 +1,41 @@
+package staff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/plaintext"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/rhythm"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/rhythm/splitter"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/timesig"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
+)
+
+// NewTextStaff creates the staff which writes the lines as the plain text numbered notation into the canvas writer
+func NewTextStaff() Staff {
+	si := NewStaff().(*staffInteractor)
+	si.RenderAlign = NewRenderText()
+	return si
+}
+
+func NewRenderText() RenderStaffWithAlign {
+	return &renderStaffText{
+		Rhythm: rhythm.New(splitter.New()),
+	}
+}
+
+// renderStaffText writes the same note renderers drawn by the aligned svg as the plain text
+type renderStaffText struct {
+	Rhythm rhythm.Rhythm
+}
+
+func (rst *renderStaffText) RenderWithAlign(ctx context.Context, canv canvas.Canvas, staffPos, y int, ts timesig.TimeSignature, ks keysig.KeySignature, noteRenderer [][]*entity.NoteRenderer) int {
+	// the beams are grouped the same way as the svg
+	for _, measure := range noteRenderer {
+		rst.Rhythm.Split(ctx, ts, measure)
+	}
+	fmt.Fprintf(canv.Writer(), "%s\n\n", plaintext.Line(noteRenderer))
+	return 0
+}
@@ -19,6 +19,10 @@ type StaffInfo struct {
 	SyllableCount         int
 	RepeatInfo            []*musicxml.RepeatInfo
 	SyllableOffset        map[int]int
+	// the hairpins not closed yet on the line, by the wedge number
+	OpenWedges map[int]musicxml.WedgeType
+	// the clef active at the end of the line
+	Clef *musicxml.Clef
 }
 
 type StaffData struct {
@@ -31,6 +35,12 @@ type StaffData struct {
 	RepeatInfo    []*musicxml.RepeatInfo
 
 	SyllableOffset map[int]int
+	OpenWedges     map[int]musicxml.WedgeType
+	Clef           *musicxml.Clef
+
+	// identifies the notes of the staff, see entity.NoteID
+	Hymn   string
+	PartID string
 }
 
 const (
@@ -41,4 +51,15 @@ const (
 	MEASURE_TEXT_OFFSET = 15
 
 	STAFF_LINE_DISTANCE = 70
+	// the space reserved below every line, on top of the line distance and the margin bottom of the line
+	STAFF_LINE_SPACING = 70
+
+	// extra space between the systems of the multi parts score
+	PART_SYSTEM_DISTANCE = 20
+	PART_LABEL_MARGIN    = 5
+	PART_LABEL_BASELINE  = 3
+
+	// the lower voice is rendered without the gregorian staff, right below the upper voice
+	VOICE_ROW_OFFSET   = 30
+	VOICE_ROW_DISTANCE = 20
 )
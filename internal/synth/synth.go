@@ -0,0 +1,166 @@
+package synth
+
+import (
+	"math"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+)
+
+const (
+	DEFAULT_SAMPLE_RATE = 22050
+
+	// the peak of the mix, some headroom is left below the full scale
+	PEAK_LEVEL = 0.8
+)
+
+type Voice string
+
+const (
+	VoiceOrgan Voice = "organ"
+	VoicePiano Voice = "piano"
+)
+
+// harmonic is the partial of the additive voice, the multiple of the frequency of the note
+type harmonic struct {
+	multiple  float64
+	amplitude float64
+	// how fast the partial fades, per second. zero keeps it sustained
+	decay float64
+}
+
+// instrument is the additive voice with its envelope
+type instrument struct {
+	harmonics []harmonic
+	// in seconds
+	attack  float64
+	release float64
+}
+
+var instruments = map[Voice]instrument{
+	// the drawbars of the 8', 4', 2 2/3' and 2' with a bit of the 16'
+	VoiceOrgan: {
+		harmonics: []harmonic{
+			{multiple: 0.5, amplitude: 0.15},
+			{multiple: 1, amplitude: 1},
+			{multiple: 2, amplitude: 0.5},
+			{multiple: 3, amplitude: 0.25},
+			{multiple: 4, amplitude: 0.2},
+		},
+		attack:  0.02,
+		release: 0.08,
+	},
+	// the higher partials fade faster than the fundamental
+	VoicePiano: {
+		harmonics: []harmonic{
+			{multiple: 1, amplitude: 1, decay: 1.2},
+			{multiple: 2, amplitude: 0.45, decay: 2},
+			{multiple: 3, amplitude: 0.2, decay: 3},
+			{multiple: 4, amplitude: 0.1, decay: 4},
+			{multiple: 5, amplitude: 0.05, decay: 5},
+		},
+		attack:  0.005,
+		release: 0.15,
+	},
+}
+
+// IsVoice tells whether the voice is available
+func IsVoice(v Voice) bool {
+	_, ok := instruments[v]
+	return ok
+}
+
+// Render synthesizes the score repeated on every verse, the verses are separated by a quarter rest.
+// the samples are mono in the range of -1 to 1
+func Render(score playback.Score, verses int, voice Voice, sampleRate int) []float64 {
+	if verses < 1 {
+		verses = 1
+	}
+	inst, ok := instruments[voice]
+	if !ok {
+		inst = instruments[VoiceOrgan]
+	}
+
+	verseSamples := int(math.Round(score.VerseSeconds() * float64(sampleRate)))
+
+	// every verse is the same, it is synthesized once
+	single := make([]float64, verseSamples+int(inst.release*float64(sampleRate))+1)
+	for _, track := range score.Tracks {
+		for _, n := range track.Notes {
+			start := score.Seconds(n.Start)
+			duration := score.Seconds(n.Start+n.Duration) - start
+			inst.play(single, int(start*float64(sampleRate)), duration, frequency(n.Key), sampleRate)
+		}
+	}
+
+	result := make([]float64, verseSamples*(verses-1)+len(single))
+	for v := 0; v < verses; v++ {
+		offset := v * verseSamples
+		for i, s := range single {
+			result[offset+i] += s
+		}
+	}
+
+	normalize(result)
+	return result
+}
+
+// frequency of the midi key, the A4 is 440 Hz
+func frequency(key int) float64 {
+	return 440 * math.Pow(2, float64(key-69)/12)
+}
+
+// play adds the note into the samples
+func (inst instrument) play(samples []float64, start int, duration float64, freq float64, sampleRate int) {
+	rate := float64(sampleRate)
+	length := int((duration + inst.release) * rate)
+
+	for i := 0; i < length && start+i < len(samples); i++ {
+		if start+i < 0 {
+			continue
+		}
+		t := float64(i) / rate
+
+		envelope := 1.0
+		if t < inst.attack {
+			envelope = t / inst.attack
+		}
+		if t > duration {
+			// the short note is released before its attack is done
+			envelope = math.Min(envelope, 1-(t-duration)/inst.release)
+		}
+		if envelope <= 0 {
+			continue
+		}
+
+		value := 0.0
+		for _, h := range inst.harmonics {
+			f := freq * h.multiple
+			// above the nyquist it aliases
+			if f >= rate/2 {
+				continue
+			}
+			amplitude := h.amplitude
+			if h.decay > 0 {
+				amplitude *= math.Exp(-h.decay * t)
+			}
+			value += amplitude * math.Sin(2*math.Pi*f*t)
+		}
+		samples[start+i] += value * envelope
+	}
+}
+
+// normalize scales the mix to the peak level
+func normalize(samples []float64) {
+	peak := 0.0
+	for _, s := range samples {
+		peak = math.Max(peak, math.Abs(s))
+	}
+	if peak == 0 {
+		return
+	}
+
+	gain := PEAK_LEVEL / peak
+	for i := range samples {
+		samples[i] *= gain
+	}
+}
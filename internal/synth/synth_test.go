@@ -0,0 +1,101 @@
+package synth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/playback"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	// a quarter on 60 bpm is a second
+	score := playback.Score{
+		Tracks: []playback.Track{
+			{Notes: []playback.Note{{Key: 69, Start: 0, Duration: playback.TICKS_PER_QUARTER}}},
+		},
+		Tempos: []playback.Tempo{{Start: 0, BPM: 60}},
+		Length: playback.TICKS_PER_QUARTER,
+	}
+	sampleRate := 1000
+
+	tests := []struct {
+		name   string
+		verses int
+		voice  Voice
+		want   int
+	}{
+		{
+			name:   "single verse, the quarter rest and the release",
+			verses: 1,
+			voice:  VoiceOrgan,
+			want:   2000 + 80 + 1,
+		},
+		{
+			name:   "three verses",
+			verses: 3,
+			voice:  VoicePiano,
+			want:   2000*3 + 150 + 1,
+		},
+		{
+			name:   "unknown voice is the organ, zero verse is played once",
+			verses: 0,
+			voice:  Voice("harp"),
+			want:   2000 + 80 + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := Render(score, tt.verses, tt.voice, sampleRate)
+			assert.Len(t, samples, tt.want)
+
+			peak := 0.0
+			for _, s := range samples {
+				peak = math.Max(peak, math.Abs(s))
+			}
+			assert.InDelta(t, PEAK_LEVEL, peak, 1e-9)
+
+			// the note is silent on the rest between the verses
+			assert.Equal(t, 0.0, samples[1500])
+			if tt.verses > 1 {
+				assert.NotEqual(t, 0.0, samples[2000+100])
+			}
+		})
+	}
+}
+
+func TestRender_Silence(t *testing.T) {
+	samples := Render(playback.Score{Length: playback.TICKS_PER_QUARTER}, 1, VoiceOrgan, 1000)
+	for _, s := range samples {
+		assert.Equal(t, 0.0, s)
+	}
+}
+
+func Test_frequency(t *testing.T) {
+	assert.InDelta(t, 440, frequency(69), 1e-9)
+	assert.InDelta(t, 261.6256, frequency(60), 1e-4)
+	assert.InDelta(t, 880, frequency(81), 1e-9)
+}
+
+func TestWriteWAV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteWAV(buf, []float64{0, 1, -1, 2}, 8000)
+	assert.NoError(t, err)
+
+	result := buf.Bytes()
+	assert.Len(t, result, 44+8)
+	assert.Equal(t, "RIFF", string(result[0:4]))
+	assert.Equal(t, uint32(36+8), binary.LittleEndian.Uint32(result[4:]))
+	assert.Equal(t, "WAVEfmt ", string(result[8:16]))
+	assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(result[22:]))
+	assert.Equal(t, uint32(8000), binary.LittleEndian.Uint32(result[24:]))
+	assert.Equal(t, uint32(16000), binary.LittleEndian.Uint32(result[28:]))
+	assert.Equal(t, "data", string(result[36:40]))
+
+	samples := make([]int16, 4)
+	binary.Read(bytes.NewReader(result[44:]), binary.LittleEndian, samples)
+	// the sample out of the range is clipped
+	assert.Equal(t, []int16{0, math.MaxInt16, -math.MaxInt16, math.MaxInt16}, samples)
+}
@@ -0,0 +1,46 @@
+package synth
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+const (
+	wavFormatPCM     = 1
+	wavBitsPerSample = 16
+)
+
+// WriteWAV writes the samples as the mono 16 bit pcm wav
+func WriteWAV(w io.Writer, samples []float64, sampleRate int) error {
+	dataSize := len(samples) * wavBitsPerSample / 8
+
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+dataSize))
+	copy(header[8:], "WAVE")
+
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], wavFormatPCM)
+	binary.LittleEndian.PutUint16(header[22:], 1)
+	binary.LittleEndian.PutUint32(header[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(sampleRate*wavBitsPerSample/8))
+	binary.LittleEndian.PutUint16(header[32:], wavBitsPerSample/8)
+	binary.LittleEndian.PutUint16(header[34:], wavBitsPerSample)
+
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(dataSize))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	data := make([]byte, dataSize)
+	for i, s := range samples {
+		s = math.Max(-1, math.Min(1, s))
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(int16(math.Round(s*math.MaxInt16))))
+	}
+	_, err := w.Write(data)
+	return err
+}
@@ -51,6 +51,16 @@ func (t *Time) calculateNoteLength(ctx context.Context, note musicxml.Note) floa
 		musicxml.NoteLength16th:    0.25,
 	}
 
+	// the grace note is taken from the time of its main note
+	if note.Grace != nil {
+		return 0
+	}
+
+	// the whole measure rest, without the type
+	if note.Type == "" && note.Rest != nil && note.Rest.Measure == "yes" && t.Beat > 0 {
+		return float64(t.Beat)
+	}
+
 	ratio := float64(1) // beat-type 4
 
 	if t.BeatType == 8 {
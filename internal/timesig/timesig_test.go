@@ -26,6 +26,7 @@ func TestTime_calculateNoteLength(t *testing.T) {
 	//      eight with .          =     3/4 beat
 	// 16th                       =     1/4 beat
 	type fields struct {
+		Beat     int
 		BeatType int
 	}
 
@@ -259,10 +260,37 @@ func TestTime_calculateNoteLength(t *testing.T) {
 			},
 			want: 0.25,
 		},
+		{
+			name: "whole measure rest without type",
+			args: args{
+				note: musicxml.Note{
+					Rest: &musicxml.Rest{Measure: "yes"},
+				},
+			},
+			field: fields{
+				Beat:     6,
+				BeatType: 8,
+			},
+			want: 6,
+		},
+		{
+			name: "grace note",
+			args: args{
+				note: musicxml.Note{
+					Type:  musicxml.NoteLengthEighth,
+					Grace: &musicxml.Grace{Slash: "yes"},
+				},
+			},
+			field: fields{
+				BeatType: 4,
+			},
+			want: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tr := &Time{
+				Beat:     tt.field.Beat,
 				BeatType: tt.field.BeatType,
 			}
 			if got := tr.calculateNoteLength(context.Background(), tt.args.note); got != tt.want {
@@ -0,0 +1,245 @@
+package transpose
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+)
+
+const letters = "CDEFGAB"
+
+// the semitones of the letters from C
+var naturalSemitones = []float64{0, 2, 4, 5, 7, 9, 11}
+
+var accidentalNames = map[float64]musicxml.NoteAccidental{
+	-2: musicxml.NoteAccidentalDoubleFlat,
+	-1: musicxml.NoteAccidentalFlat,
+	0:  musicxml.NoteAccidentalNatural,
+	1:  musicxml.NoteAccidentalSharp,
+	2:  musicxml.NoteAccidentalDoubleSharp,
+}
+
+var (
+	pitchBlock      = regexp.MustCompile(`(?s)<pitch>(.*?)</pitch>`)
+	rootBlock       = regexp.MustCompile(`(?s)<root>(.*?)</root>`)
+	bassBlock       = regexp.MustCompile(`(?s)<bass>(.*?)</bass>`)
+	accidentalValue = regexp.MustCompile(`(<accidental[^>]*>)\s*([a-z-]+)\s*(</accidental>)`)
+	fifthsValue     = regexp.MustCompile(`<fifths>\s*(-?\d+)\s*</fifths>`)
+	keyStepValue    = regexp.MustCompile(`<key-step>\s*([A-G])\s*</key-step>\s*<key-alter>\s*(-?[\d.]+)\s*</key-alter>(\s*<key-accidental[^>]*>[^<]*</key-accidental>)?`)
+)
+
+// Apply transposes every part of the music: the pitch of the notes, the chord symbols and the key signatures.
+// the notes keep their degree on the new key, so the numbered notes stay the same.
+// the key beyond seven accidentals is spelled enharmonically, the notes under the key follow its spelling
+func Apply(music *musicxml.MusicXML, interval Interval) {
+	if interval == (Interval{}) {
+		return
+	}
+
+	for p := range music.Parts {
+		// the interval of the current key, it changes on every key signature
+		current := interval
+		measures := music.Parts[p].Measures
+		for m := range measures {
+			if attr := measures[m].Attribute; attr != nil {
+				transposeKeySignature(attr.Key, interval)
+			}
+			for c := range measures[m].AttributeChanges {
+				transposeKeySignature(measures[m].AttributeChanges[c].Attribute.Key, interval)
+			}
+
+			for e, elmnt := range measures[m].Appendix {
+				switch elmnt.Name() {
+				case musicxml.ElementNote:
+					measures[m].Appendix[e].Content = transposeNote(elmnt.Content, current)
+				case musicxml.ElementHarmony:
+					measures[m].Appendix[e].Content = transposeHarmony(elmnt.Content, current)
+				case musicxml.ElementAttributes:
+					if match := fifthsValue.FindStringSubmatch(elmnt.Content); match != nil {
+						fifths, _ := strconv.Atoi(match[1])
+						current = keyInterval(fifths, interval)
+					}
+					measures[m].Appendix[e].Content = transposeKey(elmnt.Content, current)
+				}
+			}
+		}
+	}
+}
+
+// transposeKeySignature moves the decoded key signature, the same as the key on the content
+func transposeKeySignature(key *musicxml.KeySignature, interval Interval) {
+	if key == nil {
+		return
+	}
+
+	interval = keyInterval(key.Fifth, interval)
+
+	// the do moves with the notes, e.g. C4 down a semitone is B3, the notes keep their octave marks
+	do := keysig.NewKey(key)
+	root := do.GetBasedPitch()
+	rootAlter := float64(strings.Count(root, "#") - strings.Count(root, "b"))
+	_, _, key.DoOctave = transposePitch(root[:1], rootAlter, do.GetDoOctave(), interval)
+
+	key.Fifth += interval.Fifths
+	for i := range key.Steps {
+		if i < len(key.Alters) {
+			key.Steps[i], key.Alters[i], _ = transposePitch(key.Steps[i], key.Alters[i], 4, interval)
+		}
+	}
+}
+
+// keyInterval returns the interval of the key, the enharmonic interval is taken when the key goes beyond seven accidentals.
+// the same interval is used for the notes of the key, so the key and the notes are spelled alike
+func keyInterval(fifths int, interval Interval) Interval {
+	switch result := fifths + interval.Fifths; {
+	case result > 7:
+		return newInterval(interval.Fifths-12, interval.Semitones)
+	case result < -7:
+		return newInterval(interval.Fifths+12, interval.Semitones)
+	}
+	return interval
+}
+
+func transposeKey(content string, interval Interval) string {
+	content = fifthsValue.ReplaceAllStringFunc(content, func(s string) string {
+		fifths, _ := strconv.Atoi(fifthsValue.FindStringSubmatch(s)[1])
+		return fmt.Sprintf("<fifths>%d</fifths>", fifths+interval.Fifths)
+	})
+
+	// the non-traditional key, the written accidental of the letter is dropped, it follows the alter
+	return keyStepValue.ReplaceAllStringFunc(content, func(s string) string {
+		match := keyStepValue.FindStringSubmatch(s)
+		alter, _ := strconv.ParseFloat(match[2], 64)
+		step, alter, _ := transposePitch(match[1], alter, 4, interval)
+		return fmt.Sprintf("<key-step>%s</key-step><key-alter>%s</key-alter>", step, formatAlter(alter))
+	})
+}
+
+func transposeNote(content string, interval Interval) string {
+	var alter float64
+	found := false
+	content = pitchBlock.ReplaceAllStringFunc(content, func(block string) string {
+		step, alter0, octave, ok := readPitch(block, "", true)
+		if !ok {
+			return block
+		}
+		found = true
+
+		step, alter, octave = transposePitch(step, alter0, octave, interval)
+		result := "<pitch><step>" + step + "</step>"
+		if alter != 0 {
+			result += "<alter>" + formatAlter(alter) + "</alter>"
+		}
+		return result + fmt.Sprintf("<octave>%d</octave></pitch>", octave)
+	})
+
+	if !found {
+		return content
+	}
+
+	// the written accidental follows the new alter
+	return accidentalValue.ReplaceAllStringFunc(content, func(s string) string {
+		name, ok := accidentalNames[alter]
+		if !ok {
+			return s
+		}
+		match := accidentalValue.FindStringSubmatch(s)
+		return match[1] + string(name) + match[3]
+	})
+}
+
+func transposeHarmony(content string, interval Interval) string {
+	for _, part := range []struct {
+		block *regexp.Regexp
+		name  string
+	}{
+		{block: rootBlock, name: "root"},
+		{block: bassBlock, name: "bass"},
+	} {
+		name := part.name
+		content = part.block.ReplaceAllStringFunc(content, func(block string) string {
+			step, alter, _, ok := readPitch(block, name+"-", false)
+			if !ok {
+				return block
+			}
+
+			step, alter, _ = transposePitch(step, alter, 4, interval)
+			result := fmt.Sprintf("<%s><%s-step>%s</%s-step>", name, name, step, name)
+			if alter != 0 {
+				result += fmt.Sprintf("<%s-alter>%s</%s-alter>", name, formatAlter(alter), name)
+			}
+			return result + fmt.Sprintf("</%s>", name)
+		})
+	}
+	return content
+}
+
+// readPitch reads the step, the alter and the octave of the block, the tags are prefixed (e.g. root-step)
+func readPitch(block, prefix string, withOctave bool) (step string, alter float64, octave int, ok bool) {
+	step, ok = tagValue(block, prefix+"step")
+	if !ok || len(step) != 1 || !strings.Contains(letters, step) {
+		return "", 0, 0, false
+	}
+
+	if raw, found := tagValue(block, prefix+"alter"); found {
+		alter, _ = strconv.ParseFloat(raw, 64)
+	}
+
+	if withOctave {
+		raw, found := tagValue(block, "octave")
+		if !found {
+			return "", 0, 0, false
+		}
+		octave, _ = strconv.Atoi(raw)
+	}
+	return step, alter, octave, true
+}
+
+func tagValue(block, tag string) (string, bool) {
+	start := strings.Index(block, "<"+tag+">")
+	if start < 0 {
+		return "", false
+	}
+	start += len(tag) + 2
+	end := strings.Index(block[start:], "</"+tag+">")
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(block[start : start+end]), true
+}
+
+// transposePitch moves the letter by the steps, the alter keeps the pitch exactly moved by the semitones
+func transposePitch(step string, alter float64, octave int, interval Interval) (string, float64, int) {
+	letter := strings.Index(letters, step)
+	pitch := float64(octave*12) + naturalSemitones[letter] + alter + float64(interval.Semitones)
+
+	index := octave*7 + letter + interval.Steps
+	newOctave, newLetter := floorDiv(index, 7), mod(index, 7)
+	newAlter := pitch - float64(newOctave*12) - naturalSemitones[newLetter]
+
+	// the triple sharp or flat is spelled by the next letter
+	for math.Abs(newAlter) > 2 {
+		if newAlter > 0 {
+			index++
+		} else {
+			index--
+		}
+		newOctave, newLetter = floorDiv(index, 7), mod(index, 7)
+		newAlter = pitch - float64(newOctave*12) - naturalSemitones[newLetter]
+	}
+
+	return string(letters[newLetter]), newAlter, newOctave
+}
+
+func formatAlter(alter float64) string {
+	return strconv.FormatFloat(alter, 'f', -1, 64)
+}
+
+func floorDiv(a, b int) int {
+	return int(math.Floor(float64(a) / float64(b)))
+}
@@ -0,0 +1,266 @@
+package transpose
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/moveabledo"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+const hymnInF = `<score-partwise>
+	<part id="P1">
+	<measure number="1">
+		<attributes><divisions>1</divisions><key><fifths>-1</fifths></key><time><beats>4</beats><beat-type>4</beat-type></time></attributes>
+		<harmony><root><root-step>F</root-step></root><kind>major</kind><bass><bass-step>A</bass-step></bass></harmony>
+		<note><pitch><step>F</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>B</step><alter>-1</alter><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>B</step><octave>4</octave></pitch><duration>1</duration><accidental>natural</accidental><type>quarter</type></note>
+		<note><pitch><step>E</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+	</measure>
+	<measure number="2">
+		<note><pitch><step>C</step><octave>5</octave></pitch><duration>2</duration><type>half</type></note>
+		<note><rest/><duration>2</duration><type>half</type></note>
+	</measure>
+	</part>
+</score-partwise>`
+
+func TestApply(t *testing.T) {
+	type pitch struct {
+		Step       string
+		Octave     int
+		Accidental musicxml.NoteAccidental
+	}
+
+	tests := []struct {
+		name      string
+		interval  Interval
+		wantFifth int
+		wantNotes []pitch
+		wantChord string
+	}{
+		{
+			name:      "down a tone to Eb",
+			interval:  Interval{Fifths: -2, Steps: -1, Semitones: -2},
+			wantFifth: -3,
+			wantNotes: []pitch{
+				{Step: "E", Octave: 4}, {Step: "A", Octave: 4}, {Step: "A", Octave: 4, Accidental: musicxml.NoteAccidentalNatural}, {Step: "D", Octave: 4},
+				{Step: "B", Octave: 4}, {},
+			},
+			wantChord: "Eb/G",
+		},
+		{
+			name:      "up a minor third to Ab",
+			interval:  Interval{Fifths: -3, Steps: 2, Semitones: 3},
+			wantFifth: -4,
+			wantNotes: []pitch{
+				{Step: "A", Octave: 4}, {Step: "D", Octave: 5}, {Step: "D", Octave: 5, Accidental: musicxml.NoteAccidentalNatural}, {Step: "G", Octave: 4},
+				{Step: "E", Octave: 5}, {},
+			},
+			wantChord: "Ab/C",
+		},
+		{
+			name:      "up a tone to G",
+			interval:  Interval{Fifths: 2, Steps: 1, Semitones: 2},
+			wantFifth: 1,
+			wantNotes: []pitch{
+				{Step: "G", Octave: 4}, {Step: "C", Octave: 5}, {Step: "C", Octave: 5, Accidental: musicxml.NoteAccidentalSharp}, {Step: "F", Octave: 4},
+				{Step: "D", Octave: 5}, {},
+			},
+			wantChord: "G/B",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			original, err := musicxml.Parse([]byte(hymnInF))
+			if !assert.NoError(t, err) {
+				return
+			}
+			music, _ := musicxml.Parse([]byte(hymnInF))
+
+			Apply(&music, tt.interval)
+
+			measures := music.MainPart().Measures
+			assert.Equal(t, tt.wantFifth, measures[0].Attribute.Key.Fifth)
+			assert.Contains(t, measures[0].Appendix[0].Content, "<fifths>")
+			assert.Equal(t, tt.wantFifth, keysig.NewKeySignature(ctx, measures).Signatures[0].Fifth)
+
+			originalKey := keysig.NewKeySignature(ctx, original.MainPart().Measures).Signatures[0]
+			key := keysig.NewKeySignature(ctx, measures).Signatures[0]
+
+			got := []pitch{}
+			for m := range measures {
+				measures[m].Build()
+				original.Parts[0].Measures[m].Build()
+				for n, note := range measures[m].Notes {
+					got = append(got, pitch{Step: note.Pitch.Step, Octave: note.Pitch.Octave, Accidental: note.Accidental})
+
+					// the numbered notes stay the same
+					wantNumber, wantOctave, wantStrikethrough := moveabledo.GetNumberedNotation(originalKey, original.Parts[0].Measures[m].Notes[n])
+					number, octave, strikethrough := moveabledo.GetNumberedNotation(key, note)
+					assert.Equal(t, wantNumber, number)
+					assert.Equal(t, wantOctave, octave)
+					assert.Equal(t, wantStrikethrough, strikethrough)
+				}
+			}
+			assert.Equal(t, tt.wantNotes, got)
+			assert.Equal(t, tt.wantChord, measures[0].Notes[0].Harmony.String())
+		})
+	}
+}
+
+func TestApply_octave(t *testing.T) {
+	const hymnInC = `<score-partwise>
+	<part id="P1">
+	<measure number="1">
+		<attributes><divisions>1</divisions><key><fifths>0</fifths></key></attributes>
+		<note><pitch><step>B</step><octave>3</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>B</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>D</step><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+	</measure>
+	</part>
+</score-partwise>`
+
+	// 7, 1, 7 and 2' on every key
+	wantOctaves := []int{-1, 0, 0, 1}
+
+	for _, semitones := range []int{-5, -4, -3, -2, -1, 1, 2, 5, 6, 7} {
+		t.Run(strconv.Itoa(semitones), func(t *testing.T) {
+			ctx := context.Background()
+			music, err := musicxml.Parse([]byte(hymnInC))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			measures := music.MainPart().Measures
+			interval, err := Request{Semitones: semitones}.Interval(keysig.NewKeySignature(ctx, measures).Signatures[0])
+			if !assert.NoError(t, err) {
+				return
+			}
+			Apply(&music, interval)
+
+			key := keysig.NewKeySignature(ctx, measures).Signatures[0]
+			measures[0].Build()
+
+			got := []int{}
+			for _, note := range measures[0].Notes {
+				_, octave, _ := moveabledo.GetNumberedNotation(key, note)
+				got = append(got, octave)
+			}
+			assert.Equal(t, wantOctaves, got, "do = %s", key.Key)
+		})
+	}
+}
+
+func Test_transposePitch(t *testing.T) {
+	tests := []struct {
+		name      string
+		step      string
+		alter     float64
+		octave    int
+		interval  Interval
+		wantStep  string
+		wantAlter float64
+		wantOct   int
+	}{
+		{name: "C4 down a tone", step: "C", octave: 4, interval: Interval{Steps: -1, Semitones: -2}, wantStep: "B", wantAlter: -1, wantOct: 3},
+		{name: "B3 up a semitone", step: "B", octave: 3, interval: Interval{Steps: 1, Semitones: 1}, wantStep: "C", wantAlter: 0, wantOct: 4},
+		{name: "the triple sharp is respelled", step: "F", alter: 2, octave: 4, interval: Interval{Steps: 0, Semitones: 1}, wantStep: "G", wantAlter: 1, wantOct: 4},
+		{name: "the quarter tone", step: "E", alter: -0.5, octave: 4, interval: Interval{Steps: 1, Semitones: 2}, wantStep: "F", wantAlter: 0.5, wantOct: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, alter, octave := transposePitch(tt.step, tt.alter, tt.octave, tt.interval)
+			assert.Equal(t, tt.wantStep, step)
+			assert.Equal(t, tt.wantAlter, alter)
+			assert.Equal(t, tt.wantOct, octave)
+		})
+	}
+}
+
+func TestApply_nonTraditionalKey(t *testing.T) {
+	music, err := musicxml.Parse([]byte(`<score-partwise>
+	<part id="P1">
+	<measure number="1">
+		<attributes><key><key-step>B</key-step><key-alter>-1</key-alter><key-accidental>flat</key-accidental><key-step>F</key-step><key-alter>1</key-alter></key></attributes>
+		<note><pitch><step>G</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+	</measure>
+	</part>
+</score-partwise>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	Apply(&music, Interval{Fifths: 2, Steps: 1, Semitones: 2})
+
+	measure := music.MainPart().Measures[0]
+	assert.Equal(t, []string{"C", "G"}, measure.Attribute.Key.Steps)
+	assert.Equal(t, []float64{0, 1}, measure.Attribute.Key.Alters)
+	assert.Contains(t, measure.Appendix[0].Content, "<key-step>C</key-step><key-alter>0</key-alter><key-step>G</key-step><key-alter>1</key-alter>")
+	assert.NotContains(t, measure.Appendix[0].Content, "key-accidental")
+
+	key := keysig.NewKey(measure.Attribute.Key)
+	// the key is guessed again from the letters
+	assert.Equal(t, "C", key.Key)
+	assert.Equal(t, []keysig.KeyAlter{{Step: "G", Alter: 1}}, key.Alters)
+}
+
+func TestApply_keyChangeBeyondSevenAccidentals(t *testing.T) {
+	const score = `<score-partwise>
+	<part id="P1">
+	<measure number="1">
+		<attributes><divisions>1</divisions><key><fifths>0</fifths></key></attributes>
+		<note><pitch><step>E</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+	</measure>
+	<measure number="2">
+		<note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<attributes><key><fifths>-6</fifths></key></attributes>
+		<note><pitch><step>G</step><alter>-1</alter><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+		<note><pitch><step>C</step><alter>-1</alter><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+	</measure>
+	</part>
+</score-partwise>`
+
+	ctx := context.Background()
+	original, err := musicxml.Parse([]byte(score))
+	if !assert.NoError(t, err) {
+		return
+	}
+	music, _ := musicxml.Parse([]byte(score))
+
+	// up a semitone from C is Db, Gb moves to Abb (-11) and is spelled as G instead
+	Apply(&music, Interval{Fifths: -5, Steps: 1, Semitones: 1})
+
+	measures := music.MainPart().Measures
+	assert.Equal(t, -5, measures[0].Attribute.Key.Fifth)
+	if assert.Len(t, measures[1].AttributeChanges, 1) {
+		assert.Equal(t, 1, measures[1].AttributeChanges[0].Attribute.Key.Fifth)
+	}
+	assert.Contains(t, measures[1].Appendix[1].Content, "<fifths>1</fifths>")
+
+	originalKeys := keysig.NewKeySignature(ctx, original.MainPart().Measures)
+	keys := keysig.NewKeySignature(ctx, measures)
+
+	got := []string{}
+	for m := range measures {
+		measures[m].Build()
+		original.Parts[0].Measures[m].Build()
+		for n, note := range measures[m].Notes {
+			got = append(got, note.Pitch.Step)
+
+			// the numbered notes stay the same
+			originalNote := original.Parts[0].Measures[m].Notes[n]
+			wantNumber, _, wantStrikethrough := moveabledo.GetNumberedNotation(originalKeys.GetKeyAt(ctx, measures[m].Number, originalNote.Element), originalNote)
+			number, _, strikethrough := moveabledo.GetNumberedNotation(keys.GetKeyAt(ctx, measures[m].Number, note.Element), note)
+			assert.Equal(t, wantNumber, number)
+			assert.Equal(t, wantStrikethrough, strikethrough)
+		}
+	}
+	assert.Equal(t, []string{"F", "D", "G", "C"}, got)
+	assert.NotContains(t, measures[1].Appendix[2].Content, "<alter>", "G on the key of G")
+}
@@ -0,0 +1,108 @@
+package transpose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+)
+
+// the limit of the transposition by the semitones, an octave up or down
+const MAX_SEMITONES = 12
+
+var ErrInvalidTranspose = errors.New("invalid transpose")
+
+var keyRoot = regexp.MustCompile(`^[A-G][#b]?$`)
+
+// Request is the transposition asked on the render, either by the semitones or to the target key
+type Request struct {
+	Semitones int
+	// the root of the target key on the mode of the hymn, e.g. Bb. empty transposes by the semitones
+	Key string
+}
+
+// ParseRequest parses the semitones (e.g. -2) or the root of the target key (e.g. Bb, f#)
+func ParseRequest(raw string) (Request, error) {
+	raw = strings.TrimSpace(raw)
+	if semitones, err := strconv.Atoi(raw); err == nil {
+		if semitones < -MAX_SEMITONES || semitones > MAX_SEMITONES {
+			return Request{}, fmt.Errorf("%w: %d semitones is out of range", ErrInvalidTranspose, semitones)
+		}
+		return Request{Semitones: semitones}, nil
+	}
+
+	root := strings.NewReplacer("♭", "b", "♯", "#").Replace(raw)
+	if root != "" {
+		root = strings.ToUpper(root[:1]) + root[1:]
+	}
+	if !keyRoot.MatchString(root) {
+		return Request{}, fmt.Errorf("%w: %q is neither the semitones nor the key", ErrInvalidTranspose, raw)
+	}
+	return Request{Key: root}, nil
+}
+
+// Interval is the distance of the transposition. the letter of the note moves by the steps,
+// the pitch moves by the semitones and the key signature moves by the fifths
+type Interval struct {
+	Fifths    int
+	Steps     int
+	Semitones int
+}
+
+// Interval returns the interval of the request from the key.
+// the semitones are spelled with the key of the fewest accidentals, the target key takes the nearest direction
+func (r Request) Interval(from keysig.Key) (Interval, error) {
+	if r.Key == "" {
+		if r.Semitones == 0 {
+			return Interval{}, nil
+		}
+		// the fifths of the new key are kept between Gb (-6) and B (5)
+		fifths := mod(7*r.Semitones+from.Fifth+6, 12) - 6 - from.Fifth
+		return newInterval(fifths, r.Semitones), nil
+	}
+
+	target, ok := from.Mode.GetFifth(r.Key)
+	if !ok {
+		return Interval{}, fmt.Errorf("%w: there is no %s %s key", ErrInvalidTranspose, r.Key, from.Mode.String())
+	}
+	fifths := target - from.Fifth
+	// the tritone goes down
+	return newInterval(fifths, mod(7*fifths+6, 12)-6), nil
+}
+
+// newInterval finds the steps of the interval, a fifth is four steps up
+func newInterval(fifths, semitones int) Interval {
+	steps := mod(4*fifths, 7)
+	steps += 7 * int(math.Round((float64(semitones)*7/12-float64(steps))/7))
+	return Interval{
+		Fifths:    fifths,
+		Steps:     steps,
+		Semitones: semitones,
+	}
+}
+
+// Music transposes the music by the request, the interval is taken from the first key of the main part
+func Music(ctx context.Context, music *musicxml.MusicXML, request Request) error {
+	key := keysig.NewKey(&musicxml.KeySignature{})
+	if ks := keysig.NewKeySignature(ctx, music.MainPart().Measures); len(ks.Signatures) > 0 {
+		key = ks.Signatures[0]
+	}
+
+	interval, err := request.Interval(key)
+	if err != nil {
+		return err
+	}
+
+	Apply(music, interval)
+	return nil
+}
+
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
@@ -0,0 +1,72 @@
+package transpose
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/keysig"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Request
+		wantErr bool
+	}{
+		{name: "down a tone", raw: "-2", want: Request{Semitones: -2}},
+		{name: "up with the sign", raw: "+3", want: Request{Semitones: 3}},
+		{name: "the key", raw: "Bb", want: Request{Key: "Bb"}},
+		{name: "the lowercase key", raw: "f#", want: Request{Key: "F#"}},
+		{name: "the flat sign", raw: "E♭", want: Request{Key: "Eb"}},
+		{name: "out of range", raw: "13", wantErr: true},
+		{name: "not a key", raw: "H", wantErr: true},
+		{name: "empty", raw: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRequest(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTranspose)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRequest_Interval(t *testing.T) {
+	cMajor := keysig.NewKey(&musicxml.KeySignature{Fifth: 0})
+	fMajor := keysig.NewKey(&musicxml.KeySignature{Fifth: -1})
+	eMinor := keysig.NewKey(&musicxml.KeySignature{Fifth: 1, Mode: "minor"})
+
+	tests := []struct {
+		name    string
+		request Request
+		from    keysig.Key
+		want    Interval
+		wantErr bool
+	}{
+		{name: "nothing", request: Request{}, from: cMajor, want: Interval{}},
+		{name: "C down a tone is Bb", request: Request{Semitones: -2}, from: cMajor, want: Interval{Fifths: -2, Steps: -1, Semitones: -2}},
+		{name: "C up a semitone is Db", request: Request{Semitones: 1}, from: cMajor, want: Interval{Fifths: -5, Steps: 1, Semitones: 1}},
+		{name: "F up an octave", request: Request{Semitones: 12}, from: fMajor, want: Interval{Fifths: 0, Steps: 7, Semitones: 12}},
+		{name: "F to D is down", request: Request{Key: "D"}, from: fMajor, want: Interval{Fifths: 3, Steps: -2, Semitones: -3}},
+		{name: "F to Bb is up", request: Request{Key: "Bb"}, from: fMajor, want: Interval{Fifths: -1, Steps: 3, Semitones: 5}},
+		{name: "E minor to D minor", request: Request{Key: "D"}, from: eMinor, want: Interval{Fifths: -2, Steps: -1, Semitones: -2}},
+		{name: "no such key on the mode", request: Request{Key: "Db"}, from: eMinor, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.request.Interval(tt.from)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTranspose)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -38,10 +38,10 @@ func IsPitchEqual(one, two string) bool {
 	return result
 }
 
-// pitchSemitone returns the semitone value (0-11) for a given pitch string.
+// PitchSemitone returns the semitone value (0-11) for a given pitch string.
 // Base note semitones: C=0, D=2, E=4, F=5, G=7, A=9, B=11
 // Accidentals: bb=-2, b=-1, none=0, #=+1, x=+2
-func pitchSemitone(pitch string) int {
+func PitchSemitone(pitch string) int {
 	if len(pitch) == 0 {
 		return -1
 	}
@@ -85,8 +85,8 @@ func ComparePitch(one, two string) int {
 		return 0
 	}
 
-	oneSemitone := pitchSemitone(one)
-	twoSemitone := pitchSemitone(two)
+	oneSemitone := PitchSemitone(one)
+	twoSemitone := PitchSemitone(two)
 
 	if oneSemitone > twoSemitone {
 		return 1
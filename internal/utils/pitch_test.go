@@ -161,9 +161,9 @@ func TestPitchSemitone(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.pitch, func(t *testing.T) {
-			result := pitchSemitone(tt.pitch)
+			result := PitchSemitone(tt.pitch)
 			if result != tt.expected {
-				t.Errorf("pitchSemitone(%q) = %d, want %d", tt.pitch, result, tt.expected)
+				t.Errorf("PitchSemitone(%q) = %d, want %d", tt.pitch, result, tt.expected)
 			}
 		})
 	}
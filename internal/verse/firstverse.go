@@ -35,7 +35,7 @@ func BuildContent(music musicxml.MusicXML, metadata *entity.HymnMetaData) [][]en
 
 	prevTotalLyric := -1
 	wordVerses := map[int]entity.LyricWordVerse{}
-	for _, measure := range music.Part.Measures {
+	for _, measure := range music.MainPart().Measures {
 		measure.Build()
 		for _, note := range measure.Notes {
 			if len(note.Lyric) == 0 {
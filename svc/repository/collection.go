@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Collection is the hymnal, every hymnal has its own musicxml and metadata
+type Collection struct {
+	// the prefix of the url, e.g. kidung-jemaat
+	Slug  string
+	Title string
+	Repo  Repository
+	// the hymnal referenced by the code of the reference column, e.g. nr to nyanyikanlah-kidung-baru
+	References map[string]string
+}
+
+// HymnReference is the same hymn on the other hymnal
+type HymnReference struct {
+	Hymnal string
+	HymnIndicator
+	// empty when the hymn is not found on the hymnal
+	Title string
+}
+
+// Collections is the configured hymnals, in order
+type Collections struct {
+	list   []*Collection
+	bySlug map[string]*Collection
+}
+
+// NewCollections registers the hymnals, the referenced hymnal has to be registered too
+func NewCollections(collections ...*Collection) (*Collections, error) {
+	result := &Collections{
+		list:   collections,
+		bySlug: map[string]*Collection{},
+	}
+
+	for _, collection := range collections {
+		if _, ok := result.bySlug[collection.Slug]; ok {
+			return nil, fmt.Errorf("hymnal %s is registered twice", collection.Slug)
+		}
+		result.bySlug[collection.Slug] = collection
+	}
+
+	for _, collection := range collections {
+		for code, slug := range collection.References {
+			if _, ok := result.bySlug[slug]; !ok {
+				return nil, fmt.Errorf("hymnal %s references %s on %s, which is not registered", collection.Slug, slug, code)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (c *Collections) List() []*Collection {
+	return c.list
+}
+
+func (c *Collections) Get(slug string) (*Collection, bool) {
+	collection, ok := c.bySlug[slug]
+	return collection, ok
+}
+
+// ResolveReferences finds the hymn on the hymnals referenced by the hymn of the hymnal, ordered by the hymnal
+func (c *Collections) ResolveReferences(ctx context.Context, slug string, hymn HymnData) ([]HymnReference, error) {
+	result := []HymnReference{}
+	collection, ok := c.bySlug[slug]
+	if !ok {
+		return result, nil
+	}
+
+	for code, number := range hymn.References() {
+		target, ok := c.bySlug[collection.References[code]]
+		if !ok {
+			// the column is not configured
+			continue
+		}
+
+		reference := HymnReference{
+			Hymnal:        target.Slug,
+			HymnIndicator: HymnIndicator{Number: number},
+		}
+
+		metadata, err := target.Repo.GetHymnMetaData(ctx, number)
+		if err != nil && !errors.Is(err, ErrHymnNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			reference.HymnIndicator = metadata.HymnIndicator
+			reference.Title = metadata.Title
+		}
+
+		result = append(result, reference)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Hymnal < result[j].Hymnal
+	})
+	return result, nil
+}
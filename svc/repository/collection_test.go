@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCollections(t *testing.T) {
+	tests := []struct {
+		name        string
+		collections []*Collection
+		wantErr     bool
+	}{
+		{
+			name: "the reference is registered",
+			collections: []*Collection{
+				{Slug: "kidung-jemaat", References: map[string]string{RefCodeNR: "nyanyikanlah-kidung-baru"}},
+				{Slug: "nyanyikanlah-kidung-baru"},
+			},
+		},
+		{
+			name: "registered twice",
+			collections: []*Collection{
+				{Slug: "kidung-jemaat"},
+				{Slug: "kidung-jemaat"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "the reference is not registered",
+			collections: []*Collection{
+				{Slug: "kidung-jemaat", References: map[string]string{RefCodeBE: "pelengkap-kidung-jemaat"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCollections(tt.collections...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.collections, got.List())
+		})
+	}
+}
+
+func TestCollections_ResolveReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hymn := HymnData{
+		HymnIndicator: HymnIndicator{HymnID: 1, Number: 5},
+		RefNR:         sql.NullInt16{Int16: 12, Valid: true},
+		RefBE:         sql.NullInt16{Int16: 40, Valid: true},
+	}
+
+	t.Run("resolved on the other hymnals", func(t *testing.T) {
+		nkb := NewMockRepository(ctrl)
+		nkb.EXPECT().GetHymnMetaData(gomock.Any(), 12).Return(&HymnMetadata{
+			HymnData: HymnData{HymnIndicator: HymnIndicator{HymnID: 7, Number: 12}, Title: "Dua Belas"},
+		}, nil)
+		pkj := NewMockRepository(ctrl)
+		pkj.EXPECT().GetHymnMetaData(gomock.Any(), 40).Return(nil, ErrHymnNotFound)
+
+		collections, err := NewCollections(
+			&Collection{Slug: "kidung-jemaat", References: map[string]string{RefCodeNR: "nyanyikanlah-kidung-baru", RefCodeBE: "pelengkap-kidung-jemaat"}},
+			&Collection{Slug: "nyanyikanlah-kidung-baru", Repo: nkb},
+			&Collection{Slug: "pelengkap-kidung-jemaat", Repo: pkj},
+		)
+		assert.NoError(t, err)
+
+		got, err := collections.ResolveReferences(context.Background(), "kidung-jemaat", hymn)
+		assert.NoError(t, err)
+		assert.Equal(t, []HymnReference{
+			{Hymnal: "nyanyikanlah-kidung-baru", HymnIndicator: HymnIndicator{HymnID: 7, Number: 12}, Title: "Dua Belas"},
+			{Hymnal: "pelengkap-kidung-jemaat", HymnIndicator: HymnIndicator{Number: 40}},
+		}, got)
+	})
+
+	t.Run("the column is not configured", func(t *testing.T) {
+		collections, err := NewCollections(&Collection{Slug: "kidung-jemaat"})
+		assert.NoError(t, err)
+
+		got, err := collections.ResolveReferences(context.Background(), "kidung-jemaat", hymn)
+		assert.NoError(t, err)
+		assert.Equal(t, []HymnReference{}, got)
+	})
+
+	t.Run("failed to get the hymn", func(t *testing.T) {
+		nkb := NewMockRepository(ctrl)
+		nkb.EXPECT().GetHymnMetaData(gomock.Any(), 12).Return(nil, errors.New("database is locked"))
+
+		collections, err := NewCollections(
+			&Collection{Slug: "kidung-jemaat", References: map[string]string{RefCodeNR: "nyanyikanlah-kidung-baru"}},
+			&Collection{Slug: "nyanyikanlah-kidung-baru", Repo: nkb},
+		)
+		assert.NoError(t, err)
+
+		_, err = collections.ResolveReferences(context.Background(), "kidung-jemaat", hymn)
+		assert.Error(t, err)
+	})
+}
+
+func TestSchema_replacer(t *testing.T) {
+	query := "SELECT * FROM jdy_hymn a JOIN jdy_hymn_verces b JOIN verse_footnotes c JOIN jdy_hymn_tag t JOIN hymn_melody m WHERE hymn_search MATCH ?"
+
+	assert.Equal(t, query, DefaultSchema.replacer().Replace(query))
+	assert.Equal(t, DefaultSchema, NewSchema(""))
+	assert.Equal(t,
+		"SELECT * FROM pkj_hymn a JOIN pkj_hymn_verces b JOIN pkj_verse_footnotes c JOIN pkj_hymn_tag t JOIN pkj_hymn_melody m WHERE pkj_hymn_search MATCH ?",
+		NewSchema("pkj").replacer().Replace(query),
+	)
+}
@@ -7,6 +7,11 @@ import (
 
 var ErrHymnNotFound = errors.New("hymn not found")
 var ErrHymnHasMoreThanOneVariant = errors.New("hymn has more than one variant")
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+var ErrInvalidMelodyQuery = errors.New("invalid melody query")
+
+// the fallback extensions of the musicxml file, in order
+var musicXMLExtensions = []string{".musicxml", ".mxl", ".xml"}
 
 type HymnDB struct {
 	HymnData
@@ -21,6 +26,18 @@ type HymnMetadata struct {
 	VerseFootNotes map[int]map[int]VerseFootNotes
 }
 
+// TotalVerses returns the number of the verses of the hymn.
+// the first verse is written on the musicxml, it is counted when it is not stored
+func (hm *HymnMetadata) TotalVerses() int {
+	if hm == nil {
+		return 1
+	}
+	if _, ok := hm.Verse[1]; ok {
+		return len(hm.Verse)
+	}
+	return len(hm.Verse) + 1
+}
+
 type HymnIndicator struct {
 	HymnID  int            `db:"hymn_id"`
 	Number  int            `db:"hymn_number"`
@@ -40,6 +57,75 @@ type HymnData struct {
 	IsForKids      sql.NullInt16  `db:"kids_starred"`
 }
 
+// the code of the reference columns of the hymn, the hymnal of the code is configured
+const (
+	RefCodeNR = "nr"
+	RefCodeBE = "be"
+)
+
+// References returns the number of the same hymn on the other hymnals, by the code of the column
+func (hd HymnData) References() map[string]int {
+	result := map[string]int{}
+	if hd.RefNR.Valid && hd.RefNR.Int16 > 0 {
+		result[RefCodeNR] = int(hd.RefNR.Int16)
+	}
+	if hd.RefBE.Valid && hd.RefBE.Int16 > 0 {
+		result[RefCodeBE] = int(hd.RefBE.Int16)
+	}
+	return result
+}
+
+// HymnSummary is the hymn on the list of the hymns
+type HymnSummary struct {
+	HymnData
+	// the number of the verses, the same as HymnMetadata.TotalVerses
+	TotalVerses int `db:"total_verses"`
+}
+
+// SearchDocument is the searchable text of the hymn
+type SearchDocument struct {
+	HymnIndicator
+	Title     string
+	FirstLine string
+	Lyric     string
+}
+
+// SearchResult is the hymn matched by the search, the best match first
+type SearchResult struct {
+	HymnIndicator
+	Title     string `db:"title"`
+	FirstLine string `db:"first_line"`
+	// the matched text, the matched words are wrapped with <mark>
+	Snippet string  `db:"snippet"`
+	Rank    float64 `db:"rank"`
+}
+
+// MelodyDocument is the melody of the hymn, encoded for the every way of the matching
+type MelodyDocument struct {
+	HymnIndicator
+	Incipit         string
+	Degrees         string
+	DegreesMerged   string
+	Intervals       string
+	IntervalsMerged string
+}
+
+// MelodyQuery is the encoded melody to find on the hymns
+type MelodyQuery struct {
+	Pattern string
+	// the pattern is the intervals instead of the degrees, the melody matches on any key
+	AnyKey bool
+	// the pattern has the repeated notes merged
+	AnyRhythm bool
+}
+
+// MelodyResult is the hymn whose melody contains the query
+type MelodyResult struct {
+	HymnIndicator
+	Title   string `db:"title"`
+	Incipit string `db:"incipit"`
+}
+
 type HymnVerse struct {
 	VerseID  sql.NullInt32  `db:"verse_id"`
 	Number   sql.NullInt32  `db:"hymn_num"`
@@ -58,3 +144,26 @@ type VerseFootNotes struct {
 	MarkerStyle       sql.NullInt32  `db:"marker_style"`
 	Footnote          sql.NullString `db:"footnote"`
 }
+
+type TagKind string
+
+const (
+	// the theme of the hymn, e.g. advent, communion or baptism
+	TagKindTheme TagKind = "theme"
+	// the bible reference, e.g. Mzm 23:1-6
+	TagKindBible TagKind = "bible"
+	// the sunday or the feast of the church calendar, e.g. advent-1 or good-friday
+	TagKindDay TagKind = "day"
+)
+
+type HymnTag struct {
+	Kind  TagKind `db:"kind"`
+	Value string  `db:"value"`
+}
+
+// TaggedHymn is the hymn with one of its tags
+type TaggedHymn struct {
+	HymnIndicator
+	Title string `db:"title"`
+	HymnTag
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RebuildMelodyIndex replaces the melodies of the hymns
+func (r *repository) RebuildMelodyIndex(ctx context.Context, melodies []MelodyDocument) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{r.query(qryDropMelodyIndex), r.query(qryCreateMelodyIndex)} {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	insert, err := tx.PreparexContext(ctx, sqlx.Rebind(sqlx.QUESTION, r.query(qryInsertMelodyIndex)))
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, melody := range melodies {
+		_, err := insert.ExecContext(ctx, melody.HymnID, melody.Number, melody.Variant, melody.Incipit,
+			melody.Degrees, melody.DegreesMerged, melody.Intervals, melody.IntervalsMerged)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CheckMelodyIndex returns the error when the melody index is not built
+func (r *repository) CheckMelodyIndex(ctx context.Context) error {
+	rows, err := r.db.QueryContext(ctx, r.query(qryCheckMelodyIndex))
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// SearchMelody returns the hymns whose melody contains the pattern
+func (r *repository) SearchMelody(ctx context.Context, query MelodyQuery, limit int) ([]MelodyResult, error) {
+	if query.Pattern == "" {
+		return nil, ErrInvalidMelodyQuery
+	}
+
+	column := "degrees"
+	if query.AnyKey {
+		column = "intervals"
+	}
+	if query.AnyRhythm {
+		column += "_merged"
+	}
+
+	rows := []MelodyResult{}
+	err := r.db.SelectContext(ctx, &rows, sqlx.Rebind(sqlx.QUESTION, fmt.Sprintf(r.query(qrySearchMelody), column)), query.Pattern, limit)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -37,6 +37,30 @@ LEFT JOIN verse_footnotes c
 WHERE a.hymn_number = ?
 	`
 
+	// the verses are counted the same as HymnMetadata.TotalVerses, the first verse is on the musicxml when it is not stored
+	qryListHymns = `
+	SELECT 
+		a.ID as hymn_id,
+		a.hymn_number,
+		a.hymn_variant,
+		a.title,
+		a.footnotes,
+		a.footnotes_title,
+		a.lyric,
+		a.music,
+		a.nr_number,
+		a.be_number,
+		a.copyright,
+		a.kids_starred,
+		COUNT(DISTINCT CASE WHEN b.verse_num <> 1 THEN b.verse_num END) + 1 as total_verses
+	FROM jdy_hymn a 
+	LEFT JOIN jdy_hymn_verces b 
+		ON a.hymn_number = b.hymn_num 
+		AND (a.hymn_variant = b.hymn_variant OR (a.hymn_variant IS NULL AND b.hymn_variant IS NULL))
+	GROUP BY a.ID
+	ORDER BY a.hymn_number, a.hymn_variant
+	`
+
 	qryHymnHasVariant = `
 	SELECT 
 		a.ID as hymn_id,
@@ -73,3 +97,186 @@ const (
 		RETURNING id
 	`
 )
+
+const (
+	// the accents are removed on both the index and the query, e.g. Yésus matches yesus
+	qryCreateSearchIndex = `
+		CREATE VIRTUAL TABLE hymn_search USING fts5(
+			hymn_id UNINDEXED,
+			hymn_number UNINDEXED,
+			hymn_variant UNINDEXED,
+			title,
+			first_line,
+			lyric,
+			tokenize = 'unicode61 remove_diacritics 2'
+		)
+	`
+
+	qryDropSearchIndex = `DROP TABLE IF EXISTS hymn_search`
+
+	// the table is created on the temporary schema and rolled back, it fails when the sqlite is built without the fts5
+	qryProbeFullTextSearch = `CREATE VIRTUAL TABLE temp.fts5_probe USING fts5(content)`
+
+	// fails when the index is not built or the sqlite is built without the fts5
+	qryCheckSearchIndex = `SELECT 1 FROM hymn_search LIMIT 1`
+
+	qryInsertSearchIndex = `
+		INSERT INTO hymn_search
+		(
+			hymn_id,
+			hymn_number,
+			hymn_variant,
+			title,
+			first_line,
+			lyric
+		)
+		VALUES
+		(
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+	`
+
+	// the title weights more than the first line, and the first line more than the rest of the lyric
+	qrySearchHymns = `
+	SELECT 
+		hymn_id,
+		hymn_number,
+		hymn_variant,
+		title,
+		first_line,
+		snippet(hymn_search, -1, '<mark>', '</mark>', '…', 12) as snippet,
+		bm25(hymn_search, 0, 0, 0, 10.0, 5.0, 1.0) as rank
+	FROM 
+		hymn_search
+	WHERE 
+		hymn_search MATCH ?
+	ORDER BY 
+		rank
+	LIMIT ?
+	`
+)
+
+const (
+	// the melodies are wrapped with the spaces, e.g. ' 1 1 5 5 ', so the pattern matches the whole notes
+	qryCreateMelodyIndex = `
+		CREATE TABLE hymn_melody (
+			hymn_id INTEGER NOT NULL,
+			hymn_number INTEGER NOT NULL,
+			hymn_variant TEXT,
+			incipit TEXT NOT NULL,
+			degrees TEXT NOT NULL,
+			degrees_merged TEXT NOT NULL,
+			intervals TEXT NOT NULL,
+			intervals_merged TEXT NOT NULL
+		)
+	`
+
+	qryDropMelodyIndex = `DROP TABLE IF EXISTS hymn_melody`
+
+	qryCheckMelodyIndex = `SELECT 1 FROM hymn_melody LIMIT 1`
+
+	qryInsertMelodyIndex = `
+		INSERT INTO hymn_melody
+		(
+			hymn_id,
+			hymn_number,
+			hymn_variant,
+			incipit,
+			degrees,
+			degrees_merged,
+			intervals,
+			intervals_merged
+		)
+		VALUES
+		(
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?,
+			?
+		)
+	`
+
+	// the column is filled by the kind of the melody query
+	qrySearchMelody = `
+	SELECT 
+		m.hymn_id,
+		m.hymn_number,
+		m.hymn_variant,
+		h.title,
+		m.incipit
+	FROM 
+		hymn_melody m
+	JOIN jdy_hymn h 
+		ON h.ID = m.hymn_id
+	WHERE 
+		instr(m.%s, ?) > 0
+	ORDER BY 
+		m.hymn_number, m.hymn_variant
+	LIMIT ?
+	`
+)
+
+const (
+	qryCreateTagTable = `
+		CREATE TABLE IF NOT EXISTS jdy_hymn_tag (
+			hymn_id INTEGER NOT NULL,
+			kind TEXT NOT NULL,
+			value TEXT NOT NULL,
+			PRIMARY KEY (hymn_id, kind, value)
+		)
+	`
+
+	qryHymnTags = `
+	SELECT 
+		kind,
+		value
+	FROM 
+		jdy_hymn_tag
+	WHERE 
+		hymn_id = ?
+	ORDER BY 
+		kind, value
+	`
+
+	qryDeleteHymnTags = `DELETE FROM jdy_hymn_tag WHERE hymn_id = ?`
+
+	qryInsertHymnTag = `
+		INSERT OR IGNORE INTO jdy_hymn_tag
+		(
+			hymn_id,
+			kind,
+			value
+		)
+		VALUES
+		(
+			?,
+			?,
+			?
+		)
+	`
+
+	// the condition of the tags is appended, one (kind, value) pair for every tag
+	qryHymnsByTags = `
+	SELECT 
+		a.ID as hymn_id,
+		a.hymn_number,
+		a.hymn_variant,
+		a.title,
+		t.kind,
+		t.value
+	FROM jdy_hymn a 
+	JOIN jdy_hymn_tag t 
+		ON t.hymn_id = a.ID
+	WHERE %s
+	ORDER BY a.hymn_number, a.hymn_variant
+	`
+)
@@ -3,9 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"encoding/xml"
 	"io"
 	"os"
+	"path"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
@@ -16,19 +17,43 @@ type Repository interface {
 	GetMusicXML(ctx context.Context, filepath string) (musicxml.MusicXML, error)
 	InsertVerse(ctx context.Context, tx Transactional, hymn, verse, style, col, row int, content string) (int, error)
 	GetHymnVariant(ctx context.Context, hymnNum int) ([]HymnIndicator, error)
+	ListHymns(ctx context.Context) ([]HymnSummary, error)
+	RebuildSearchIndex(ctx context.Context, docs []SearchDocument) error
+	SearchHymns(ctx context.Context, query string, limit int) ([]SearchResult, error)
+	CheckSearchIndex(ctx context.Context) error
+	CheckFullTextSearch(ctx context.Context) error
+	RebuildMelodyIndex(ctx context.Context, melodies []MelodyDocument) error
+	SearchMelody(ctx context.Context, query MelodyQuery, limit int) ([]MelodyResult, error)
+	CheckMelodyIndex(ctx context.Context) error
+	CreateTagTable(ctx context.Context) error
+	GetHymnTags(ctx context.Context, hymnID int) ([]HymnTag, error)
+	SetHymnTags(ctx context.Context, hymnID int, tags []HymnTag) error
+	FindHymnsByTags(ctx context.Context, tags []HymnTag) ([]TaggedHymn, error)
 	StartTransaction(ctx context.Context) (Transactional, error)
 }
 
 type repository struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	tables *strings.Replacer
 }
 
 func New(ctx context.Context, db *sqlx.DB) Repository {
+	return NewWithSchema(ctx, db, DefaultSchema)
+}
+
+// NewWithSchema creates the repository of the hymnal on its own tables
+func NewWithSchema(ctx context.Context, db *sqlx.DB, schema Schema) Repository {
 	return &repository{
-		db: db,
+		db:     db,
+		tables: schema.replacer(),
 	}
 }
 
+// query returns the query on the tables of the hymnal
+func (r *repository) query(qry string) string {
+	return r.tables.Replace(qry)
+}
+
 func fillNull(val int) sql.NullInt32 {
 	result := sql.NullInt32{}
 
@@ -58,7 +83,7 @@ func (r *repository) InsertVerse(ctx context.Context, tx Transactional, hymn, ve
 
 	var newID int
 
-	query := sqlx.Rebind(sqlx.QUESTION, qryInsertVerse)
+	query := sqlx.Rebind(sqlx.QUESTION, r.query(qryInsertVerse))
 	if tx != nil {
 		stx := tx.(*sqlTx)
 		err := stx.tx.QueryRow(query, hymn, verse, content, styleQL, colQL, rowQL).Scan(&newID)
@@ -78,7 +103,7 @@ func (r *repository) InsertVerse(ctx context.Context, tx Transactional, hymn, ve
 
 func (r *repository) GetHymnMetaData(ctx context.Context, hymnNum int, variant ...string) (*HymnMetadata, error) {
 	param := []interface{}{hymnNum}
-	query := qryHymnData
+	query := r.query(qryHymnData)
 	if len(variant) > 0 {
 		// FIXME: properly separate query
 		query += " AND a.hymn_variant = ?"
@@ -125,15 +150,14 @@ func (r *repository) GetHymnMetaData(ctx context.Context, hymnNum int, variant .
 }
 
 func (r *repository) GetMusicXML(ctx context.Context, filepath string) (musicxml.MusicXML, error) {
-	xmlFile, err := os.Open(filepath)
+	xmlFile, err := os.Open(resolveMusicXMLPath(filepath))
 	if err != nil {
 		return musicxml.MusicXML{}, err
 	}
 	defer xmlFile.Close()
 	content, _ := io.ReadAll(xmlFile)
 
-	var music musicxml.MusicXML
-	err = xml.Unmarshal(content, &music)
+	music, err := musicxml.Parse(content)
 	if err != nil {
 		return musicxml.MusicXML{}, err
 	}
@@ -141,8 +165,25 @@ func (r *repository) GetMusicXML(ctx context.Context, filepath string) (musicxml
 	return music, err
 }
 
+// resolveMusicXMLPath looks for the other musicxml extension (compressed .mxl or plain .xml)
+// when the file on the path does not exist
+func resolveMusicXMLPath(filepath string) string {
+	if _, err := os.Stat(filepath); err == nil {
+		return filepath
+	}
+
+	base := strings.TrimSuffix(filepath, path.Ext(filepath))
+	for _, ext := range musicXMLExtensions {
+		if _, err := os.Stat(base + ext); err == nil {
+			return base + ext
+		}
+	}
+
+	return filepath
+}
+
 func (r *repository) GetHymnVariant(ctx context.Context, hymnNum int) ([]HymnIndicator, error) {
-	query := sqlx.Rebind(sqlx.QUESTION, qryHymnHasVariant)
+	query := sqlx.Rebind(sqlx.QUESTION, r.query(qryHymnHasVariant))
 	rows := []HymnIndicator{}
 	err := r.db.Select(&rows, query, hymnNum)
 
@@ -151,3 +192,13 @@ func (r *repository) GetHymnVariant(ctx context.Context, hymnNum int) ([]HymnInd
 	}
 	return rows, err
 }
+
+// ListHymns returns every hymn and its variants, ordered by the number
+func (r *repository) ListHymns(ctx context.Context) ([]HymnSummary, error) {
+	rows := []HymnSummary{}
+	err := r.db.SelectContext(ctx, &rows, r.query(qryListHymns))
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/utils/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `
+	CREATE TABLE jdy_hymn (
+		ID INTEGER PRIMARY KEY,
+		hymn_number INTEGER,
+		hymn_variant TEXT,
+		title TEXT,
+		footnotes TEXT,
+		footnotes_title TEXT,
+		lyric TEXT,
+		music TEXT,
+		nr_number INTEGER,
+		be_number INTEGER,
+		copyright TEXT,
+		kids_starred INTEGER
+	);
+	CREATE TABLE jdy_hymn_verces (
+		ID INTEGER PRIMARY KEY,
+		hymn_num INTEGER,
+		hymn_variant TEXT,
+		verse_num INTEGER,
+		content TEXT,
+		style_row INTEGER,
+		column_pos INTEGER,
+		row_pos INTEGER
+	);
+	CREATE TABLE verse_footnotes (
+		id INTEGER PRIMARY KEY,
+		hymne_num INTEGER,
+		hymne_variant TEXT,
+		verse_num INTEGER,
+		line_pos INTEGER,
+		footnote_marker TEXT,
+		marker_style INTEGER,
+		footnote TEXT
+	);
+	INSERT INTO jdy_hymn (ID, hymn_number, title, lyric, music) VALUES
+		(1, 1, 'No verse', '', ''),
+		(2, 2, 'The first verse is stored', '', ''),
+		(3, 3, 'The verses with a gap', '', '');
+	INSERT INTO jdy_hymn_verces (hymn_num, verse_num, content) VALUES
+		(2, 1, ''), (2, 2, ''), (2, 3, ''),
+		(3, 2, ''), (3, 5, '');
+	INSERT INTO verse_footnotes (hymne_num, verse_num, line_pos, footnote) VALUES
+		(3, 2, 1, 'the first footnote'), (3, 2, 2, 'the second footnote');
+`
+
+func TestRepository_TotalVerses(t *testing.T) {
+	ctx := context.Background()
+	db, err := storage.NewStorage(ctx, ":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+	// the memory database is gone with its connection
+	db.SetMaxOpenConns(1)
+
+	_, err = db.ExecContext(ctx, testSchema)
+	if !assert.NoError(t, err) {
+		return
+	}
+	repo := New(ctx, db)
+
+	hymns, err := repo.ListHymns(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	want := map[int]int{1: 1, 2: 3, 3: 3}
+	assert.Len(t, hymns, len(want))
+	for _, hymn := range hymns {
+		metadata, err := repo.GetHymnMetaData(ctx, hymn.Number)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		// the list and the detail count the same verses
+		assert.Equal(t, want[hymn.Number], hymn.TotalVerses, hymn.Title)
+		assert.Equal(t, want[hymn.Number], metadata.TotalVerses(), hymn.Title)
+	}
+}
+
+func TestRepository_CheckFullTextSearch(t *testing.T) {
+	ctx := context.Background()
+	db, err := storage.NewStorage(ctx, ":memory:")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// the error depends on the build tag, the probe is never kept
+	err = New(ctx, db).CheckFullTextSearch(ctx)
+	if err != nil {
+		assert.Contains(t, err.Error(), "fts5")
+	}
+
+	var tables int
+	assert.NoError(t, db.GetContext(ctx, &tables, `SELECT COUNT(*) FROM sqlite_temp_master WHERE name = 'fts5_probe'`))
+	assert.Equal(t, 0, tables)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import "strings"
+
+// Schema is the tables of the hymnal. the queries are written with the tables of the kidung jemaat
+type Schema struct {
+	Hymn     string
+	Verse    string
+	Footnote string
+	Tag      string
+	Search   string
+	Melody   string
+}
+
+// DefaultSchema is the tables of the kidung jemaat
+var DefaultSchema = Schema{
+	Hymn:     "jdy_hymn",
+	Verse:    "jdy_hymn_verces",
+	Footnote: "verse_footnotes",
+	Tag:      "jdy_hymn_tag",
+	Search:   "hymn_search",
+	Melody:   "hymn_melody",
+}
+
+// NewSchema returns the tables with the same layout of the kidung jemaat, named after the prefix.
+// e.g. pkj_hymn, pkj_hymn_verces and pkj_verse_footnotes. the default schema when the prefix is empty
+func NewSchema(prefix string) Schema {
+	if prefix == "" {
+		return DefaultSchema
+	}
+
+	return Schema{
+		Hymn:     prefix + "_hymn",
+		Verse:    prefix + "_hymn_verces",
+		Footnote: prefix + "_verse_footnotes",
+		Tag:      prefix + "_hymn_tag",
+		Search:   prefix + "_hymn_search",
+		Melody:   prefix + "_hymn_melody",
+	}
+}
+
+// replacer rewrites the tables of the query, the longer names go first
+func (s Schema) replacer() *strings.Replacer {
+	return strings.NewReplacer(
+		DefaultSchema.Verse, s.Verse,
+		DefaultSchema.Tag, s.Tag,
+		DefaultSchema.Hymn, s.Hymn,
+		DefaultSchema.Footnote, s.Footnote,
+		DefaultSchema.Search, s.Search,
+		DefaultSchema.Melody, s.Melody,
+	)
+}
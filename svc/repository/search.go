@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// RebuildSearchIndex replaces the full text index of the hymns with the documents.
+// the sqlite has to be built with the fts5 (go build -tags sqlite_fts5)
+func (r *repository) RebuildSearchIndex(ctx context.Context, docs []SearchDocument) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{r.query(qryDropSearchIndex), r.query(qryCreateSearchIndex)} {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	insert, err := tx.PreparexContext(ctx, sqlx.Rebind(sqlx.QUESTION, r.query(qryInsertSearchIndex)))
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, doc := range docs {
+		_, err := insert.ExecContext(ctx, doc.HymnID, doc.Number, doc.Variant, doc.Title, doc.FirstLine, doc.Lyric)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CheckSearchIndex returns the error when the full text index is not built, or the sqlite is built without the fts5
+func (r *repository) CheckSearchIndex(ctx context.Context) error {
+	rows, err := r.db.QueryContext(ctx, r.query(qryCheckSearchIndex))
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// CheckFullTextSearch returns the error when the sqlite is built without the fts5, nothing is written
+func (r *repository) CheckFullTextSearch(ctx context.Context) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, qryProbeFullTextSearch)
+	return err
+}
+
+// SearchHymns returns the hymns matching every word of the query, the last word is matched as the prefix
+func (r *repository) SearchHymns(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	match := SearchMatch(query)
+	if match == "" {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	rows := []SearchResult{}
+	err := r.db.SelectContext(ctx, &rows, sqlx.Rebind(sqlx.QUESTION, r.query(qrySearchHymns)), match, limit)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SearchMatch turns the query into the fts5 match of the words, the syntax of the fts5 on the query is ignored.
+// e.g. `kasih tu` is matched as `"kasih" "tu"*`
+func SearchMatch(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.Is(unicode.Mn, r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	for i, word := range words {
+		words[i] = `"` + word + `"`
+	}
+	// the user might still be typing the last word
+	words[len(words)-1] += "*"
+
+	return strings.Join(words, " ")
+}
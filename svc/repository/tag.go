@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateTagTable creates the table of the tags of the hymns when it does not exist yet
+func (r *repository) CreateTagTable(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, r.query(qryCreateTagTable))
+	return err
+}
+
+func (r *repository) GetHymnTags(ctx context.Context, hymnID int) ([]HymnTag, error) {
+	rows := []HymnTag{}
+	err := r.db.SelectContext(ctx, &rows, sqlx.Rebind(sqlx.QUESTION, r.query(qryHymnTags)), hymnID)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SetHymnTags replaces every tag of the hymn
+func (r *repository) SetHymnTags(ctx context.Context, hymnID int, tags []HymnTag) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, sqlx.Rebind(sqlx.QUESTION, r.query(qryDeleteHymnTags)), hymnID); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, sqlx.Rebind(sqlx.QUESTION, r.query(qryInsertHymnTag)), hymnID, tag.Kind, tag.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindHymnsByTags returns the hymns having any of the tags, once for every matched tag
+func (r *repository) FindHymnsByTags(ctx context.Context, tags []HymnTag) ([]TaggedHymn, error) {
+	rows := []TaggedHymn{}
+	if len(tags) == 0 {
+		return rows, nil
+	}
+
+	conditions := make([]string, 0, len(tags))
+	params := make([]interface{}, 0, len(tags)*2)
+	for _, tag := range tags {
+		conditions = append(conditions, "(t.kind = ? AND t.value = ?)")
+		params = append(params, tag.Kind, tag.Value)
+	}
+
+	query := fmt.Sprintf(r.query(qryHymnsByTags), strings.Join(conditions, " OR "))
+	err := r.db.SelectContext(ctx, &rows, sqlx.Rebind(sqlx.QUESTION, query), params...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
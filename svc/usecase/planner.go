@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/liturgy"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+)
+
+// the weight of the matched tag, the hymn for the sunday or the feast comes before the hymn for the season
+var tagWeights = map[repository.TagKind]int{
+	repository.TagKindDay:   3,
+	repository.TagKindTheme: 1,
+}
+
+type SuggestedHymn struct {
+	repository.HymnIndicator
+	Title   string
+	Matched []repository.HymnTag
+	Score   int
+}
+
+// Suggestion is the hymns suggested for the day of the church calendar
+type Suggestion struct {
+	Day   liturgy.Day
+	Hymns []SuggestedHymn
+}
+
+type Planner interface {
+	SuggestHymns(ctx context.Context, date time.Time, limit int) (*Suggestion, error)
+}
+
+type plannerInteractor struct {
+	repo repository.Repository
+}
+
+func NewPlanner(repo repository.Repository) Planner {
+	return &plannerInteractor{
+		repo: repo,
+	}
+}
+
+// SuggestHymns returns the hymns tagged with the sunday or the feast of the date, or with the theme of its season
+func (pi *plannerInteractor) SuggestHymns(ctx context.Context, date time.Time, limit int) (*Suggestion, error) {
+	if limit <= 0 || limit > SEARCH_LIMIT {
+		limit = SEARCH_LIMIT
+	}
+
+	day := liturgy.DayOf(date)
+	tags := []repository.HymnTag{}
+	if day.Name != "" {
+		tags = append(tags, repository.HymnTag{Kind: repository.TagKindDay, Value: day.Name})
+	}
+	for _, theme := range day.Themes {
+		tags = append(tags, repository.HymnTag{Kind: repository.TagKindTheme, Value: theme})
+	}
+
+	rows, err := pi.repo.FindHymnsByTags(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	hymns := []SuggestedHymn{}
+	position := map[int]int{}
+	for _, row := range rows {
+		i, ok := position[row.HymnID]
+		if !ok {
+			i = len(hymns)
+			position[row.HymnID] = i
+			hymns = append(hymns, SuggestedHymn{
+				HymnIndicator: row.HymnIndicator,
+				Title:         row.Title,
+				Matched:       []repository.HymnTag{},
+			})
+		}
+
+		hymns[i].Matched = append(hymns[i].Matched, row.HymnTag)
+		hymns[i].Score += tagWeights[row.Kind]
+	}
+
+	// the order of the number is kept on the same score
+	sort.SliceStable(hymns, func(i, j int) bool {
+		return hymns[i].Score > hymns[j].Score
+	})
+	if len(hymns) > limit {
+		hymns = hymns[:limit]
+	}
+
+	return &Suggestion{
+		Day:   day,
+		Hymns: hymns,
+	}, nil
+}
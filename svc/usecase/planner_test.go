@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/liturgy"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanner_SuggestHymns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	christmasEve := time.Date(2026, time.December, 24, 18, 0, 0, 0, time.UTC)
+	tags := []repository.HymnTag{
+		{Kind: repository.TagKindDay, Value: "christmas-eve"},
+		{Kind: repository.TagKindTheme, Value: "advent"},
+		{Kind: repository.TagKindTheme, Value: "christmas"},
+	}
+	advent := repository.HymnIndicator{HymnID: 1, Number: 83}
+	christmas := repository.HymnIndicator{HymnID: 2, Number: 92, Variant: sql.NullString{String: "a", Valid: true}}
+	both := repository.HymnIndicator{HymnID: 3, Number: 120}
+
+	t.Run("the sunday comes first", func(t *testing.T) {
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().FindHymnsByTags(gomock.Any(), tags).Return([]repository.TaggedHymn{
+			{HymnIndicator: advent, Title: "Advent", HymnTag: tags[1]},
+			{HymnIndicator: christmas, Title: "Christmas", HymnTag: tags[2]},
+			{HymnIndicator: both, Title: "Both", HymnTag: tags[0]},
+			{HymnIndicator: both, Title: "Both", HymnTag: tags[2]},
+		}, nil)
+
+		got, err := NewPlanner(repo).SuggestHymns(context.Background(), christmasEve, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, &Suggestion{
+			Day: liturgy.Day{
+				Date:   time.Date(2026, time.December, 24, 0, 0, 0, 0, time.UTC),
+				Season: liturgy.SeasonAdvent,
+				Name:   "christmas-eve",
+				Themes: []string{"advent", "christmas"},
+			},
+			Hymns: []SuggestedHymn{
+				{HymnIndicator: both, Title: "Both", Matched: []repository.HymnTag{tags[0], tags[2]}, Score: 4},
+				{HymnIndicator: advent, Title: "Advent", Matched: []repository.HymnTag{tags[1]}, Score: 1},
+			},
+		}, got)
+	})
+
+	t.Run("the weekday only has the season", func(t *testing.T) {
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().FindHymnsByTags(gomock.Any(), []repository.HymnTag{
+			{Kind: repository.TagKindTheme, Value: "ordinary"},
+		}).Return([]repository.TaggedHymn{}, nil)
+
+		got, err := NewPlanner(repo).SuggestHymns(context.Background(), time.Date(2026, time.July, 15, 0, 0, 0, 0, time.UTC), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []SuggestedHymn{}, got.Hymns)
+	})
+
+	t.Run("failed to find the hymns", func(t *testing.T) {
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().FindHymnsByTags(gomock.Any(), tags).Return(nil, errors.New("no such table: jdy_hymn_tag"))
+
+		got, err := NewPlanner(repo).SuggestHymns(context.Background(), christmasEve, 0)
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/melody"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
+)
+
+// the maximum of the search results
+const SEARCH_LIMIT = 50
+
+type Search interface {
+	BuildIndex(ctx context.Context) error
+	Search(ctx context.Context, query string, limit int) ([]repository.SearchResult, error)
+	SearchMelody(ctx context.Context, query string, anyKey, anyRhythm bool, limit int) ([]repository.MelodyResult, error)
+}
+
+type searchInteractor struct {
+	config config.Config
+	repo   repository.Repository
+}
+
+func NewSearch(config config.Config, repo repository.Repository) Search {
+	return &searchInteractor{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// BuildIndex indexes the title, the lyric and the melody of every hymn, the first verse and the melody
+// are taken from the musicxml and the other verses from the database
+func (si *searchInteractor) BuildIndex(ctx context.Context) error {
+	// without the fts5 neither index is rebuilt, the musicxml are not read either
+	if err := si.repo.CheckFullTextSearch(ctx); err != nil {
+		return err
+	}
+
+	hymns, err := si.repo.ListHymns(ctx)
+	if err != nil {
+		return err
+	}
+
+	docs := make([]repository.SearchDocument, 0, len(hymns))
+	melodies := make([]repository.MelodyDocument, 0, len(hymns))
+	for _, hymn := range hymns {
+		variant := []string{}
+		if hymn.Variant.Valid {
+			variant = append(variant, hymn.Variant.String)
+		}
+
+		lines := []string{}
+		music, err := si.repo.GetMusicXML(ctx, MusicXMLPath(si.config.MusicXML, hymn.Number, variant...))
+		if err != nil {
+			log.Printf("[BuildIndex] the first verse and the melody of %d%s are not indexed, err %s\n", hymn.Number, hymn.Variant.String, err.Error())
+		} else {
+			lines = FirstVerseLines(music)
+			melodies = append(melodies, newMelodyDocument(ctx, hymn.HymnIndicator, music))
+		}
+
+		metadata, err := si.repo.GetHymnMetaData(ctx, hymn.Number, variant...)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, VerseLines(metadata)...)
+
+		doc := repository.SearchDocument{
+			HymnIndicator: hymn.HymnIndicator,
+			Title:         hymn.Title,
+			Lyric:         strings.Join(lines, "\n"),
+		}
+		if len(lines) > 0 {
+			doc.FirstLine = lines[0]
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := si.repo.RebuildMelodyIndex(ctx, melodies); err != nil {
+		return err
+	}
+
+	return si.repo.RebuildSearchIndex(ctx, docs)
+}
+
+func newMelodyDocument(ctx context.Context, hymn repository.HymnIndicator, music musicxml.MusicXML) repository.MelodyDocument {
+	notes := melody.Extract(ctx, music)
+	return repository.MelodyDocument{
+		HymnIndicator:   hymn,
+		Incipit:         melody.Incipit(notes),
+		Degrees:         melody.Degrees(notes, false),
+		DegreesMerged:   melody.Degrees(notes, true),
+		Intervals:       melody.Intervals(notes, false),
+		IntervalsMerged: melody.Intervals(notes, true),
+	}
+}
+
+func (si *searchInteractor) Search(ctx context.Context, query string, limit int) ([]repository.SearchResult, error) {
+	if limit <= 0 || limit > SEARCH_LIMIT {
+		limit = SEARCH_LIMIT
+	}
+	return si.repo.SearchHymns(ctx, query, limit)
+}
+
+// SearchMelody finds the hymns whose melody contains the numbered notation of the query, e.g. `1 1 5 5 6 6 5`.
+// anyKey matches the same tune starting on any note, anyRhythm merges the repeated notes.
+// the query is read on the major scale, the tune on the minor key is written from la (6), e.g. `6 7 1 2 3`
+func (si *searchInteractor) SearchMelody(ctx context.Context, query string, anyKey, anyRhythm bool, limit int) ([]repository.MelodyResult, error) {
+	notes := melody.ParseQuery(query)
+	if len(notes) < melody.MIN_QUERY_NOTES {
+		return nil, repository.ErrInvalidMelodyQuery
+	}
+
+	if limit <= 0 || limit > SEARCH_LIMIT {
+		limit = SEARCH_LIMIT
+	}
+
+	pattern := melody.Degrees(notes, anyRhythm)
+	if anyKey {
+		pattern = melody.Intervals(notes, anyRhythm)
+	}
+
+	return si.repo.SearchMelody(ctx, repository.MelodyQuery{
+		Pattern:   pattern,
+		AnyKey:    anyKey,
+		AnyRhythm: anyRhythm,
+	}, limit)
+}
+
+// FirstVerseLines returns the lyric of the first verse on the main part, one line for every line of the score
+func FirstVerseLines(music musicxml.MusicXML) []string {
+	result := []string{}
+	line := ""
+	for i, measure := range music.MainPart().Measures {
+		if i > 0 && measure.Print != nil && (measure.Print.NewSystem == musicxml.PrintNewSystemTypeYes || measure.Print.NewPage == musicxml.PrintNewSystemTypeYes) {
+			if text := strings.TrimSpace(line); text != "" {
+				result = append(result, text)
+			}
+			line = ""
+		}
+
+		measure.Build()
+		for _, note := range measure.Notes {
+			for _, lyric := range note.Lyric {
+				if lyric.Number > 1 {
+					continue
+				}
+
+				texts := []string{}
+				for _, text := range lyric.Text {
+					texts = append(texts, strings.TrimSpace(text.Value))
+				}
+				line += strings.Join(texts, " ")
+				if lyric.Syllabic != musicxml.LyricSyllabicTypeBegin && lyric.Syllabic != musicxml.LyricSyllabicTypeMiddle {
+					line += " "
+				}
+				break
+			}
+		}
+	}
+
+	if text := strings.TrimSpace(line); text != "" {
+		result = append(result, text)
+	}
+	return result
+}
+
+// VerseLines returns the lyric of the verses on the database, ordered by the verse
+func VerseLines(metadata *repository.HymnMetadata) []string {
+	result := []string{}
+	if metadata == nil {
+		return result
+	}
+
+	numbers := []int{}
+	for number := range metadata.Verse {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	for _, number := range numbers {
+		verse := [][]entity.LyricWordVerse{}
+		if err := json.Unmarshal([]byte(metadata.Verse[number].Content.String), &verse); err != nil {
+			log.Printf("[VerseLines] failed to unmarshal verse %d of %d, err %s\n", number, metadata.Number, err.Error())
+			continue
+		}
+
+		for _, line := range verse {
+			words := []string{}
+			for _, word := range line {
+				if word.ScoreOnly || strings.TrimSpace(word.Word) == "" {
+					continue
+				}
+				words = append(words, strings.TrimSpace(word.Word))
+			}
+			if len(words) > 0 {
+				result = append(result, strings.Join(words, " "))
+			}
+		}
+	}
+	return result
+}
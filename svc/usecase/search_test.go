@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
+	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const searchMusicXML = `<?xml version="1.0" encoding="UTF-8"?>
+<score-partwise version="3.1">
+  <part-list><score-part id="P1"><part-name>Voice</part-name></score-part></part-list>
+  <part id="P1">
+    <measure number="1">
+      <note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>
+        <lyric number="1"><syllabic>single</syllabic><text>Ha</text></lyric></note>
+      <note><pitch><step>D</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>
+        <lyric number="1"><syllabic>begin</syllabic><text>le</text></lyric>
+        <lyric number="2"><syllabic>single</syllabic><text>dua</text></lyric></note>
+      <note><pitch><step>E</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>
+        <lyric number="1"><syllabic>end</syllabic><text>lu</text></lyric></note>
+    </measure>
+    <measure number="2">
+      <print new-system="yes"/>
+      <note><pitch><step>F</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type>
+        <lyric number="1"><syllabic>single</syllabic><text>ya</text></lyric></note>
+      <note><rest/><duration>1</duration><type>quarter</type></note>
+    </measure>
+  </part>
+</score-partwise>`
+
+func TestFirstVerseLines(t *testing.T) {
+	music, err := musicxml.Parse([]byte(searchMusicXML))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Ha lelu", "ya"}, FirstVerseLines(music))
+	assert.Equal(t, []string{}, FirstVerseLines(musicxml.MusicXML{}))
+}
+
+func TestVerseLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *repository.HymnMetadata
+		want     []string
+	}{
+		{
+			name: "no metadata",
+			want: []string{},
+		},
+		{
+			name: "ordered by the verse",
+			metadata: &repository.HymnMetadata{
+				Verse: map[int]repository.HymnVerse{
+					3: {Content: sql.NullString{Valid: true, String: `[[{"word":"Ketiga"},{"word":"baris"}]]`}},
+					2: {Content: sql.NullString{Valid: true, String: `[[{"word":"Kedua"},{"word":"-","score_only":true},{"word":" "}],[{"word":"lagi"}]]`}},
+				},
+			},
+			want: []string{"Kedua", "lagi", "Ketiga baris"},
+		},
+		{
+			name: "invalid content is skipped",
+			metadata: &repository.HymnMetadata{
+				Verse: map[int]repository.HymnVerse{
+					2: {Content: sql.NullString{Valid: true, String: `not json`}},
+				},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerseLines(tt.metadata))
+		})
+	}
+}
+
+func TestSearch_BuildIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	music, err := musicxml.Parse([]byte(searchMusicXML))
+	assert.NoError(t, err)
+
+	cfg := config.Config{MusicXML: config.MusicXMLConfig{Path: "/files/", FilePrefix: "kj"}}
+	hymn := repository.HymnIndicator{HymnID: 7, Number: 3, Variant: sql.NullString{String: "a", Valid: true}}
+
+	t.Run("index the first verse and the other verses", func(t *testing.T) {
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().CheckFullTextSearch(gomock.Any()).Return(nil)
+		repo.EXPECT().ListHymns(gomock.Any()).Return([]repository.HymnSummary{
+			{HymnData: repository.HymnData{HymnIndicator: hymn, Title: "Haleluya"}},
+		}, nil)
+		repo.EXPECT().GetMusicXML(gomock.Any(), "/files/kj-003a.musicxml").Return(music, nil)
+		repo.EXPECT().GetHymnMetaData(gomock.Any(), 3, "a").Return(&repository.HymnMetadata{
+			Verse: map[int]repository.HymnVerse{
+				2: {Content: sql.NullString{Valid: true, String: `[[{"word":"Kedua"}]]`}},
+			},
+		}, nil)
+		repo.EXPECT().RebuildMelodyIndex(gomock.Any(), []repository.MelodyDocument{
+			{
+				HymnIndicator:   hymn,
+				Incipit:         "1 2 3 4",
+				Degrees:         " 1 2 3 4 ",
+				DegreesMerged:   " 1 2 3 4 ",
+				Intervals:       " 2 2 1 ",
+				IntervalsMerged: " 2 2 1 ",
+			},
+		}).Return(nil)
+		repo.EXPECT().RebuildSearchIndex(gomock.Any(), []repository.SearchDocument{
+			{HymnIndicator: hymn, Title: "Haleluya", FirstLine: "Ha lelu", Lyric: "Ha lelu\nya\nKedua"},
+		}).Return(nil)
+
+		assert.NoError(t, NewSearch(cfg, repo).BuildIndex(context.Background()))
+	})
+
+	t.Run("the sqlite is built without the fts5", func(t *testing.T) {
+		// neither index is rebuilt
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().CheckFullTextSearch(gomock.Any()).Return(errors.New("no such module: fts5"))
+
+		assert.Error(t, NewSearch(cfg, repo).BuildIndex(context.Background()))
+	})
+
+	t.Run("failed to list the hymns", func(t *testing.T) {
+		repo := repository.NewMockRepository(ctrl)
+		repo.EXPECT().CheckFullTextSearch(gomock.Any()).Return(nil)
+		repo.EXPECT().ListHymns(gomock.Any()).Return(nil, errors.New("database is locked"))
+
+		assert.Error(t, NewSearch(cfg, repo).BuildIndex(context.Background()))
+	})
+}
+
+const minorMusicXML = `<?xml version="1.0" encoding="UTF-8"?>
+<score-partwise version="3.1">
+  <part-list><score-part id="P1"><part-name>Voice</part-name></score-part></part-list>
+  <part id="P1">
+    <measure number="1">
+      <attributes><divisions>1</divisions><key><fifths>0</fifths><mode>minor</mode></key></attributes>
+      <note><pitch><step>A</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>B</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>C</step><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>D</step><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+      <note><pitch><step>E</step><octave>5</octave></pitch><duration>1</duration><type>quarter</type></note>
+    </measure>
+  </part>
+</score-partwise>`
+
+func TestSearch_MelodyOfMinorHymn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	music, err := musicxml.Parse([]byte(minorMusicXML))
+	assert.NoError(t, err)
+
+	cfg := config.Config{MusicXML: config.MusicXMLConfig{Path: "/files/", FilePrefix: "kj"}}
+	hymn := repository.HymnIndicator{HymnID: 9, Number: 9}
+
+	// the index is built and searched the same as the repository does, the pattern is in the column
+	var index repository.MelodyDocument
+	repo := repository.NewMockRepository(ctrl)
+	repo.EXPECT().CheckFullTextSearch(gomock.Any()).Return(nil)
+	repo.EXPECT().ListHymns(gomock.Any()).Return([]repository.HymnSummary{
+		{HymnData: repository.HymnData{HymnIndicator: hymn, Title: "Minor"}},
+	}, nil)
+	repo.EXPECT().GetMusicXML(gomock.Any(), "/files/kj-009.musicxml").Return(music, nil)
+	repo.EXPECT().GetHymnMetaData(gomock.Any(), 9).Return(&repository.HymnMetadata{}, nil)
+	repo.EXPECT().RebuildMelodyIndex(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, melodies []repository.MelodyDocument) error {
+		index = melodies[0]
+		return nil
+	})
+	repo.EXPECT().RebuildSearchIndex(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().SearchMelody(gomock.Any(), gomock.Any(), SEARCH_LIMIT).DoAndReturn(func(ctx context.Context, query repository.MelodyQuery, limit int) ([]repository.MelodyResult, error) {
+		column := index.Degrees
+		if query.AnyKey {
+			column = index.Intervals
+		}
+		if !strings.Contains(column, query.Pattern) {
+			return []repository.MelodyResult{}, nil
+		}
+		return []repository.MelodyResult{{HymnIndicator: hymn, Title: "Minor"}}, nil
+	}).Times(3)
+
+	searchMod := NewSearch(cfg, repo)
+	assert.NoError(t, searchMod.BuildIndex(context.Background()))
+	assert.Equal(t, " 6 7 1 2 3 ", index.Degrees)
+
+	for _, tt := range []struct {
+		query  string
+		anyKey bool
+	}{
+		{query: "6 7 1 2 3"},
+		{query: "6 7 1 2 3", anyKey: true},
+		// the same tune from la on the other note
+		{query: "3 4/ 5 6 7", anyKey: true},
+	} {
+		got, err := searchMod.SearchMelody(context.Background(), tt.query, tt.anyKey, false, 0)
+		assert.NoError(t, err)
+		assert.Len(t, got, 1, tt.query)
+	}
+}
+
+func TestSearch_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "default limit", limit: 0, wantLimit: SEARCH_LIMIT},
+		{name: "over the limit", limit: 1000, wantLimit: SEARCH_LIMIT},
+		{name: "the limit", limit: 5, wantLimit: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMockRepository(ctrl)
+			repo.EXPECT().SearchHymns(gomock.Any(), "kasih", tt.wantLimit).Return([]repository.SearchResult{}, nil)
+
+			got, err := NewSearch(config.Config{}, repo).Search(context.Background(), "kasih", tt.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, []repository.SearchResult{}, got)
+		})
+	}
+}
+
+func TestSearch_SearchMelody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		query     string
+		anyKey    bool
+		anyRhythm bool
+		want      *repository.MelodyQuery
+		wantErr   error
+	}{
+		{
+			name:  "the degrees",
+			query: "1 1 5 5 6 6 5",
+			want:  &repository.MelodyQuery{Pattern: " 1 1 5 5 6 6 5 "},
+		},
+		{
+			name:      "any key and any rhythm",
+			query:     "1 1 5 5 6 6 5",
+			anyKey:    true,
+			anyRhythm: true,
+			want:      &repository.MelodyQuery{Pattern: " -5 2 -2 ", AnyKey: true, AnyRhythm: true},
+		},
+		{
+			name:    "too short",
+			query:   "1 5",
+			wantErr: repository.ErrInvalidMelodyQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repository.NewMockRepository(ctrl)
+			if tt.want != nil {
+				repo.EXPECT().SearchMelody(gomock.Any(), *tt.want, SEARCH_LIMIT).Return([]repository.MelodyResult{}, nil)
+			}
+
+			got, err := NewSearch(config.Config{}, repo).SearchMelody(context.Background(), tt.query, tt.anyKey, tt.anyRhythm, 0)
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.Equal(t, []repository.MelodyResult{}, got)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jodi-ivan/numbered-notation-xml/internal/entity"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
 	"github.com/jodi-ivan/numbered-notation-xml/internal/renderer"
+	"github.com/jodi-ivan/numbered-notation-xml/internal/transpose"
 	"github.com/jodi-ivan/numbered-notation-xml/svc/repository"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/canvas"
 	"github.com/jodi-ivan/numbered-notation-xml/utils/config"
@@ -49,7 +50,7 @@ func collectRepeat(measures []musicxml.Measure) [][2]int {
 				case musicxml.BarLineRepeatDirectionBackward:
 					// closing
 					if len(result) == 0 {
-						result = append(result, [2]int{1}) // beginning of the measure
+						result = append(result, [2]int{measures[0].Number}) // beginning of the score, including the pickup
 					}
 					lastKnown := result[len(result)-1]
 					lastKnown[1] = measures[i].Number
@@ -61,9 +62,105 @@ func collectRepeat(measures []musicxml.Measure) [][2]int {
 	return result
 }
 
+// repeatRange is the part of the score played twice, either by the repeat barlines or by the D.C. / D.S. jump
+type repeatRange struct {
+	start, end int
+
+	// the measures only played on the first pass, e.g. the measures skipped by the To Coda.
+	// they are treated as the first ending of the repeat.
+	endings map[int]*musicxml.BarlineEnding
+
+	// the measure played after the second pass, e.g. the coda. zero when it is the next measure
+	next int
+}
+
+// collectJumps collects the D.C. and D.S. jumps as the repeat range.
+// the To Coda (and the Fine) marks the end of the second pass, the measures after it until the jump become the first ending.
+func collectJumps(measures []musicxml.Measure) []repeatRange {
+	result := []repeatRange{}
+	if len(measures) == 0 {
+		return result
+	}
+
+	navigations := make([]map[musicxml.NavigationType]bool, len(measures))
+	for i, measure := range measures {
+		navigations[i] = measure.GetNavigation()
+	}
+
+	segno, toCoda, fine := 0, 0, 0
+	for i, measure := range measures {
+		nav := navigations[i]
+
+		if nav[musicxml.NavigationSegno] {
+			segno = measure.Number
+		}
+
+		if nav[musicxml.NavigationToCoda] {
+			toCoda = measure.Number
+		} else if nav[musicxml.NavigationFine] {
+			fine = measure.Number
+		}
+
+		start := 0
+		switch {
+		case nav[musicxml.NavigationDalSegno]:
+			start = segno
+		case nav[musicxml.NavigationDaCapo]:
+			start = measures[0].Number
+		default:
+			continue
+		}
+
+		if start == 0 || start > measure.Number {
+			continue
+		}
+
+		jump := repeatRange{
+			start:   start,
+			end:     measure.Number,
+			endings: map[int]*musicxml.BarlineEnding{},
+		}
+
+		lastPass := toCoda
+		if lastPass == 0 {
+			lastPass = fine
+		}
+
+		if lastPass >= start && lastPass < measure.Number {
+			for j := i; j >= 0 && measures[j].Number != lastPass; j-- {
+				jump.endings[measures[j].Number] = &musicxml.BarlineEnding{Number: "1"}
+			}
+		}
+
+		if toCoda >= start && toCoda < measure.Number {
+			for j := i + 1; j < len(measures); j++ {
+				if navigations[j][musicxml.NavigationCoda] {
+					jump.next = measures[j].Number
+					break
+				}
+			}
+		}
+
+		result = append(result, jump)
+		toCoda, fine = 0, 0
+	}
+
+	return result
+}
+
 func ProcessRepeats(music *musicxml.MusicXML) {
+	for i := range music.Parts {
+		processPartRepeats(&music.Parts[i])
+	}
+}
 
-	repeats := collectRepeat(music.Part.Measures)
+func processPartRepeats(part *musicxml.Part) {
+
+	repeats := []repeatRange{}
+	for _, repeat := range collectRepeat(part.Measures) {
+		repeats = append(repeats, repeatRange{start: repeat[0], end: repeat[1]})
+	}
+	repeats = append(repeats, collectJumps(part.Measures)...)
 
 	if len(repeats) == 0 {
 		return
@@ -72,11 +169,14 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 
 	measureMap := map[int]*musicxml.Measure{}
 	syllCountMeasure := map[int][2]int{}
+	// the position of the measure on the part, the measure out of the numbering is not in the order of its number
+	positions := map[int]int{}
 
 	// lastSyllBefore := 0
-	// lastMeasure := 0
-	for i, measure := range music.Part.Measures {
-		measureMap[measure.Number] = &music.Part.Measures[i]
+	prevMeasure := 0
+	for i, measure := range part.Measures {
+		measureMap[measure.Number] = &part.Measures[i]
+		positions[measure.Number] = i
 		count := 0
 		for _, a := range measure.Appendix {
 			if n, err := a.ParseAsNote(); err == nil && len(n.Lyric) > 0 {
@@ -86,7 +186,7 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 
 		lastMeasureCount := 1
 		if len(syllCountMeasure) > 0 {
-			lastMeasureCount = syllCountMeasure[measure.Number-1][1] + 1
+			lastMeasureCount = syllCountMeasure[prevMeasure][1] + 1
 		}
 
 		bl, _ := bli.GetRendererLeftBarline(measure, constant.LAYOUT_INDENT_LENGTH, nil)
@@ -95,10 +195,18 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 		}
 
 		syllCountMeasure[measure.Number] = [2]int{lastMeasureCount, lastMeasureCount + count - 1}
+		prevMeasure = measure.Number
 
 	}
 	for _, repeat := range repeats {
-		for start := repeat[0]; start <= repeat[1]; start++ {
+		first, hasStart := positions[repeat.start]
+		last, hasEnd := positions[repeat.end]
+		if !hasStart || !hasEnd || first > last {
+			continue
+		}
+
+		for _, measure := range part.Measures[first : last+1] {
+			start := measure.Number
 			var barlineEnding *musicxml.BarlineEnding
 
 			bl, _ := bli.GetRendererLeftBarline(*measureMap[start], constant.LAYOUT_INDENT_LENGTH, nil)
@@ -109,11 +217,15 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 			if bl != nil {
 				barlineEnding = bl.Barline.Ending
 			}
+			if ending, ok := repeat.endings[start]; ok && barlineEnding == nil {
+				barlineEnding = ending
+			}
+
 			repeatType := musicxml.RepeatInfoTypeMiddle
 			switch start {
-			case repeat[0]:
+			case repeat.start:
 				repeatType = musicxml.RepeatInfoTypeOpening
-			case repeat[1]:
+			case repeat.end:
 				repeatType = musicxml.RepeatInfoTypeClosing
 			}
 			idx := start
@@ -125,15 +237,24 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 				Type:          repeatType,
 				SyllCntStart:  syllCountMeasure[idx][0],
 				SyllCntEnd:    syllCountMeasure[idx][1],
-				OffsetStart:   syllCountMeasure[repeat[1]][1],
+				OffsetStart:   syllCountMeasure[repeat.end][1],
 				MeasureNumber: start,
 				BarlineEnding: barlineEnding,
 			}
 
 		}
 
-		if measureMap[repeat[1]].RepeatInfo.BarlineEnding != nil {
-			nextMeasure := repeat[1] + 1
+		closing := measureMap[repeat.end]
+		if closing == nil || closing.RepeatInfo == nil {
+			continue
+		}
+
+		nextMeasure, hasNext := repeat.next, repeat.next != 0
+		if !hasNext && last+1 < len(part.Measures) {
+			nextMeasure, hasNext = part.Measures[last+1].Number, true
+		}
+
+		if hasNext && closing.RepeatInfo.BarlineEnding != nil && measureMap[nextMeasure] != nil {
 
 			measureMap[nextMeasure].RepeatInfo = &musicxml.RepeatInfo{
 				Type:          musicxml.RepeatInfoTypeClosing,
@@ -150,12 +271,16 @@ func ProcessRepeats(music *musicxml.MusicXML) {
 
 }
 
-func (i *interactor) RenderHymn(ctx context.Context, canv canvas.Canvas, hymnNum int, variant ...string) error {
-	filepath := fmt.Sprintf("%s%s-%03d.musicxml", i.config.MusicXML.Path, i.config.MusicXML.FilePrefix, hymnNum)
+// MusicXMLPath returns the path of the musicxml of the hymn
+func MusicXMLPath(cfg config.MusicXMLConfig, hymnNum int, variant ...string) string {
 	if len(variant) > 0 {
-		filepath = fmt.Sprintf("%s%s-%03d%s.musicxml", i.config.MusicXML.Path, i.config.MusicXML.FilePrefix, hymnNum, variant[0])
+		return fmt.Sprintf("%s%s-%03d%s.musicxml", cfg.Path, cfg.FilePrefix, hymnNum, variant[0])
 	}
-	music, err := i.repo.GetMusicXML(ctx, filepath)
+	return fmt.Sprintf("%s%s-%03d.musicxml", cfg.Path, cfg.FilePrefix, hymnNum)
+}
+
+func (i *interactor) RenderHymn(ctx context.Context, canv canvas.Canvas, hymnNum int, variant ...string) error {
+	music, err := i.repo.GetMusicXML(ctx, MusicXMLPath(i.config.MusicXML, hymnNum, variant...))
 	if err != nil {
 		flow := canv.Delegator().OnError(err)
 		if flow != canvas.DelegatorErrorFlowControlIgnore {
@@ -163,6 +288,16 @@ func (i *interactor) RenderHymn(ctx context.Context, canv canvas.Canvas, hymnNum
 		}
 	}
 
+	if param, _ := params.GetParamFromContext(ctx); param.Transpose != "" {
+		request, err := transpose.ParseRequest(param.Transpose)
+		if err == nil {
+			err = transpose.Music(ctx, &music, request)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	ProcessRepeats(&music)
 
 	metaData, err := i.repo.GetHymnMetaData(ctx, hymnNum, variant...)
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/jodi-ivan/numbered-notation-xml/internal/musicxml"
+	"github.com/stretchr/testify/assert"
+)
+
+func directionMeasure(number int, directions ...string) musicxml.Measure {
+	measure := musicxml.Measure{Number: number}
+	for _, d := range directions {
+		measure.Appendix = append(measure.Appendix, musicxml.NewElement(musicxml.ElementDirection, d))
+	}
+	return measure
+}
+
+func Test_collectJumps(t *testing.T) {
+	tests := []struct {
+		name     string
+		measures []musicxml.Measure
+		want     []repeatRange
+	}{
+		{
+			name: "no jumps",
+			measures: []musicxml.Measure{
+				directionMeasure(1),
+				directionMeasure(2),
+			},
+			want: []repeatRange{},
+		},
+		{
+			name: "D.S. without segno",
+			measures: []musicxml.Measure{
+				directionMeasure(1),
+				directionMeasure(2, `<direction-type><words>D.S.</words></direction-type>`),
+			},
+			want: []repeatRange{},
+		},
+		{
+			name: "D.C. al Fine",
+			measures: []musicxml.Measure{
+				directionMeasure(1),
+				directionMeasure(2, `<direction-type><words>Fine</words></direction-type>`),
+				directionMeasure(3),
+				directionMeasure(4, `<direction-type><words>D.C. al Fine</words></direction-type>`),
+			},
+			want: []repeatRange{
+				{
+					start: 1, end: 4,
+					endings: map[int]*musicxml.BarlineEnding{
+						3: {Number: "1"},
+						4: {Number: "1"},
+					},
+				},
+			},
+		},
+		{
+			name: "D.S. al Coda",
+			measures: []musicxml.Measure{
+				directionMeasure(1),
+				directionMeasure(2, `<direction-type><segno/></direction-type><sound segno="segno"/>`),
+				directionMeasure(3, `<direction-type><words>To Coda</words></direction-type><direction-type><coda/></direction-type><sound tocoda="coda"/>`),
+				directionMeasure(4, `<direction-type><words>D.S. al Coda</words></direction-type><sound dalsegno="segno"/>`),
+				directionMeasure(5, `<direction-type><coda/></direction-type><sound coda="coda"/>`),
+			},
+			want: []repeatRange{
+				{
+					start: 2, end: 4,
+					endings: map[int]*musicxml.BarlineEnding{
+						4: {Number: "1"},
+					},
+					next: 5,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, collectJumps(tt.measures))
+		})
+	}
+}
+
+func TestProcessRepeats_DalSegno(t *testing.T) {
+	music := &musicxml.MusicXML{
+		Parts: []musicxml.Part{
+			{
+				Measures: []musicxml.Measure{
+					directionMeasure(1),
+					directionMeasure(2, `<direction-type><segno/></direction-type>`),
+					directionMeasure(3, `<direction-type><words>D.S.</words></direction-type>`),
+				},
+			},
+		},
+	}
+
+	ProcessRepeats(music)
+
+	measures := music.Parts[0].Measures
+	assert.Nil(t, measures[0].RepeatInfo)
+	assert.Equal(t, musicxml.RepeatInfoTypeOpening, measures[1].RepeatInfo.Type)
+	assert.Equal(t, musicxml.RepeatInfoTypeClosing, measures[2].RepeatInfo.Type)
+}
+
+func TestProcessRepeats_Pickup(t *testing.T) {
+	note := `<note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type><lyric number="1"><text>la</text></lyric></note>`
+	music, err := musicxml.Parse([]byte(`<score-partwise><part id="P1">
+		<measure number="X1" implicit="yes">` + note + `</measure>
+		<measure number="1">` + note + note + `</measure>
+		<measure number="2">` + note + `<barline location="right"><repeat direction="backward"/></barline></measure>
+	</part></score-partwise>`))
+	assert.NoError(t, err)
+
+	ProcessRepeats(&music)
+
+	measures := music.Parts[0].Measures
+	assert.Equal(t, musicxml.RepeatInfoTypeOpening, measures[0].RepeatInfo.Type)
+	assert.Equal(t, 1, measures[0].RepeatInfo.SyllCntStart)
+	assert.Equal(t, 1, measures[0].RepeatInfo.SyllCntEnd)
+
+	assert.Equal(t, musicxml.RepeatInfoTypeMiddle, measures[1].RepeatInfo.Type)
+	assert.Equal(t, 2, measures[1].RepeatInfo.SyllCntStart)
+	assert.Equal(t, 3, measures[1].RepeatInfo.SyllCntEnd)
+
+	assert.Equal(t, musicxml.RepeatInfoTypeClosing, measures[2].RepeatInfo.Type)
+	assert.Equal(t, 4, measures[2].RepeatInfo.SyllCntStart)
+	assert.Equal(t, 4, measures[2].RepeatInfo.OffsetStart)
+}
+
+func TestProcessRepeats_MeasureOutOfNumbering(t *testing.T) {
+	note := `<note><pitch><step>C</step><octave>4</octave></pitch><duration>1</duration><type>quarter</type><lyric number="1"><text>la</text></lyric></note>`
+	music, err := musicxml.Parse([]byte(`<score-partwise><part id="P1">
+		<measure number="1"><barline location="left"><repeat direction="forward"/></barline>` + note + `</measure>
+		<measure number="X1" implicit="yes">` + note + `</measure>
+		<measure number="2">` + note + `<barline location="right"><repeat direction="backward"/></barline></measure>
+		<measure number="3">` + note + `</measure>
+	</part></score-partwise>`))
+	assert.NoError(t, err)
+
+	ProcessRepeats(&music)
+
+	measures := music.Parts[0].Measures
+	assert.Equal(t, []int{1, 4, 2, 3}, []int{measures[0].Number, measures[1].Number, measures[2].Number, measures[3].Number})
+	assert.Equal(t, musicxml.RepeatInfoTypeOpening, measures[0].RepeatInfo.Type)
+
+	assert.Equal(t, musicxml.RepeatInfoTypeMiddle, measures[1].RepeatInfo.Type)
+	assert.Equal(t, 2, measures[1].RepeatInfo.SyllCntStart)
+	assert.Equal(t, 2, measures[1].RepeatInfo.SyllCntEnd)
+
+	assert.Equal(t, musicxml.RepeatInfoTypeClosing, measures[2].RepeatInfo.Type)
+	assert.Nil(t, measures[3].RepeatInfo)
+}
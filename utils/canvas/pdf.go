@@ -0,0 +1,173 @@
+package canvas
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// the css pixel is 0.75 point, the page has the same physical size as the printed svg
+const PDF_PX_TO_PT = 0.75
+
+// PDFCanvas renders the svg first, the svg is converted into the pdf on End
+type PDFCanvas struct {
+	*convertedCanvas
+}
+
+func NewPDFCanvas(out io.Writer, d Delegator, fontPath string) Canvas {
+	return &PDFCanvas{
+		convertedCanvas: newConvertedCanvas(out, d, func(svg io.Reader, out io.Writer) error {
+			return SVGToPDF(svg, out, fontPath)
+		}),
+	}
+}
+
+// SVGToPDF converts the svg written by the canvas into the single page pdf, the fonts are embedded from the font path
+func SVGToPDF(svg io.Reader, out io.Writer, fontPath string) error {
+	painter := &pdfPainter{
+		fontPath: fontPath,
+		fonts:    map[string]bool{},
+	}
+
+	if err := convertSVG(svg, painter); err != nil {
+		return err
+	}
+
+	painter.pdf.TransformEnd()
+	return painter.pdf.Output(out)
+}
+
+type pdfPainter struct {
+	pdf      *fpdf.Fpdf
+	fontPath string
+	// the registered font, by the family and the style
+	fonts map[string]bool
+}
+
+func (pp *pdfPainter) newPage(width, height float64) {
+	pp.pdf = fpdf.NewCustom(&fpdf.InitType{
+		UnitStr: "pt",
+		Size:    fpdf.SizeType{Wd: width * PDF_PX_TO_PT, Ht: height * PDF_PX_TO_PT},
+	})
+	pp.pdf.SetMargins(0, 0, 0)
+	pp.pdf.SetAutoPageBreak(false, 0)
+	pp.pdf.AddPage()
+
+	// draw everything on the svg pixel
+	pp.pdf.TransformBegin()
+	pp.pdf.TransformScale(PDF_PX_TO_PT*100, PDF_PX_TO_PT*100, 0, 0)
+}
+
+func (pp *pdfPainter) err() error {
+	if pp.pdf != nil && pp.pdf.Err() {
+		return pp.pdf.Error()
+	}
+	return nil
+}
+
+// setFont registers the font on the first use, the regular font is used for the bold and italic as well
+func (pp *pdfPainter) setFont(family string, style svgStyle) {
+	key, file := fontFile(family)
+	fontStyle := style.fontStyle()
+
+	if !pp.fonts[key+fontStyle] {
+		pp.pdf.AddUTF8Font(key, fontStyle, filepath.Join(pp.fontPath, file))
+		pp.fonts[key+fontStyle] = true
+	}
+	pp.pdf.SetFont(key, fontStyle, style.FontSize)
+}
+
+func (pp *pdfPainter) stringWidth(text string) float64 {
+	return pp.pdf.GetStringWidth(text)
+}
+
+func (pp *pdfPainter) text(x, y float64, text string, style svgStyle) {
+	rgb, _ := parseColor(style.Fill)
+	pp.pdf.SetTextColor(rgb[0], rgb[1], rgb[2])
+	if style.Opacity < 1 {
+		pp.pdf.SetAlpha(style.Opacity, "Normal")
+		defer pp.pdf.SetAlpha(1, "Normal")
+	}
+	pp.pdf.Text(x, y, text)
+}
+
+func (pp *pdfPainter) line(x1, y1, x2, y2 float64, style svgStyle) {
+	if pp.paint(style, false) == "" {
+		return
+	}
+	defer pp.resetPaint(style)
+	pp.pdf.Line(x1, y1, x2, y2)
+}
+
+func (pp *pdfPainter) circle(cx, cy, r float64, style svgStyle) {
+	drawStyle := pp.paint(style, true)
+	if drawStyle == "" {
+		return
+	}
+	defer pp.resetPaint(style)
+	pp.pdf.Circle(cx, cy, r, drawStyle)
+}
+
+func (pp *pdfPainter) rect(x, y, width, height float64, style svgStyle) {
+	drawStyle := pp.paint(style, true)
+	if drawStyle == "" {
+		return
+	}
+	defer pp.resetPaint(style)
+	pp.pdf.Rect(x, y, width, height, drawStyle)
+}
+
+func (pp *pdfPainter) path(segments []pathSegment, style svgStyle) {
+	drawStyle := pp.paint(style, true)
+	if drawStyle == "" {
+		return
+	}
+	defer pp.resetPaint(style)
+
+	for _, s := range segments {
+		switch s.Command {
+		case 'M':
+			pp.pdf.MoveTo(s.Points[0], s.Points[1])
+		case 'L':
+			pp.pdf.LineTo(s.Points[0], s.Points[1])
+		case 'C':
+			pp.pdf.CurveBezierCubicTo(s.Points[0], s.Points[1], s.Points[2], s.Points[3], s.Points[4], s.Points[5])
+		case 'Z':
+			pp.pdf.ClosePath()
+		}
+	}
+	pp.pdf.DrawPath(drawStyle)
+}
+
+// paint sets the colors and the line style, returns the fpdf draw style ("D", "F" or "FD"), empty when nothing is painted
+func (pp *pdfPainter) paint(style svgStyle, fillable bool) string {
+	result := ""
+	if rgb, ok := parseColor(style.Fill); ok && fillable {
+		pp.pdf.SetFillColor(rgb[0], rgb[1], rgb[2])
+		result += "F"
+	}
+	if rgb, ok := parseColor(style.Stroke); ok {
+		pp.pdf.SetDrawColor(rgb[0], rgb[1], rgb[2])
+		pp.pdf.SetLineWidth(style.StrokeWidth)
+		pp.pdf.SetLineCapStyle(style.LineCap)
+		if len(style.DashArray) > 0 {
+			pp.pdf.SetDashPattern(style.DashArray, style.DashOffset)
+		}
+		result += "D"
+	}
+
+	if result != "" && style.Opacity < 1 {
+		pp.pdf.SetAlpha(style.Opacity, "Normal")
+	}
+	return result
+}
+
+func (pp *pdfPainter) resetPaint(style svgStyle) {
+	if len(style.DashArray) > 0 {
+		pp.pdf.SetDashPattern([]float64{}, 0)
+	}
+	if style.Opacity < 1 {
+		pp.pdf.SetAlpha(1, "Normal")
+	}
+}
@@ -0,0 +1,30 @@
+package canvas
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSVG = `<?xml version="1.0"?>
+	<svg width="200" height="100" xmlns="http://www.w3.org/2000/svg">
+	<defs><style>@font-face{font-family:Caladea}</style></defs>
+	<g style="font-family:Caladea;font-size:12px">
+		<text x="10" y="20" text-anchor="middle">1 <tspan style="font-weight:bold">2</tspan></text>
+		<line x1="0" y1="50" x2="200" y2="50" stroke="black" stroke-width="1.5"/>
+		<path d="M10 60 Q 50 80 90 60" fill="none" stroke="#000"/>
+		<circle cx="5" cy="5" r="2"/>
+	</g>
+	</svg>`
+
+func TestSVGToPDF(t *testing.T) {
+	var out bytes.Buffer
+	err := SVGToPDF(strings.NewReader(testSVG), &out, "../../files/var/www/fonts")
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out.Bytes(), []byte("%PDF")))
+
+	err = SVGToPDF(strings.NewReader(`<svg></svg>`), &out, "../../files/var/www/fonts")
+	assert.ErrorIs(t, err, ErrInvalidSVG)
+}
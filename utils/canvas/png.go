@@ -0,0 +1,266 @@
+package canvas
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// the dpi of the svg pixel
+	PNG_DEFAULT_DPI = 96.0
+	// the maximum size of the png, the hymn is 800px wide on the svg
+	PNG_MAX_WIDTH = 4800
+	PNG_MAX_DPI   = PNG_DEFAULT_DPI * PNG_MAX_WIDTH / 800
+)
+
+// PNGOption is the size of the png, the width in pixel takes the precedence over the dpi.
+// the svg size is used when both of them are empty
+type PNGOption struct {
+	Width int
+	DPI   float64
+}
+
+func (po PNGOption) scale(svgWidth float64) float64 {
+	if po.Width > 0 {
+		return float64(po.Width) / svgWidth
+	}
+	if po.DPI > 0 {
+		return po.DPI / PNG_DEFAULT_DPI
+	}
+	return 1
+}
+
+// PNGCanvas renders the svg first, the svg is rasterized into the png on End
+type PNGCanvas struct {
+	*convertedCanvas
+}
+
+func NewPNGCanvas(out io.Writer, d Delegator, fontPath string, option PNGOption) Canvas {
+	return &PNGCanvas{
+		convertedCanvas: newConvertedCanvas(out, d, func(svg io.Reader, out io.Writer) error {
+			return SVGToPNG(svg, out, fontPath, option)
+		}),
+	}
+}
+
+// SVGToPNG rasterizes the svg written by the canvas on the white background, the fonts are loaded from the font path
+func SVGToPNG(svg io.Reader, out io.Writer, fontPath string, option PNGOption) error {
+	painter := &pngPainter{
+		option:   option,
+		fontPath: fontPath,
+		fonts:    map[string]*opentype.Font{},
+		faces:    map[string]font.Face{},
+		rast:     &vector.Rasterizer{},
+	}
+
+	if err := convertSVG(svg, painter); err != nil {
+		return err
+	}
+
+	return png.Encode(out, painter.img)
+}
+
+type pngPainter struct {
+	img      *image.RGBA
+	option   PNGOption
+	scale    float64
+	fontPath string
+
+	fonts map[string]*opentype.Font
+	// the font face by the font and the size
+	faces map[string]font.Face
+	face  font.Face
+
+	rast *vector.Rasterizer
+	e    error
+}
+
+func (pp *pngPainter) newPage(width, height float64) {
+	pp.scale = pp.option.scale(width)
+	pp.img = image.NewRGBA(image.Rect(0, 0, int(math.Ceil(width*pp.scale)), int(math.Ceil(height*pp.scale))))
+	draw.Draw(pp.img, pp.img.Bounds(), image.White, image.Point{}, draw.Src)
+}
+
+func (pp *pngPainter) err() error {
+	return pp.e
+}
+
+// setFont loads the font on the first use, the regular font is used for the bold and italic as well
+func (pp *pngPainter) setFont(family string, style svgStyle) {
+	key, file := fontFile(family)
+	size := style.FontSize * pp.scale
+
+	faceKey := key + ":" + strconv.FormatFloat(size, 'f', 2, 64)
+	if face, ok := pp.faces[faceKey]; ok {
+		pp.face = face
+		return
+	}
+
+	f, ok := pp.fonts[key]
+	if !ok {
+		raw, err := os.ReadFile(filepath.Join(pp.fontPath, file))
+		if err != nil {
+			pp.e = err
+			return
+		}
+		f, err = opentype.Parse(raw)
+		if err != nil {
+			// mozart11 has the old short OS/2 table, parse it as the font without the table
+			f, err = opentype.Parse(withoutOS2Table(raw))
+		}
+		if err != nil {
+			pp.e = err
+			return
+		}
+		pp.fonts[key] = f
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingNone,
+	})
+	if err != nil {
+		pp.e = err
+		return
+	}
+	pp.faces[faceKey] = face
+	pp.face = face
+}
+
+func (pp *pngPainter) stringWidth(text string) float64 {
+	if pp.face == nil {
+		return 0
+	}
+	return float64(font.MeasureString(pp.face, text)) / 64 / pp.scale
+}
+
+func (pp *pngPainter) text(x, y float64, text string, style svgStyle) {
+	if pp.face == nil {
+		return
+	}
+	rgb, _ := parseColor(style.Fill)
+	drawer := &font.Drawer{
+		Dst:  pp.img,
+		Src:  image.NewUniform(toColor(rgb, style.Opacity)),
+		Face: pp.face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * pp.scale * 64), Y: fixed.Int26_6(y * pp.scale * 64)},
+	}
+	drawer.DrawString(text)
+}
+
+func (pp *pngPainter) line(x1, y1, x2, y2 float64, style svgStyle) {
+	pp.stroke([]polyline{{points: []point{{x1, y1}, {x2, y2}}}}, style)
+}
+
+func (pp *pngPainter) circle(cx, cy, r float64, style svgStyle) {
+	outline := circlePolygon(point{cx, cy}, r)
+	if rgb, ok := parseColor(style.Fill); ok {
+		pp.fill([][]point{outline}, toColor(rgb, style.Opacity))
+	}
+	pp.stroke([]polyline{{points: outline, closed: true}}, style)
+}
+
+func (pp *pngPainter) rect(x, y, width, height float64, style svgStyle) {
+	outline := []point{{x, y}, {x + width, y}, {x + width, y + height}, {x, y + height}}
+	if rgb, ok := parseColor(style.Fill); ok {
+		pp.fill([][]point{outline}, toColor(rgb, style.Opacity))
+	}
+	pp.stroke([]polyline{{points: outline, closed: true}}, style)
+}
+
+func (pp *pngPainter) path(segments []pathSegment, style svgStyle) {
+	lines := flattenPath(segments)
+	if rgb, ok := parseColor(style.Fill); ok {
+		polygons := make([][]point, 0, len(lines))
+		for _, l := range lines {
+			polygons = append(polygons, l.points)
+		}
+		pp.fill(polygons, toColor(rgb, style.Opacity))
+	}
+	pp.stroke(lines, style)
+}
+
+func (pp *pngPainter) stroke(lines []polyline, style svgStyle) {
+	rgb, ok := parseColor(style.Stroke)
+	if !ok || style.StrokeWidth <= 0 {
+		return
+	}
+	if len(style.DashArray) > 0 {
+		lines = dashPolylines(lines, style.DashArray, style.DashOffset)
+	}
+	pp.fill(strokePolygons(lines, style.StrokeWidth, style.LineCap), toColor(rgb, style.Opacity))
+}
+
+// fill rasterizes the polygons on its bounding box only, the polygons are on the svg pixel
+func (pp *pngPainter) fill(polygons [][]point, c color.Color) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, polygon := range polygons {
+		for _, p := range polygon {
+			minX, minY = math.Min(minX, p.x), math.Min(minY, p.y)
+			maxX, maxY = math.Max(maxX, p.x), math.Max(maxY, p.y)
+		}
+	}
+	if math.IsInf(minX, 0) {
+		return
+	}
+
+	bounds := image.Rect(
+		int(math.Floor(minX*pp.scale)), int(math.Floor(minY*pp.scale)),
+		int(math.Ceil(maxX*pp.scale))+1, int(math.Ceil(maxY*pp.scale))+1,
+	).Intersect(pp.img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	pp.rast.Reset(bounds.Dx(), bounds.Dy())
+	for _, polygon := range polygons {
+		if len(polygon) < 3 {
+			continue
+		}
+		for i, p := range polygon {
+			x, y := float32(p.x*pp.scale-float64(bounds.Min.X)), float32(p.y*pp.scale-float64(bounds.Min.Y))
+			if i == 0 {
+				pp.rast.MoveTo(x, y)
+				continue
+			}
+			pp.rast.LineTo(x, y)
+		}
+		pp.rast.ClosePath()
+	}
+	pp.rast.Draw(pp.img, bounds, image.NewUniform(c), image.Point{})
+}
+
+// withoutOS2Table renames the OS/2 table on the table directory, the table is optional for the rasterizer
+func withoutOS2Table(raw []byte) []byte {
+	if len(raw) < 12 {
+		return raw
+	}
+	result := append([]byte{}, raw...)
+	numTables := int(result[4])<<8 | int(result[5])
+	for i := 0; i < numTables && 12+16*i+4 <= len(result); i++ {
+		tag := result[12+16*i : 12+16*i+4]
+		if string(tag) == "OS/2" {
+			// the tags are sorted, OS/0 keeps the order
+			copy(tag, "OS/0")
+		}
+	}
+	return result
+}
+
+func toColor(rgb [3]int, opacity float64) color.Color {
+	return color.NRGBA{R: uint8(rgb[0]), G: uint8(rgb[1]), B: uint8(rgb[2]), A: uint8(math.Round(255 * opacity))}
+}
@@ -0,0 +1,189 @@
+package canvas
+
+import "math"
+
+// the number of the straight lines of the flattened cubic curve and the circle
+const (
+	PNG_CURVE_STEPS  = 16
+	PNG_CIRCLE_STEPS = 48
+)
+
+type point struct {
+	x, y float64
+}
+
+type polyline struct {
+	points []point
+	closed bool
+}
+
+// flattenPath converts the path segments into the polylines, one polyline for each sub path
+func flattenPath(segments []pathSegment) []polyline {
+	result := []polyline{}
+	current := point{}
+	for _, s := range segments {
+		switch s.Command {
+		case 'M':
+			current = point{s.Points[0], s.Points[1]}
+			result = append(result, polyline{points: []point{current}})
+			continue
+		case 'Z':
+			if len(result) > 0 {
+				result[len(result)-1].closed = true
+				current = result[len(result)-1].points[0]
+			}
+			continue
+		}
+
+		if len(result) == 0 {
+			result = append(result, polyline{points: []point{current}})
+		}
+		last := &result[len(result)-1]
+
+		switch s.Command {
+		case 'L':
+			current = point{s.Points[0], s.Points[1]}
+			last.points = append(last.points, current)
+		case 'C':
+			p0 := current
+			for i := 1; i <= PNG_CURVE_STEPS; i++ {
+				t := float64(i) / PNG_CURVE_STEPS
+				mt := 1 - t
+				a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+				last.points = append(last.points, point{
+					a*p0.x + b*s.Points[0] + c*s.Points[2] + d*s.Points[4],
+					a*p0.y + b*s.Points[1] + c*s.Points[3] + d*s.Points[5],
+				})
+			}
+			current = point{s.Points[4], s.Points[5]}
+		}
+	}
+	return result
+}
+
+// dashPolylines splits the polylines into the dashes, the odd dash array is repeated as the svg does
+func dashPolylines(lines []polyline, dashArray []float64, offset float64) []polyline {
+	pattern := dashArray
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+	}
+	total := 0.0
+	for _, dash := range pattern {
+		total += dash
+	}
+	if total <= 0 {
+		return lines
+	}
+
+	result := []polyline{}
+	for _, l := range lines {
+		points := l.points
+		if l.closed && len(points) > 0 {
+			points = append(append([]point{}, points...), points[0])
+		}
+
+		// find the dash on the offset
+		index, remaining := 0, pattern[0]
+		pos := math.Mod(offset, total)
+		if pos < 0 {
+			pos += total
+		}
+		for pos > 0 {
+			if pos < remaining {
+				remaining -= pos
+				break
+			}
+			pos -= remaining
+			index = (index + 1) % len(pattern)
+			remaining = pattern[index]
+		}
+
+		var dash []point
+		if index%2 == 0 && len(points) > 0 {
+			dash = []point{points[0]}
+		}
+		for i := 1; i < len(points); i++ {
+			from, to := points[i-1], points[i]
+			length := math.Hypot(to.x-from.x, to.y-from.y)
+			walked := 0.0
+			for length-walked > remaining {
+				walked += remaining
+				at := point{from.x + (to.x-from.x)*walked/length, from.y + (to.y-from.y)*walked/length}
+				if index%2 == 0 {
+					result = append(result, polyline{points: append(dash, at)})
+					dash = nil
+				} else {
+					dash = []point{at}
+				}
+				index = (index + 1) % len(pattern)
+				remaining = pattern[index]
+			}
+			remaining -= length - walked
+			if index%2 == 0 {
+				dash = append(dash, to)
+			}
+		}
+		if index%2 == 0 && len(dash) > 1 {
+			result = append(result, polyline{points: dash})
+		}
+	}
+	return result
+}
+
+// strokePolygons returns the outline of the stroke, the joins are always round.
+// every polygon has the same orientation, the overlapping parts are not cancelled out by the rasterizer
+func strokePolygons(lines []polyline, width float64, lineCap string) [][]point {
+	halfWidth := width / 2
+	result := [][]point{}
+	for _, l := range lines {
+		points := l.points
+		if l.closed && len(points) > 0 {
+			points = append(append([]point{}, points...), points[0])
+		}
+
+		for i := 1; i < len(points); i++ {
+			from, to := points[i-1], points[i]
+			length := math.Hypot(to.x-from.x, to.y-from.y)
+			if length == 0 {
+				continue
+			}
+			dx, dy := (to.x-from.x)/length, (to.y-from.y)/length
+
+			if lineCap == "square" && !l.closed {
+				if i == 1 {
+					from = point{from.x - dx*halfWidth, from.y - dy*halfWidth}
+				}
+				if i == len(points)-1 {
+					to = point{to.x + dx*halfWidth, to.y + dy*halfWidth}
+				}
+			}
+
+			nx, ny := -dy*halfWidth, dx*halfWidth
+			result = append(result, []point{
+				{from.x + nx, from.y + ny},
+				{to.x + nx, to.y + ny},
+				{to.x - nx, to.y - ny},
+				{from.x - nx, from.y - ny},
+			})
+
+			if i < len(points)-1 || l.closed {
+				result = append(result, circlePolygon(to, halfWidth))
+			}
+		}
+
+		if lineCap == "round" && !l.closed && len(points) > 0 {
+			result = append(result, circlePolygon(points[0], halfWidth), circlePolygon(points[len(points)-1], halfWidth))
+		}
+	}
+	return result
+}
+
+// circlePolygon returns the circle with the same orientation as the stroke segment
+func circlePolygon(center point, r float64) []point {
+	result := make([]point, PNG_CIRCLE_STEPS)
+	for i := range result {
+		angle := 2 * math.Pi * float64(i) / PNG_CIRCLE_STEPS
+		result[i] = point{center.x + r*math.Cos(angle), center.y - r*math.Sin(angle)}
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package canvas
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSVGToPNG(t *testing.T) {
+	tests := []struct {
+		name       string
+		option     PNGOption
+		wantWidth  int
+		wantHeight int
+	}{
+		{
+			name:       "svg size",
+			wantWidth:  200,
+			wantHeight: 100,
+		},
+		{
+			name:       "width",
+			option:     PNGOption{Width: 600},
+			wantWidth:  600,
+			wantHeight: 300,
+		},
+		{
+			name:       "dpi",
+			option:     PNGOption{DPI: 192},
+			wantWidth:  400,
+			wantHeight: 200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := SVGToPNG(strings.NewReader(testSVG), &out, "../../files/var/www/fonts", tt.option)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			img, err := png.Decode(&out)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWidth, img.Bounds().Dx())
+			assert.Equal(t, tt.wantHeight, img.Bounds().Dy())
+
+			// the line on y=50 is painted, the corner is the white background
+			r, _, _, _ := img.At(tt.wantWidth/2, tt.wantHeight/2).RGBA()
+			assert.Less(t, r, uint32(0x8000))
+			r, _, _, _ = img.At(tt.wantWidth-1, tt.wantHeight-1).RGBA()
+			assert.Equal(t, uint32(0xffff), r)
+		})
+	}
+}
+
+func TestDashPolylines(t *testing.T) {
+	lines := []polyline{{points: []point{{0, 0}, {10, 0}}}}
+
+	assert.Equal(t, []polyline{
+		{points: []point{{0, 0}, {2, 0}}},
+		{points: []point{{5, 0}, {7, 0}}},
+	}, dashPolylines(lines, []float64{2, 3}, 0))
+
+	assert.Equal(t, []polyline{
+		{points: []point{{0, 0}, {1, 0}}},
+		{points: []point{{4, 0}, {6, 0}}},
+		{points: []point{{9, 0}, {10, 0}}},
+	}, dashPolylines(lines, []float64{2, 3}, 1))
+}
+
+func TestStrokePolygons(t *testing.T) {
+	lines := []polyline{{points: []point{{0, 0}, {10, 0}}}}
+
+	assert.Equal(t, [][]point{
+		{{0, 1}, {10, 1}, {10, -1}, {0, -1}},
+	}, strokePolygons(lines, 2, "butt"))
+
+	assert.Equal(t, [][]point{
+		{{-1, 1}, {11, 1}, {11, -1}, {-1, -1}},
+	}, strokePolygons(lines, 2, "square"))
+
+	assert.Len(t, strokePolygons(lines, 2, "round"), 3)
+}
@@ -0,0 +1,445 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const (
+	SVG_DEFAULT_FONT = "Caladea"
+	SVG_MUSIC_FONT   = "Noto Music"
+)
+
+var ErrInvalidSVG = errors.New("canvas: the rendered svg has no size")
+
+// fontFiles maps the font family used on the svg into the font file on the font directory
+var fontFiles = map[string]string{
+	"caladea":       "caladea.ttf",
+	"figtree":       "figtree.ttf",
+	"notomusic":     "noto-music.ttf",
+	"oldstandardtt": "old-standard-tt.ttf",
+	"mozart11":      "mozart11.ttf",
+
+	"serif":      "caladea.ttf",
+	"sans-serif": "figtree.ttf",
+}
+
+// fontFile returns the key and the file of the font family, caladea is used for the unknown font
+func fontFile(family string) (string, string) {
+	key := strings.ToLower(strings.ReplaceAll(family, " ", ""))
+	file, ok := fontFiles[key]
+	if !ok {
+		return "caladea", fontFiles["caladea"]
+	}
+	return key, file
+}
+
+// svgPainter draws the svg on the other format, every coordinate is on the svg pixel
+type svgPainter interface {
+	newPage(width, height float64)
+	setFont(family string, style svgStyle)
+	stringWidth(text string) float64
+	text(x, y float64, text string, style svgStyle)
+
+	line(x1, y1, x2, y2 float64, style svgStyle)
+	circle(cx, cy, r float64, style svgStyle)
+	rect(x, y, width, height float64, style svgStyle)
+	path(segments []pathSegment, style svgStyle)
+
+	err() error
+}
+
+// convertedCanvas renders the svg first, the svg is converted into the other format on End.
+// the page height is the height given on Start, computed by the renderer.
+type convertedCanvas struct {
+	Canvas
+
+	d       *deferredDelegator
+	svg     *bytes.Buffer
+	out     io.Writer
+	convert func(svg io.Reader, out io.Writer) error
+}
+
+// deferredDelegator holds the start of the write until the svg is converted,
+// the error of the conversion is sent before anything is written
+type deferredDelegator struct {
+	Delegator
+	started bool
+}
+
+func (dd *deferredDelegator) OnBeforeStartWrite() {
+	dd.started = true
+}
+
+func newConvertedCanvas(out io.Writer, d Delegator, convert func(svg io.Reader, out io.Writer) error) *convertedCanvas {
+	if d == nil {
+		d = &delegatorDiscard{}
+	}
+
+	svg := &bytes.Buffer{}
+	return &convertedCanvas{
+		Canvas:  NewBufferedCanvas(svg, d),
+		d:       &deferredDelegator{Delegator: d},
+		svg:     svg,
+		out:     out,
+		convert: convert,
+	}
+}
+
+func (c *convertedCanvas) End() {
+	c.Canvas.End()
+
+	var result bytes.Buffer
+	if err := c.convert(c.svg, &result); err != nil {
+		c.d.OnError(err)
+		return
+	}
+
+	if c.d.started {
+		c.d.Delegator.OnBeforeStartWrite()
+	}
+	if _, err := c.out.Write(result.Bytes()); err != nil {
+		c.d.OnError(err)
+	}
+}
+
+func (c *convertedCanvas) Delegator() Delegator {
+	return c.d
+}
+
+// convertSVG walks through the svg written by the canvas and draws it with the painter.
+// only the elements written by the renderer are supported: g, text, tspan, line, circle, rect and path
+func convertSVG(svg io.Reader, painter svgPainter) error {
+	decoder := xml.NewDecoder(svg)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	started := false
+	styles := []svgStyle{defaultSVGStyle}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			style := styles[len(styles)-1].inherit(t.Attr)
+
+			switch t.Name.Local {
+			case "svg":
+				if !started {
+					started = newPage(painter, t.Attr)
+				}
+			case "defs", "style", "title", "desc":
+				// the fonts are loaded from the font path instead
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			case "text":
+				runs, err := readText(decoder, t, style)
+				if err != nil {
+					return err
+				}
+				if started {
+					drawText(painter, runs)
+				}
+				// the end element is consumed by the text
+				continue
+			case "line", "circle", "rect", "path":
+				if started {
+					drawShape(painter, t, style)
+				}
+			}
+
+			styles = append(styles, style)
+
+		case xml.EndElement:
+			if len(styles) > 1 {
+				styles = styles[:len(styles)-1]
+			}
+		}
+
+		if err := painter.err(); err != nil {
+			return err
+		}
+	}
+
+	if !started {
+		return ErrInvalidSVG
+	}
+	return nil
+}
+
+func newPage(painter svgPainter, attrs []xml.Attr) bool {
+	width, height := 0.0, 0.0
+	for _, attr := range attrs {
+		switch attr.Name.Local {
+		case "width":
+			width = parseLength(attr.Value, 0)
+		case "height":
+			height = parseLength(attr.Value, 0)
+		}
+	}
+	if width == 0 || height == 0 {
+		return false
+	}
+
+	painter.newPage(width, height)
+	return true
+}
+
+func drawShape(painter svgPainter, t xml.StartElement, style svgStyle) {
+	attr := func(name string) float64 {
+		for _, a := range t.Attr {
+			if a.Name.Local == name {
+				return parseLength(a.Value, 0)
+			}
+		}
+		return 0
+	}
+
+	switch t.Name.Local {
+	case "line":
+		painter.line(attr("x1"), attr("y1"), attr("x2"), attr("y2"), style)
+	case "circle":
+		painter.circle(attr("cx"), attr("cy"), attr("r"), style)
+	case "rect":
+		painter.rect(attr("x"), attr("y"), attr("width"), attr("height"), style)
+	case "path":
+		d := ""
+		for _, a := range t.Attr {
+			if a.Name.Local == "d" {
+				d = a.Value
+			}
+		}
+		segments := parsePathData(d)
+		if len(segments) == 0 {
+			return
+		}
+		painter.path(segments, style)
+	}
+}
+
+// textRun is the part of the text with the same style, the tspan might move the position
+type textRun struct {
+	text  string
+	style svgStyle
+	x, y  *float64
+}
+
+// readText reads the text element with its tspan, the whitespace is collapsed as the browser does
+func readText(decoder *xml.Decoder, start xml.StartElement, style svgStyle) ([]textRun, error) {
+	runs := []textRun{}
+	x, y := positionAttr(start.Attr)
+	runs = append(runs, textRun{style: style, x: x, y: y})
+
+	styles := []svgStyle{style}
+	depth := 0
+	for depth >= 0 {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			childStyle := styles[len(styles)-1].inherit(t.Attr)
+			styles = append(styles, childStyle)
+			x, y := positionAttr(t.Attr)
+			runs = append(runs, textRun{style: childStyle, x: x, y: y})
+		case xml.EndElement:
+			depth--
+			styles = styles[:len(styles)-1]
+			if depth >= 0 {
+				// the text after the tspan is back to the parent style
+				runs = append(runs, textRun{style: styles[len(styles)-1]})
+			}
+		case xml.CharData:
+			runs[len(runs)-1].text += string(t)
+		}
+	}
+
+	collapseWhitespace(runs)
+	return runs, nil
+}
+
+func drawText(painter svgPainter, runs []textRun) {
+	// the anchor is applied on each chunk, the chunk starts on the absolute position
+	chunks := [][]textRun{}
+	for i, run := range runs {
+		if i == 0 || run.x != nil {
+			chunks = append(chunks, []textRun{})
+		}
+		chunks[len(chunks)-1] = append(chunks[len(chunks)-1], run)
+	}
+
+	cursorX, cursorY := 0.0, 0.0
+	for _, chunk := range chunks {
+		drawTextChunk(painter, chunk, &cursorX, &cursorY)
+	}
+}
+
+func drawTextChunk(painter svgPainter, runs []textRun, cursorX, cursorY *float64) {
+	if len(runs) == 0 {
+		return
+	}
+	if runs[0].x != nil {
+		*cursorX = *runs[0].x
+	}
+	if runs[0].y != nil {
+		*cursorY = *runs[0].y
+	}
+
+	width := 0.0
+	for _, run := range runs {
+		width += textWidth(painter, run)
+	}
+	switch runs[0].style.TextAnchor {
+	case "middle":
+		*cursorX -= width / 2
+	case "end":
+		*cursorX -= width
+	}
+
+	for i, run := range runs {
+		if i > 0 && run.y != nil {
+			*cursorY = *run.y
+		}
+		if run.text == "" {
+			continue
+		}
+		if _, ok := parseColor(run.style.Fill); !ok {
+			*cursorX += textWidth(painter, run)
+			continue
+		}
+		for _, part := range splitMusicSymbol(run.text) {
+			if part.symbol != 0 {
+				*cursorX += drawSymbol(painter, part.symbol, *cursorX, *cursorY, run.style)
+				continue
+			}
+			painter.setFont(part.fontFamily(run.style.FontFamily), run.style)
+			painter.text(*cursorX, *cursorY, part.text, run.style)
+			*cursorX += painter.stringWidth(part.text)
+		}
+	}
+}
+
+func textWidth(painter svgPainter, run textRun) float64 {
+	width := 0.0
+	for _, part := range splitMusicSymbol(run.text) {
+		if part.symbol != 0 {
+			width += symbolWidth(painter, part.symbol, run.style)
+			continue
+		}
+		painter.setFont(part.fontFamily(run.style.FontFamily), run.style)
+		width += painter.stringWidth(part.text)
+	}
+	return width
+}
+
+func positionAttr(attrs []xml.Attr) (*float64, *float64) {
+	var x, y *float64
+	for _, attr := range attrs {
+		value := parseLength(attr.Value, 0)
+		switch attr.Name.Local {
+		case "x":
+			x = &value
+		case "y":
+			y = &value
+		}
+	}
+	return x, y
+}
+
+// collapseWhitespace removes the new lines and the repeated spaces, the leading and trailing spaces of the text are removed
+func collapseWhitespace(runs []textRun) {
+	prevSpace := true
+	for i := range runs {
+		var sb strings.Builder
+		for _, r := range runs[i].text {
+			// the non breaking space is kept
+			if r != ' ' && unicode.IsSpace(r) {
+				if prevSpace {
+					continue
+				}
+				prevSpace = true
+				sb.WriteRune(' ')
+				continue
+			}
+			prevSpace = false
+			sb.WriteRune(r)
+		}
+		runs[i].text = sb.String()
+	}
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		runs[i].text = strings.TrimRight(runs[i].text, " ")
+		if runs[i].text != "" {
+			break
+		}
+	}
+}
+
+type textPart struct {
+	text   string
+	music  bool
+	family string // overrides the font family of the text, e.g. the mozart11 glyph of the music symbol
+	symbol rune   // the music symbol drawn as the shape
+}
+
+// fontFamily returns the music font for the music symbols, the text fonts does not have them
+func (tp textPart) fontFamily(family string) string {
+	if tp.family != "" {
+		return tp.family
+	}
+	if tp.music && !strings.EqualFold(family, "mozart11") {
+		return SVG_MUSIC_FONT
+	}
+	return family
+}
+
+// musicGlyphs maps the music symbols into the mozart11 glyph, the bundled noto music has the latin glyphs only
+var musicGlyphs = map[rune]rune{
+	0x1D110: 0xF05D, // fermata
+	0x266A:  0xF09C, // eighth note
+	0x266D:  0xF095, // flat
+	0x266E:  0xF096, // natural
+	0x266F:  0xF097, // sharp
+}
+
+// splitMusicSymbol splits the music symbols (e.g. ♩ or 𝄐) from the text, every drawn symbol is on its own part
+func splitMusicSymbol(text string) []textPart {
+	result := []textPart{}
+	for _, r := range text {
+		if isDrawnSymbol(r) {
+			result = append(result, textPart{music: true, symbol: r})
+			continue
+		}
+
+		part := textPart{music: isMusicSymbol(r)}
+		if glyph, ok := musicGlyphs[r]; ok {
+			r, part.family = glyph, "mozart11"
+		}
+
+		last := len(result) - 1
+		if last < 0 || result[last].symbol != 0 || result[last].music != part.music || result[last].family != part.family {
+			result = append(result, part)
+		}
+		result[len(result)-1].text += string(r)
+	}
+	return result
+}
+
+func isMusicSymbol(r rune) bool {
+	return (r >= 0x2669 && r <= 0x266F) || (r >= 0x1D100 && r <= 0x1D1FF)
+}
@@ -0,0 +1,147 @@
+package canvas
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertedCanvas_End(t *testing.T) {
+	errConvert := errors.New("convert failed")
+
+	tests := []struct {
+		name      string
+		convert   func(svg io.Reader, out io.Writer) error
+		delegator func(*gomock.Controller) *MockDelegator
+		want      string
+	}{
+		{
+			name: "the start of the write is sent after the conversion",
+			convert: func(svg io.Reader, out io.Writer) error {
+				_, err := out.Write([]byte("converted"))
+				return err
+			},
+			delegator: func(ctrl *gomock.Controller) *MockDelegator {
+				d := NewMockDelegator(ctrl)
+				d.EXPECT().OnBeforeStartWrite().Times(1)
+				return d
+			},
+			want: "converted",
+		},
+		{
+			name: "the conversion failed before anything is written",
+			convert: func(svg io.Reader, out io.Writer) error {
+				return errConvert
+			},
+			delegator: func(ctrl *gomock.Controller) *MockDelegator {
+				d := NewMockDelegator(ctrl)
+				d.EXPECT().OnBeforeStartWrite().Times(0)
+				d.EXPECT().OnError(errConvert).Return(DelegatorErrorFlowControlStop)
+				return d
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var out bytes.Buffer
+			canv := newConvertedCanvas(&out, tt.delegator(ctrl), tt.convert)
+			canv.Start(100, 100)
+			canv.Delegator().OnBeforeStartWrite()
+			canv.End()
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestParsePathData(t *testing.T) {
+	tests := []struct {
+		name string
+		d    string
+		want []pathSegment
+	}{
+		{
+			name: "absolute line",
+			d:    "M 10 20 L 30 40 Z",
+			want: []pathSegment{
+				{Command: 'M', Points: []float64{10, 20}},
+				{Command: 'L', Points: []float64{30, 40}},
+				{Command: 'Z'},
+			},
+		},
+		{
+			name: "relative with the implicit line to",
+			d:    "m10,20 5,5 h5 v-10",
+			want: []pathSegment{
+				{Command: 'M', Points: []float64{10, 20}},
+				{Command: 'L', Points: []float64{15, 25}},
+				{Command: 'L', Points: []float64{20, 25}},
+				{Command: 'L', Points: []float64{20, 15}},
+			},
+		},
+		{
+			name: "quadratic is converted into cubic",
+			d:    "M0 0 Q 30 30 60 0",
+			want: []pathSegment{
+				{Command: 'M', Points: []float64{0, 0}},
+				{Command: 'C', Points: []float64{20, 20, 40, 20, 60, 0}},
+			},
+		},
+		{
+			name: "smooth cubic reflects the previous control point",
+			d:    "M0 0 C 0 10 10 10 10 0 S 20 -10 20 0",
+			want: []pathSegment{
+				{Command: 'M', Points: []float64{0, 0}},
+				{Command: 'C', Points: []float64{0, 10, 10, 10, 10, 0}},
+				{Command: 'C', Points: []float64{10, -10, 20, -10, 20, 0}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parsePathData(tt.d))
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      [3]int
+		wantPaint bool
+	}{
+		{name: "none", value: "none"},
+		{name: "named", value: "black", want: [3]int{0, 0, 0}, wantPaint: true},
+		{name: "short hex", value: "#f00", want: [3]int{255, 0, 0}, wantPaint: true},
+		{name: "hex", value: "#808080", want: [3]int{128, 128, 128}, wantPaint: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, paint := parseColor(tt.value)
+			assert.Equal(t, tt.wantPaint, paint)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitMusicSymbol(t *testing.T) {
+	assert.Equal(t, []textPart{
+		{text: "Andante "},
+		{music: true, symbol: '♩'},
+		{text: " = 90"},
+	}, splitMusicSymbol("Andante ♩ = 90"))
+
+	assert.Equal(t, []textPart{
+		{text: "\uF05D\uF09C", music: true, family: "mozart11"},
+		{music: true, symbol: 0x1D100},
+		{music: true, symbol: 0x1D100},
+	}, splitMusicSymbol("\U0001D110♪\U0001D100\U0001D100"))
+}
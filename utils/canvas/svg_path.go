@@ -0,0 +1,183 @@
+package canvas
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is the absolute segment of the svg path data, the quadratic and the smooth curves are converted into the cubic one
+type pathSegment struct {
+	Command byte // M, L, C or Z
+	Points  []float64
+}
+
+var pathTokenRegex = regexp.MustCompile(`[MmLlHhVvCcSsQqTtAaZz]|[-+]?(?:\d+\.?\d*|\.\d+)(?:[eE][-+]?\d+)?`)
+
+// parsePathData parses the d attribute of the svg path
+func parsePathData(d string) []pathSegment {
+	tokens := pathTokenRegex.FindAllString(d, -1)
+
+	result := []pathSegment{}
+	var cmd byte
+	var x, y, startX, startY float64
+	// the last control point, used by the smooth curves
+	var ctrlX, ctrlY float64
+	var prevCmd byte
+
+	i := 0
+	next := func() (float64, bool) {
+		if i >= len(tokens) || isPathCommand(tokens[i]) {
+			return 0, false
+		}
+		v, _ := strconv.ParseFloat(tokens[i], 64)
+		i++
+		return v, true
+	}
+	nextN := func(n int) ([]float64, bool) {
+		values := make([]float64, n)
+		for k := range values {
+			v, ok := next()
+			if !ok {
+				return nil, false
+			}
+			values[k] = v
+		}
+		return values, true
+	}
+
+	for i < len(tokens) {
+		if isPathCommand(tokens[i]) {
+			cmd = tokens[i][0]
+			i++
+		} else if cmd == 0 {
+			i++
+			continue
+		}
+
+		relative := cmd >= 'a' && cmd <= 'z'
+		offsetX, offsetY := 0.0, 0.0
+		if relative {
+			offsetX, offsetY = x, y
+		}
+
+		upper := strings.ToUpper(string(cmd))[0]
+		switch upper {
+		case 'Z':
+			result = append(result, pathSegment{Command: 'Z'})
+			x, y = startX, startY
+			prevCmd = 'Z'
+			// Z does not take any argument, wait for the next command
+			cmd = 0
+			continue
+
+		case 'M', 'L', 'T':
+			v, ok := nextN(2)
+			if !ok {
+				return result
+			}
+			nx, ny := v[0]+offsetX, v[1]+offsetY
+
+			switch {
+			case upper == 'M':
+				result = append(result, pathSegment{Command: 'M', Points: []float64{nx, ny}})
+				startX, startY = nx, ny
+				// the following pairs are the line to
+				if relative {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+				ctrlX, ctrlY = nx, ny
+			case upper == 'T':
+				cx, cy := x, y
+				if prevCmd == 'Q' || prevCmd == 'T' {
+					cx, cy = 2*x-ctrlX, 2*y-ctrlY
+				}
+				result = append(result, quadraticToCubic(x, y, cx, cy, nx, ny))
+				ctrlX, ctrlY = cx, cy
+			default:
+				result = append(result, pathSegment{Command: 'L', Points: []float64{nx, ny}})
+				ctrlX, ctrlY = nx, ny
+			}
+			x, y = nx, ny
+
+		case 'H', 'V':
+			v, ok := next()
+			if !ok {
+				return result
+			}
+			if upper == 'H' {
+				x = v + offsetX
+			} else {
+				y = v + offsetY
+			}
+			result = append(result, pathSegment{Command: 'L', Points: []float64{x, y}})
+			ctrlX, ctrlY = x, y
+
+		case 'C', 'S':
+			n := 6
+			if upper == 'S' {
+				n = 4
+			}
+			v, ok := nextN(n)
+			if !ok {
+				return result
+			}
+			if upper == 'S' {
+				c1x, c1y := x, y
+				if prevCmd == 'C' || prevCmd == 'S' {
+					c1x, c1y = 2*x-ctrlX, 2*y-ctrlY
+				}
+				v = append([]float64{c1x - offsetX, c1y - offsetY}, v...)
+			}
+			points := []float64{
+				v[0] + offsetX, v[1] + offsetY,
+				v[2] + offsetX, v[3] + offsetY,
+				v[4] + offsetX, v[5] + offsetY,
+			}
+			result = append(result, pathSegment{Command: 'C', Points: points})
+			ctrlX, ctrlY = points[2], points[3]
+			x, y = points[4], points[5]
+
+		case 'Q':
+			v, ok := nextN(4)
+			if !ok {
+				return result
+			}
+			cx, cy := v[0]+offsetX, v[1]+offsetY
+			nx, ny := v[2]+offsetX, v[3]+offsetY
+			result = append(result, quadraticToCubic(x, y, cx, cy, nx, ny))
+			ctrlX, ctrlY = cx, cy
+			x, y = nx, ny
+
+		case 'A':
+			// the arc is not used by the renderer, it is drawn as the straight line to the end point
+			v, ok := nextN(7)
+			if !ok {
+				return result
+			}
+			x, y = v[5]+offsetX, v[6]+offsetY
+			result = append(result, pathSegment{Command: 'L', Points: []float64{x, y}})
+			ctrlX, ctrlY = x, y
+		}
+		prevCmd = upper
+	}
+
+	return result
+}
+
+func isPathCommand(token string) bool {
+	return len(token) == 1 && strings.ContainsAny(token, "MmLlHhVvCcSsQqTtAaZz")
+}
+
+func quadraticToCubic(x, y, cx, cy, nx, ny float64) pathSegment {
+	return pathSegment{
+		Command: 'C',
+		Points: []float64{
+			x + (2.0/3.0)*(cx-x), y + (2.0/3.0)*(cy-y),
+			nx + (2.0/3.0)*(cx-nx), ny + (2.0/3.0)*(cy-ny),
+			nx, ny,
+		},
+	}
+}
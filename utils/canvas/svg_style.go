@@ -0,0 +1,167 @@
+package canvas
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// svgStyle is the computed svg presentation of an element, inherited from the parent group
+type svgStyle struct {
+	FontFamily string
+	FontSize   float64
+	Bold       bool
+	Italic     bool
+	TextAnchor string
+
+	Fill        string
+	Stroke      string
+	StrokeWidth float64
+	LineCap     string
+	DashArray   []float64
+	DashOffset  float64
+
+	Opacity float64
+}
+
+var defaultSVGStyle = svgStyle{
+	FontFamily:  SVG_DEFAULT_FONT,
+	FontSize:    16,
+	Fill:        "#000000",
+	Stroke:      "none",
+	StrokeWidth: 1,
+	LineCap:     "butt",
+	Opacity:     1,
+}
+
+// inherit returns the style of the child element, the attributes are applied before the style attribute
+func (ps svgStyle) inherit(attrs []xml.Attr) svgStyle {
+	result := ps
+	result.Opacity = 1
+
+	style := ""
+	for _, attr := range attrs {
+		if attr.Name.Local == "style" {
+			style = attr.Value
+			continue
+		}
+		result.apply(attr.Name.Local, attr.Value, ps)
+	}
+
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		result.apply(strings.TrimSpace(property), strings.TrimSpace(value), ps)
+	}
+
+	result.Opacity *= ps.Opacity
+	return result
+}
+
+func (ps *svgStyle) apply(property, value string, parent svgStyle) {
+	switch property {
+	case "font-family":
+		ps.FontFamily = strings.Trim(strings.Split(value, ",")[0], `'" `)
+	case "font-size":
+		ps.FontSize = parseFontSize(value, parent.FontSize)
+	case "font-weight":
+		weight, err := strconv.Atoi(value)
+		ps.Bold = value == "bold" || value == "bolder" || (err == nil && weight >= 600)
+	case "font-style":
+		// the bold font style is written by the footnote
+		ps.Italic = value == "italic" || value == "oblique"
+		ps.Bold = ps.Bold || value == "bold"
+	case "text-anchor":
+		ps.TextAnchor = value
+	case "fill":
+		ps.Fill = value
+	case "stroke":
+		ps.Stroke = value
+	case "stroke-width":
+		ps.StrokeWidth = parseLength(value, ps.StrokeWidth)
+	case "stroke-linecap":
+		ps.LineCap = value
+	case "stroke-dasharray":
+		ps.DashArray = nil
+		for _, dash := range strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }) {
+			ps.DashArray = append(ps.DashArray, parseLength(dash, 0))
+		}
+	case "stroke-dashoffset":
+		ps.DashOffset = parseLength(value, 0)
+	case "opacity":
+		ps.Opacity = parseLength(value, 1)
+	}
+}
+
+// fontStyle returns the style of the fpdf font, e.g. "BI"
+func (ps svgStyle) fontStyle() string {
+	result := ""
+	if ps.Bold {
+		result += "B"
+	}
+	if ps.Italic {
+		result += "I"
+	}
+	return result
+}
+
+// parseFontSize supports the pixel, point and the percentage of the parent font size
+func parseFontSize(value string, parent float64) float64 {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasSuffix(value, "%"):
+		return parent * parseLength(strings.TrimSuffix(value, "%"), 100) / 100
+	case strings.HasSuffix(value, "pt"):
+		return parseLength(strings.TrimSuffix(value, "pt"), parent*0.75) / 0.75
+	}
+	return parseLength(value, parent)
+}
+
+func parseLength(value string, fallback float64) float64 {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+	return result
+}
+
+var namedColors = map[string][3]int{
+	"black": {0, 0, 0},
+	"white": {255, 255, 255},
+	"red":   {255, 0, 0},
+	"gray":  {128, 128, 128},
+	"grey":  {128, 128, 128},
+}
+
+// parseColor returns the rgb of the color, false when it is not painted
+func parseColor(value string) ([3]int, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "none" || value == "transparent" {
+		return [3]int{}, false
+	}
+
+	if rgb, ok := namedColors[value]; ok {
+		return rgb, true
+	}
+
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return [3]int{}, true
+	}
+
+	result := [3]int{}
+	for i := range result {
+		c, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return [3]int{}, true
+		}
+		result[i] = int(c)
+	}
+	return result, true
+}
@@ -0,0 +1,65 @@
+package canvas
+
+// the music symbols without any glyph on the bundled fonts, they are drawn as the shapes.
+// the sizes are on the em of the font size, measured from the noto music glyph
+const (
+	SYMBOL_THIN_LINE_EM  = 0.03
+	SYMBOL_THICK_LINE_EM = 0.11
+	SYMBOL_BARLINE_TOP   = 0.9
+	SYMBOL_BARLINE_BASE  = 0.1
+
+	SYMBOL_NOTEHEAD = 0xF09B
+	SYMBOL_STEM_EM  = 0.7
+)
+
+// symbolBar is the vertical line of the barline, x is the left side of the line
+type symbolBar struct {
+	x, width float64
+}
+
+// barlineSymbols is the lines and the advance width of the barline symbols, the same as the barline width of the renderer
+var barlineSymbols = map[rune]struct {
+	bars  []symbolBar
+	width float64
+}{
+	0x1D100: {bars: []symbolBar{{0.055, SYMBOL_THIN_LINE_EM}}, width: 0.144},                                // single
+	0x1D101: {bars: []symbolBar{{0.055, SYMBOL_THIN_LINE_EM}, {0.135, SYMBOL_THIN_LINE_EM}}, width: 0.218},  // double
+	0x1D102: {bars: []symbolBar{{0.055, SYMBOL_THIN_LINE_EM}, {0.125, SYMBOL_THICK_LINE_EM}}, width: 0.267}, // final
+	0x1D103: {bars: []symbolBar{{0.03, SYMBOL_THICK_LINE_EM}, {0.19, SYMBOL_THIN_LINE_EM}}, width: 0.267},   // reverse final
+}
+
+// isDrawnSymbol tells whether the symbol is drawn as the shape: the barlines and the quarter note
+func isDrawnSymbol(r rune) bool {
+	_, ok := barlineSymbols[r]
+	return ok || r == 0x2669
+}
+
+func symbolWidth(painter svgPainter, r rune, style svgStyle) float64 {
+	if barline, ok := barlineSymbols[r]; ok {
+		return barline.width * style.FontSize
+	}
+
+	painter.setFont("mozart11", style)
+	return painter.stringWidth(string(rune(SYMBOL_NOTEHEAD)))
+}
+
+// drawSymbol draws the symbol on the baseline, returns its advance width
+func drawSymbol(painter svgPainter, r rune, x, y float64, style svgStyle) float64 {
+	em := style.FontSize
+	fill := style
+	fill.Stroke = "none"
+
+	if barline, ok := barlineSymbols[r]; ok {
+		for _, bar := range barline.bars {
+			painter.rect(x+bar.x*em, y-SYMBOL_BARLINE_TOP*em, bar.width*em, (SYMBOL_BARLINE_TOP-SYMBOL_BARLINE_BASE)*em, fill)
+		}
+		return barline.width * em
+	}
+
+	// the quarter note is the notehead with the stem on its right side
+	painter.setFont("mozart11", style)
+	painter.text(x, y, string(rune(SYMBOL_NOTEHEAD)), style)
+	width := painter.stringWidth(string(rune(SYMBOL_NOTEHEAD)))
+	painter.rect(x+width-SYMBOL_THIN_LINE_EM*em, y-SYMBOL_STEM_EM*em, SYMBOL_THIN_LINE_EM*em, SYMBOL_STEM_EM*em, fill)
+	return width
+}
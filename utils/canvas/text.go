@@ -0,0 +1,61 @@
+package canvas
+
+import "io"
+
+// TextCanvas ignores all of the drawing, only the content written into the writer is kept.
+// it is used by the plain text and the midi renderer
+type TextCanvas struct {
+	out io.Writer
+	d   Delegator
+}
+
+func NewTextCanvas(out io.Writer, d Delegator) Canvas {
+	return &TextCanvas{
+		out: out,
+		d:   d,
+	}
+}
+
+func (c *TextCanvas) Start(w int, h int, ns ...string) {
+}
+func (c *TextCanvas) End() {
+}
+func (c *TextCanvas) Def() {
+}
+func (c *TextCanvas) DefEnd() {
+}
+func (c *TextCanvas) Group(s ...string) {
+}
+func (c *TextCanvas) Gend() {
+}
+func (c *TextCanvas) Circle(x int, y int, r int, s ...string) {
+}
+func (c *TextCanvas) Line(x1 int, y1 int, x2 int, y2 int, s ...string) {
+}
+func (c *TextCanvas) Path(d string, s ...string) {
+}
+func (c *TextCanvas) Rect(x int, y int, w int, h int, s ...string) {
+}
+func (c *TextCanvas) CenterRect(x int, y int, w int, h int, s ...string) {
+}
+func (c *TextCanvas) Qbez(sx int, sy int, cx int, cy int, ex int, ey int, s ...string) {
+}
+func (c *TextCanvas) Qbezier(sx int, sy int, cx int, cy int, ex int, ey int, tx int, ty int, s ...string) {
+}
+func (c *TextCanvas) Text(x int, y int, t string, s ...string) {
+}
+func (c *TextCanvas) TextUnescaped(x float64, y float64, t string, s ...string) {
+}
+func (c *TextCanvas) LineFloat64(x1, y1, x2, y2 float64, s ...string) {
+}
+
+func (c *TextCanvas) Writer() io.Writer {
+	return c.out
+}
+
+func (c *TextCanvas) Delegator() Delegator {
+	if c.d == nil {
+		return &delegatorDiscard{}
+	}
+	return c.d
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"gopkg.in/gcfg.v1"
 )
@@ -10,8 +12,14 @@ type Config struct {
 	Webserver WebServerConfig
 	MusicXML  MusicXMLConfig
 	SQLite    SQLiteConfig
+	Assets    AssetsConfig
+	// the hymnals by the prefix of the url, e.g. [Hymnal "kidung-jemaat"]
+	Hymnal map[string]*HymnalConfig
 }
 
+// the hymnal of the [Musicxml] and [SQLite] when there is no hymnal configured
+const DEFAULT_HYMNAL = "kidung-jemaat"
+
 type MusicXMLConfig struct {
 	Path       string
 	FilePrefix string
@@ -25,6 +33,82 @@ type SQLiteConfig struct {
 	DBPath string
 }
 
+type AssetsConfig struct {
+	FontPath string
+}
+
+type HymnalConfig struct {
+	Title        string
+	MusicXMLPath string
+	FilePrefix   string
+	DBPath       string
+	// the prefix of the tables, e.g. pkj for pkj_hymn. the tables of the kidung jemaat when it is empty
+	TablePrefix string
+	// the hymnal referenced by the column of the hymn, written as code:hymnal. e.g. nr:nyanyikanlah-kidung-baru
+	Reference []string
+}
+
+// Hymnal is the configured hymnal, ready to use
+type Hymnal struct {
+	Slug        string
+	Title       string
+	MusicXML    MusicXMLConfig
+	DBPath      string
+	TablePrefix string
+	// the hymnal by the code of the reference column
+	References map[string]string
+}
+
+// GetHymnals returns the hymnals ordered by the slug, or the kidung jemaat of the [Musicxml] and [SQLite]
+func (c Config) GetHymnals() ([]Hymnal, error) {
+	if len(c.Hymnal) == 0 {
+		return []Hymnal{
+			{
+				Slug:       DEFAULT_HYMNAL,
+				Title:      "Kidung Jemaat",
+				MusicXML:   c.MusicXML,
+				DBPath:     c.SQLite.DBPath,
+				References: map[string]string{},
+			},
+		}, nil
+	}
+
+	slugs := make([]string, 0, len(c.Hymnal))
+	for slug := range c.Hymnal {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	result := make([]Hymnal, 0, len(slugs))
+	for _, slug := range slugs {
+		hc := c.Hymnal[slug]
+		hymnal := Hymnal{
+			Slug:  slug,
+			Title: hc.Title,
+			MusicXML: MusicXMLConfig{
+				Path:       hc.MusicXMLPath,
+				FilePrefix: hc.FilePrefix,
+			},
+			DBPath:      hc.DBPath,
+			TablePrefix: hc.TablePrefix,
+			References:  map[string]string{},
+		}
+		if hymnal.Title == "" {
+			hymnal.Title = slug
+		}
+
+		for _, reference := range hc.Reference {
+			code, target, ok := strings.Cut(reference, ":")
+			if !ok || strings.TrimSpace(code) == "" || strings.TrimSpace(target) == "" {
+				return nil, fmt.Errorf("invalid reference %q of hymnal %s", reference, slug)
+			}
+			hymnal.References[strings.TrimSpace(code)] = strings.TrimSpace(target)
+		}
+		result = append(result, hymnal)
+	}
+	return result, nil
+}
+
 func InitConfig(env string) (Config, error) {
 	result := Config{}
 
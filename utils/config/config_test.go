@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/gcfg.v1"
+)
+
+func TestConfig_GetHymnals(t *testing.T) {
+	tests := []struct {
+		name    string
+		ini     string
+		want    []Hymnal
+		wantErr bool
+	}{
+		{
+			name: "the kidung jemaat without the hymnal",
+			ini: `
+[Musicxml]
+	Path = "/scores/"
+	FilePrefix = "kj"
+[SQLite]
+	DBPath = "/kj.db"`,
+			want: []Hymnal{
+				{
+					Slug:       DEFAULT_HYMNAL,
+					Title:      "Kidung Jemaat",
+					MusicXML:   MusicXMLConfig{Path: "/scores/", FilePrefix: "kj"},
+					DBPath:     "/kj.db",
+					References: map[string]string{},
+				},
+			},
+		},
+		{
+			name: "the hymnals",
+			ini: `
+[Hymnal "pelengkap-kidung-jemaat"]
+	MusicXMLPath = "/scores/pkj/"
+	FilePrefix = "pkj"
+	DBPath = "/kj.db"
+	TablePrefix = "pkj"
+[Hymnal "kidung-jemaat"]
+	Title = "Kidung Jemaat"
+	MusicXMLPath = "/scores/kj/"
+	FilePrefix = "kj"
+	DBPath = "/kj.db"
+	Reference = "nr:nyanyikanlah-kidung-baru"
+	Reference = "be : pelengkap-kidung-jemaat"`,
+			want: []Hymnal{
+				{
+					Slug:     "kidung-jemaat",
+					Title:    "Kidung Jemaat",
+					MusicXML: MusicXMLConfig{Path: "/scores/kj/", FilePrefix: "kj"},
+					DBPath:   "/kj.db",
+					References: map[string]string{
+						"nr": "nyanyikanlah-kidung-baru",
+						"be": "pelengkap-kidung-jemaat",
+					},
+				},
+				{
+					Slug:        "pelengkap-kidung-jemaat",
+					Title:       "pelengkap-kidung-jemaat",
+					MusicXML:    MusicXMLConfig{Path: "/scores/pkj/", FilePrefix: "pkj"},
+					DBPath:      "/kj.db",
+					TablePrefix: "pkj",
+					References:  map[string]string{},
+				},
+			},
+		},
+		{
+			name: "invalid reference",
+			ini: `
+[Hymnal "kidung-jemaat"]
+	Reference = "nyanyikanlah-kidung-baru"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{}
+			assert.NoError(t, gcfg.ReadStringInto(&cfg, tt.ini))
+
+			got, err := cfg.GetHymnals()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package fonts
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+var (
+	ErrInvalidFont     = errors.New("fonts: invalid truetype font")
+	ErrUnsupportedFont = errors.New("fonts: the font has no glyf table")
+)
+
+// the tables which are dropped from the subset. the substitution (e.g. the fi ligature) might point to the removed glyph
+var droppedTables = map[string]bool{
+	"GSUB": true,
+	"DSIG": true,
+}
+
+// the flags of the composite glyph component
+const (
+	compositeArgsAreWords = 0x0001
+	compositeHaveScale    = 0x0008
+	compositeMore         = 0x0020
+	compositeXYScale      = 0x0040
+	compositeTwoByTwo     = 0x0080
+)
+
+// Subset removes the outline of the glyphs which are not used by the text, the glyph ids are kept
+// so the cmap, the metrics and the kerning of the font are still valid without rewriting them.
+func Subset(raw []byte, text []rune) ([]byte, error) {
+	tables, err := readTables(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	head, maxp, loca, glyf := tables["head"], tables["maxp"], tables["loca"], tables["glyf"]
+	if glyf == nil || loca == nil {
+		return nil, ErrUnsupportedFont
+	}
+	if len(head) < 54 || len(maxp) < 6 {
+		return nil, ErrInvalidFont
+	}
+
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	offsets, err := readLoca(loca, numGlyphs, longLoca)
+	if err != nil {
+		return nil, err
+	}
+
+	glyphData := func(gid int) []byte {
+		if gid >= numGlyphs || offsets[gid] > offsets[gid+1] || int(offsets[gid+1]) > len(glyf) {
+			return nil
+		}
+		return glyf[offsets[gid]:offsets[gid+1]]
+	}
+
+	// .notdef is always kept
+	used := map[int]bool{0: true}
+	queue := []int{0}
+	for _, r := range text {
+		if gid := glyphIndex(tables["cmap"], r); gid > 0 && !used[gid] {
+			used[gid] = true
+			queue = append(queue, gid)
+		}
+	}
+	// the component of the composite glyph is used as well
+	for len(queue) > 0 {
+		gid := queue[0]
+		queue = queue[1:]
+		for _, component := range components(glyphData(gid)) {
+			if !used[component] {
+				used[component] = true
+				queue = append(queue, component)
+			}
+		}
+	}
+
+	newGlyf := []byte{}
+	newOffsets := make([]uint32, numGlyphs+1)
+	for gid := 0; gid < numGlyphs; gid++ {
+		newOffsets[gid] = uint32(len(newGlyf))
+		if used[gid] {
+			newGlyf = append(newGlyf, glyphData(gid)...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	newOffsets[numGlyphs] = uint32(len(newGlyf))
+
+	tables["glyf"] = newGlyf
+	tables["loca"] = writeLoca(newOffsets, longLoca)
+	for tag := range droppedTables {
+		delete(tables, tag)
+	}
+
+	return writeFont(raw[:4], tables), nil
+}
+
+func readTables(raw []byte) (map[string][]byte, error) {
+	if len(raw) < 12 {
+		return nil, ErrInvalidFont
+	}
+
+	result := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(raw[4:]))
+	for i := 0; i < numTables; i++ {
+		record := 12 + 16*i
+		if record+16 > len(raw) {
+			return nil, ErrInvalidFont
+		}
+		tag := string(raw[record : record+4])
+		offset := binary.BigEndian.Uint32(raw[record+8:])
+		length := binary.BigEndian.Uint32(raw[record+12:])
+		if uint64(offset)+uint64(length) > uint64(len(raw)) {
+			return nil, ErrInvalidFont
+		}
+		result[tag] = raw[offset : offset+length]
+	}
+	return result, nil
+}
+
+func readLoca(loca []byte, numGlyphs int, long bool) ([]uint32, error) {
+	size := 2
+	if long {
+		size = 4
+	}
+	if len(loca) < (numGlyphs+1)*size {
+		return nil, ErrInvalidFont
+	}
+
+	result := make([]uint32, numGlyphs+1)
+	for i := range result {
+		if long {
+			result[i] = binary.BigEndian.Uint32(loca[i*4:])
+		} else {
+			result[i] = uint32(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+	}
+	return result, nil
+}
+
+func writeLoca(offsets []uint32, long bool) []byte {
+	if long {
+		result := make([]byte, len(offsets)*4)
+		for i, offset := range offsets {
+			binary.BigEndian.PutUint32(result[i*4:], offset)
+		}
+		return result
+	}
+
+	result := make([]byte, len(offsets)*2)
+	for i, offset := range offsets {
+		binary.BigEndian.PutUint16(result[i*2:], uint16(offset/2))
+	}
+	return result
+}
+
+// components returns the glyph ids used by the composite glyph
+func components(glyph []byte) []int {
+	if len(glyph) < 10 || int16(binary.BigEndian.Uint16(glyph)) >= 0 {
+		return nil
+	}
+
+	result := []int{}
+	pos := 10
+	for pos+4 <= len(glyph) {
+		flags := binary.BigEndian.Uint16(glyph[pos:])
+		result = append(result, int(binary.BigEndian.Uint16(glyph[pos+2:])))
+		pos += 4
+
+		if flags&compositeArgsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&compositeHaveScale != 0:
+			pos += 2
+		case flags&compositeXYScale != 0:
+			pos += 4
+		case flags&compositeTwoByTwo != 0:
+			pos += 8
+		}
+
+		if flags&compositeMore == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// glyphIndex looks up the glyph of the rune on the unicode cmap, format 4 and 12 are supported
+func glyphIndex(cmap []byte, r rune) int {
+	if len(cmap) < 4 {
+		return 0
+	}
+
+	numSubtables := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < numSubtables && 4+8*i+8 <= len(cmap); i++ {
+		record := cmap[4+8*i:]
+		platform, encoding := binary.BigEndian.Uint16(record), binary.BigEndian.Uint16(record[2:])
+		// unicode platform, or the windows unicode bmp, the windows symbol and the windows unicode full
+		if platform != 0 && !(platform == 3 && (encoding == 0 || encoding == 1 || encoding == 10)) {
+			continue
+		}
+
+		offset := int(binary.BigEndian.Uint32(record[4:]))
+		if offset+2 > len(cmap) {
+			continue
+		}
+		subtable := cmap[offset:]
+
+		var gid int
+		switch binary.BigEndian.Uint16(subtable) {
+		case 4:
+			gid = glyphIndexFormat4(subtable, r)
+		case 12:
+			gid = glyphIndexFormat12(subtable, r)
+		}
+		if gid > 0 {
+			return gid
+		}
+	}
+	return 0
+}
+
+func glyphIndexFormat4(subtable []byte, r rune) int {
+	if r > 0xFFFF || len(subtable) < 14 {
+		return 0
+	}
+	segments := int(binary.BigEndian.Uint16(subtable[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segments*2 + 2
+	deltas := startCodes + segments*2
+	rangeOffsets := deltas + segments*2
+	if rangeOffsets+segments*2 > len(subtable) {
+		return 0
+	}
+
+	c := uint16(r)
+	for i := 0; i < segments; i++ {
+		end := binary.BigEndian.Uint16(subtable[endCodes+i*2:])
+		if c > end {
+			continue
+		}
+		start := binary.BigEndian.Uint16(subtable[startCodes+i*2:])
+		if c < start {
+			return 0
+		}
+
+		delta := binary.BigEndian.Uint16(subtable[deltas+i*2:])
+		rangeOffset := int(binary.BigEndian.Uint16(subtable[rangeOffsets+i*2:]))
+		if rangeOffset == 0 {
+			return int(c + delta)
+		}
+
+		pos := rangeOffsets + i*2 + rangeOffset + int(c-start)*2
+		if pos+2 > len(subtable) {
+			return 0
+		}
+		gid := binary.BigEndian.Uint16(subtable[pos:])
+		if gid == 0 {
+			return 0
+		}
+		return int(gid + delta)
+	}
+	return 0
+}
+
+func glyphIndexFormat12(subtable []byte, r rune) int {
+	if len(subtable) < 16 {
+		return 0
+	}
+	groups := int(binary.BigEndian.Uint32(subtable[12:]))
+	for i := 0; i < groups && 16+12*i+12 <= len(subtable); i++ {
+		group := subtable[16+12*i:]
+		start, end := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:])
+		if uint32(r) >= start && uint32(r) <= end {
+			return int(binary.BigEndian.Uint32(group[8:]) + uint32(r) - start)
+		}
+	}
+	return 0
+}
+
+// writeFont writes the tables with the new table directory, the checksums are recalculated
+func writeFont(version []byte, tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	header := make([]byte, 12+16*numTables)
+	copy(header, version)
+	binary.BigEndian.PutUint16(header[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(header[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(header[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(header[10:], uint16(numTables*16-searchRange))
+
+	body := []byte{}
+	headOffset := -1
+	for i, tag := range tags {
+		data := tables[tag]
+		if tag == "head" {
+			// the adjustment is calculated after the whole font is written
+			data = append([]byte{}, data...)
+			binary.BigEndian.PutUint32(data[8:], 0)
+			headOffset = len(header) + len(body)
+		}
+
+		record := header[12+16*i:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], checksum(data))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(header)+len(body)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(data)))
+
+		body = append(body, data...)
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+	}
+
+	result := append(header, body...)
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(result[headOffset+8:], 0xB1B0AFBA-checksum(result))
+	}
+	return result
+}
+
+func checksum(data []byte) uint32 {
+	var result uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		result += binary.BigEndian.Uint32(word[:])
+	}
+	return result
+}
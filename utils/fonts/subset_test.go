@@ -0,0 +1,102 @@
+package fonts
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/sfnt"
+)
+
+func TestSubset(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		text  string
+		kept  []rune
+		empty []rune
+	}{
+		{
+			name:  "caladea, the lyric",
+			file:  "caladea.ttf",
+			text:  "Suci, suci, suci",
+			kept:  []rune("Suci,"),
+			empty: []rune("XYZ"),
+		},
+		{
+			name:  "figtree, the numbered notes",
+			file:  "figtree.ttf",
+			text:  "1234567.",
+			kept:  []rune("1234567"),
+			empty: []rune("ABC"),
+		},
+		{
+			name:  "old standard, the title",
+			file:  "old-standard-tt.ttf",
+			text:  "KJ 001",
+			kept:  []rune("KJ01"),
+			empty: []rune("abc"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := os.ReadFile("../../files/var/www/fonts/" + tt.file)
+			assert.NoError(t, err)
+
+			got, err := Subset(raw, []rune(tt.text))
+			assert.NoError(t, err)
+			assert.Less(t, len(got), len(raw))
+			assert.Equal(t, uint32(0xB1B0AFBA), checksum(got))
+
+			f, err := sfnt.Parse(got)
+			assert.NoError(t, err)
+			original, err := sfnt.Parse(raw)
+			assert.NoError(t, err)
+
+			var buf sfnt.Buffer
+			for _, r := range tt.kept {
+				gid, err := f.GlyphIndex(&buf, r)
+				assert.NoError(t, err)
+				segments, err := f.LoadGlyph(&buf, gid, 1000, nil)
+				assert.NoError(t, err)
+				assert.NotEmpty(t, segments, string(r))
+
+				originalGID, _ := original.GlyphIndex(&buf, r)
+				assert.Equal(t, originalGID, gid)
+			}
+			for _, r := range tt.empty {
+				gid, err := f.GlyphIndex(&buf, r)
+				assert.NoError(t, err)
+				segments, err := f.LoadGlyph(&buf, gid, 1000, nil)
+				assert.NoError(t, err)
+				assert.Empty(t, segments, string(r))
+			}
+		})
+	}
+}
+
+func TestSubsetMozart(t *testing.T) {
+	// mozart11 has the short OS/2 table, the sfnt package could not parse it
+	raw, err := os.ReadFile("../../files/var/www/fonts/mozart11.ttf")
+	assert.NoError(t, err)
+
+	got, err := Subset(raw, []rune{0xF026})
+	assert.NoError(t, err)
+	assert.Less(t, len(got), len(raw))
+
+	tables, err := readTables(got)
+	assert.NoError(t, err)
+	offsets, err := readLoca(tables["loca"], int(tables["maxp"][4])<<8|int(tables["maxp"][5]), tables["head"][51] == 1)
+	assert.NoError(t, err)
+
+	treble, bass := glyphIndex(tables["cmap"], 0xF026), glyphIndex(tables["cmap"], 0xF025)
+	assert.NotZero(t, treble)
+	assert.NotZero(t, bass)
+	assert.NotEqual(t, offsets[treble], offsets[treble+1])
+	assert.Equal(t, offsets[bass], offsets[bass+1])
+}
+
+func TestSubsetInvalid(t *testing.T) {
+	_, err := Subset([]byte("not a font"), []rune("a"))
+	assert.ErrorIs(t, err, ErrInvalidFont)
+}
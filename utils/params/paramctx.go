@@ -11,6 +11,9 @@ type Param struct {
 	DisableGregorian bool
 	Verse            int
 	SingleVerseMode  bool
+	NumberedChord    bool
+	// the semitones (e.g. -2) or the root of the target key (e.g. Bb) the hymn is transposed to
+	Transpose string
 
 	Diagnostic   *DiagParam
 	Render       *RenderParam
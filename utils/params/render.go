@@ -1,5 +1,29 @@
 package params
 
+// Format is the output of the render other than the svg, the empty format is the svg
+type Format string
+
+const (
+	// the numbered notation as the monospace text
+	FormatText Format = "txt"
+	// the playback of the score as the standard midi file
+	FormatMIDI Format = "midi"
+	// the playback of all of the verses synthesized as the wav, with the instrument
+	FormatWAV Format = "wav"
+	// the time of every note on every verse as the json
+	FormatTiming Format = "json"
+)
+
 type RenderParam struct {
 	WhiteBackground bool
+
+	// EmbedFonts inlines the subset of the fonts into the svg, the fonts are read from the FontPath
+	EmbedFonts bool
+	FontPath   string
+
+	// Format writes the score on the other format instead of the svg
+	Format Format
+
+	// Instrument is the instrument of the wav (organ or piano)
+	Instrument string
 }